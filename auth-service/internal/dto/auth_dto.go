@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"shared/money"
+	"time"
+)
 
 // RegisterRequest merepresentasikan struktur body permintaan untuk pendaftaran pengguna baru.
 // Struct ini digunakan untuk binding data JSON dari permintaan HTTP.
@@ -21,7 +24,7 @@ type UserResponse struct {
 	Name      string    `json:"name"`
 	Email     string    `json:"email"`
 	Role      string    `json:"role"`
-	Saldo     float64   `json:"saldo"`
+	Saldo     money.Money `json:"saldo" swaggertype:"number"`
 	CreatedAt time.Time`json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"shared/money"
 )
 
 // User merepresentasikan entitas inti pengguna dalam lapisan domain.
@@ -17,7 +18,7 @@ type User struct {
 	Email     string         `gorm:"type:varchar(100);unique;not null" json:"email"` // Email unik
 	Password  string         `gorm:"type:varchar(255);not null" json:"-"`            // Kata sandi yang sudah di-hash
 	Role      string         `gorm:"type:varchar(255);not null" json:"role"`
-	Saldo     float64        `gorm:"type:decimal(12,2);default:0.00" json:"saldo"` // Saldo pengguna
+	Saldo     money.Money    `gorm:"type:decimal(12,2);default:0.00" json:"saldo"` // Saldo pengguna
 	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`             // Timestamp dibuat
 	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`             // Timestamp terakhir update
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
 	"book-service/internal/handler"
@@ -43,6 +44,7 @@ func main() {
 	mongoURI := os.Getenv("MONGO_URI")
 	dbName := os.Getenv("MONGO_DB")
 	port := os.Getenv("PORT") // PORT untuk server HTTP, bukan GRPC
+	defaultStock := os.Getenv("DEFAULT_BOOK_STOCK") // Stok awal untuk buku lama yang belum punya field stock
 
 	if mongoURI == "" {
 		log.Fatal("MONGO_URI environment variable is not set")
@@ -58,7 +60,8 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	// Registry khusus agar harga (money.Money) disimpan sebagai Decimal128
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI).SetRegistry(repository.NewRegistry()))
 	if err != nil {
 		log.Fatal("Failed to connect to MongoDB:", err)
 	}
@@ -70,12 +73,18 @@ func main() {
 	}()
 
 	bookCollection := client.Database(dbName).Collection("books")
+	reservationCollection := client.Database(dbName).Collection("reservations")
 
 	// 4. Inisialisasi Layer (Dependency Injection)
 	bookRepo := repository.NewBookRepository(bookCollection)
 	bookService := service.NewBookService(bookRepo)
 	bookHandler := handler.NewBookHandler(bookService)
 
+	stockRepo := repository.NewStockRepository(bookCollection, reservationCollection)
+	initStock(ctx, stockRepo, defaultStock)
+	stockService := service.NewStockService(stockRepo)
+	reservationHandler := handler.NewReservationHandler(stockService)
+
 	// 5. Setup HTTP Server & Routing
 	e := echo.New()
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
@@ -83,10 +92,40 @@ func main() {
 	e.Use(middleware.Recover())
 
 	// 6. Setup Route
-	routes.SetupRoutes(e, bookHandler)
+	routes.SetupRoutes(e, bookHandler, reservationHandler)
 
 	// 7. Jalankan Server
 	serverPort := ":" + port
 	fmt.Printf("Book Service with MongoDB is running on port %s\n", serverPort)
 	e.Logger.Fatal(e.Start(serverPort))
-}
\ No newline at end of file
+}
+
+// initStock mengisi stok buku lama yang belum punya field stock dengan DEFAULT_BOOK_STOCK.
+// Nilainya wajib diisi selama masih ada buku seperti itu, agar katalog lama tidak diam-diam
+// menjadi stok 0 dan tidak bisa dibeli.
+func initStock(ctx context.Context, stockRepo repository.StockRepository, value string) {
+	pending, err := stockRepo.CountUninitializedStock(ctx)
+	if err != nil {
+		log.Fatal("Failed to check book stock:", err)
+	}
+	if pending == 0 {
+		return
+	}
+
+	if value == "" {
+		log.Fatalf("DEFAULT_BOOK_STOCK environment variable is not set, but %d existing books have no stock", pending)
+	}
+	defaultStock, err := strconv.Atoi(value)
+	if err != nil || defaultStock < 0 {
+		log.Fatalf("Invalid DEFAULT_BOOK_STOCK %q: must be a non-negative integer", value)
+	}
+	if defaultStock == 0 {
+		log.Printf("Warning: DEFAULT_BOOK_STOCK is 0, %d existing books will not be purchasable until restocked", pending)
+	}
+
+	migrated, err := stockRepo.InitStock(ctx, defaultStock)
+	if err != nil {
+		log.Fatal("Failed to initialize book stock:", err)
+	}
+	log.Printf("Initialized stock=%d for %d existing books", defaultStock, migrated)
+}
@@ -1,28 +1,32 @@
 package dto
 
+import "shared/money"
+
 // CreateBookRequest adalah DTO untuk membuat buku baru.
 // Tidak ada ID, Status, atau CreatedAt karena itu diatur oleh server.
 type CreateBookRequest struct {
-	Title          string  `json:"title" validate:"required"`
-	Author         string  `json:"author" validate:"required"`
-	Publisher      string  `json:"publisher"`
-	YearPublished  int     `json:"year_published"`
-	Category       string  `json:"category"`
-	Price          float64 `json:"price" validate:"gte=0"`
-	IsDonationOnly bool    `json:"is_donation_only"`
-	Description    string  `json:"description"`
+	Title          string      `json:"title" validate:"required"`
+	Author         string      `json:"author" validate:"required"`
+	Publisher      string      `json:"publisher"`
+	YearPublished  int         `json:"year_published"`
+	Category       string      `json:"category"`
+	Price          money.Money `json:"price" validate:"gte=0" swaggertype:"number"`
+	Stock          int         `json:"stock" validate:"gte=0"` // Jumlah eksemplar awal
+	IsDonationOnly bool        `json:"is_donation_only"`
+	Description    string      `json:"description"`
 }
 
 // UpdateBookRequest adalah DTO untuk memperbarui buku.
 // Mirip dengan Create, tapi semua field bisa jadi opsional tergantung logika bisnis.
 type UpdateBookRequest struct {
-	Title          string  `json:"title" validate:"required"`
-	Author         string  `json:"author" validate:"required"`
-	Publisher      string  `json:"publisher"`
-	YearPublished  int     `json:"year_published"`
-	Category       string  `json:"category"`
-	Price          float64 `json:"price" validate:"gte=0"`
-	Status         string  `json:"status" validate:"oneof=available unavailable"` // Validasi status
-	IsDonationOnly bool    `json:"is_donation_only"`
-	Description    string  `json:"description"`
-}
\ No newline at end of file
+	Title          string      `json:"title" validate:"required"`
+	Author         string      `json:"author" validate:"required"`
+	Publisher      string      `json:"publisher"`
+	YearPublished  int         `json:"year_published"`
+	Category       string      `json:"category"`
+	Price          money.Money `json:"price" validate:"gte=0" swaggertype:"number"`
+	Status         string      `json:"status" validate:"oneof=available unavailable"` // Validasi status
+	Stock          *int        `json:"stock,omitempty" validate:"omitempty,gte=0"`    // Kosongkan jika stok tidak diubah
+	IsDonationOnly bool        `json:"is_donation_only"`
+	Description    string      `json:"description"`
+}
@@ -1,21 +1,26 @@
 package dto
 
-import "time"
+import (
+	"shared/money"
+	"time"
+)
 
 // BookResponse adalah DTO untuk data buku yang dikirim ke klien.
 // ID di sini adalah string agar mudah dikonsumsi oleh JSON.
 type BookResponse struct {
-	ID             string    `json:"id"`
-	Title          string    `json:"title"`
-	Author         string    `json:"author"`
-	Publisher      string    `json:"publisher"`
-	YearPublished  int       `json:"year_published"`
-	Category       string    `json:"category"`
-	Price          float64   `json:"price"`
-	Status         string    `json:"status"`
-	IsDonationOnly bool      `json:"is_donation_only"`
-	Description    string    `json:"description"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID             string      `json:"id"`
+	Title          string      `json:"title"`
+	Author         string      `json:"author"`
+	Publisher      string      `json:"publisher"`
+	YearPublished  int         `json:"year_published"`
+	Category       string      `json:"category"`
+	Price          money.Money `json:"price" swaggertype:"number"`
+	Status         string      `json:"status"`
+	Stock          int         `json:"stock"`
+	Reserved       int         `json:"reserved"`
+	IsDonationOnly bool        `json:"is_donation_only"`
+	Description    string      `json:"description"`
+	CreatedAt      time.Time   `json:"created_at"`
 }
 
 type DeleteResponse struct {
@@ -24,13 +29,13 @@ type DeleteResponse struct {
 }
 
 type BookCreateResponse struct {
-	StatusCode int              `json:"status_code" validate:"required" example:"201"`
-	Message    string           `json:"message" validate:"required" example:"Create user success"`
-	Data       BookResponse 	`json:"data"`
+	StatusCode int          `json:"status_code" validate:"required" example:"201"`
+	Message    string       `json:"message" validate:"required" example:"Create user success"`
+	Data       BookResponse `json:"data"`
 }
 
 type BookGetResponse struct {
-	StatusCode int              `json:"status_code" validate:"required" example:"201"`
-	Message    string           `json:"message" validate:"required" example:"Create user success"`
+	StatusCode int            `json:"status_code" validate:"required" example:"201"`
+	Message    string         `json:"message" validate:"required" example:"Create user success"`
 	Data       []BookResponse `json:"data"`
 }
@@ -11,6 +11,7 @@ func (r *CreateBookRequest) ToBookModel() *model.Book {
 		YearPublished:  r.YearPublished,
 		Category:       r.Category,
 		Price:          r.Price,
+		Stock:          r.Stock,
 		IsDonationOnly: r.IsDonationOnly,
 		Description:    r.Description,
 	}
@@ -42,6 +43,8 @@ func ToBookResponse(book model.Book) BookResponse {
 		Category:       book.Category,
 		Price:          book.Price,
 		Status:         book.Status,
+		Stock:          book.Stock,
+		Reserved:       book.Reserved,
 		IsDonationOnly: book.IsDonationOnly,
 		Description:    book.Description,
 		CreatedAt:      book.CreatedAt,
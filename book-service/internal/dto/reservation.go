@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"book-service/internal/model"
+)
+
+// ReserveStockRequest adalah DTO untuk menahan stok beberapa buku sekaligus.
+// ReservationID ditentukan oleh pemanggil (transaction-service) agar permintaan yang diulang aman.
+type ReserveStockRequest struct {
+	ReservationID string             `json:"reservation_id" validate:"required"`
+	Items         []ReserveStockItem `json:"items" validate:"required"`
+}
+
+type ReserveStockItem struct {
+	BookID   string `json:"book_id" validate:"required"`
+	Quantity int    `json:"quantity" validate:"gt=0"`
+}
+
+// ReservationResponse adalah DTO untuk data reservasi yang dikirim ke klien.
+type ReservationResponse struct {
+	ID        string             `json:"id"`
+	Status    string             `json:"status"`
+	Items     []ReserveStockItem `json:"items"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type ReservationApiResponse struct {
+	StatusCode int                 `json:"status_code" validate:"required" example:"200"`
+	Message    string              `json:"message" validate:"required" example:"Stock reserved successfully"`
+	Data       ReservationResponse `json:"data"`
+}
+
+// ToReservationResponse mengubah model reservasi menjadi DTO response.
+func ToReservationResponse(reservation model.Reservation) ReservationResponse {
+	items := make([]ReserveStockItem, len(reservation.Items))
+	for i, item := range reservation.Items {
+		items[i] = ReserveStockItem{BookID: item.BookID.Hex(), Quantity: item.Quantity}
+	}
+	return ReservationResponse{
+		ID:        reservation.ID,
+		Status:    reservation.Status,
+		Items:     items,
+		CreatedAt: reservation.CreatedAt,
+	}
+}
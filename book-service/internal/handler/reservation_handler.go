@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"book-service/internal/dto"
+	"book-service/internal/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ReservationHandler menangani endpoint reservasi stok yang dipakai transaction-service
+type ReservationHandler struct {
+	service service.StockService
+}
+
+func NewReservationHandler(service service.StockService) *ReservationHandler {
+	return &ReservationHandler{service: service}
+}
+
+// ReserveStock godoc
+// @Summary Reserve stock
+// @Description Hold stock for all items of a transaction, or none of them if any book is short
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param request body dto.ReserveStockRequest true "Items to reserve"
+// @Success 201 {object} dto.ReservationApiResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
+// @Router /reservations [post]
+func (h *ReservationHandler) ReserveStock(c echo.Context) error {
+	var req dto.ReserveStockRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+
+	reservation, err := h.service.Reserve(c.Request().Context(), req)
+	if err != nil {
+		return reservationError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.ReservationApiResponse{
+		StatusCode: http.StatusCreated,
+		Message:    "Stock reserved successfully",
+		Data:       *reservation,
+	})
+}
+
+// CommitReservation godoc
+// @Summary Commit a reservation
+// @Description Mark reserved stock as sold after the payment succeeded
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} dto.ReservationApiResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /reservations/{id}/commit [post]
+func (h *ReservationHandler) CommitReservation(c echo.Context) error {
+	reservation, err := h.service.Commit(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return reservationError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.ReservationApiResponse{
+		StatusCode: http.StatusOK,
+		Message:    "Reservation committed successfully",
+		Data:       *reservation,
+	})
+}
+
+// ReleaseReservation godoc
+// @Summary Release a reservation
+// @Description Return reserved stock after the payment failed or the transaction was cancelled
+// @Tags reservations
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} dto.ReservationApiResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /reservations/{id}/release [post]
+func (h *ReservationHandler) ReleaseReservation(c echo.Context) error {
+	reservation, err := h.service.Release(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return reservationError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.ReservationApiResponse{
+		StatusCode: http.StatusOK,
+		Message:    "Reservation released successfully",
+		Data:       *reservation,
+	})
+}
+
+// reservationError menerjemahkan error dari StockService ke HTTP status code
+func reservationError(c echo.Context, err error) error {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrInvalidReservation):
+		code = http.StatusBadRequest
+	case errors.Is(err, service.ErrReservationNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, service.ErrInsufficientStock), errors.Is(err, service.ErrReservationClosed):
+		code = http.StatusConflict
+	case errors.Is(err, service.ErrReservationPending):
+		code = http.StatusServiceUnavailable
+	}
+	return c.JSON(code, dto.ErrorResponse{
+		Code:    code,
+		Message: http.StatusText(code),
+		Details: err.Error(),
+	})
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"shared/money"
 	"time"
 	// Import package BSON dari driver MongoDB
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,8 +16,11 @@ type Book struct {
 	Publisher      string             `json:"publisher" bson:"publisher"`
 	YearPublished  int                `json:"year_published" bson:"year_published"`
 	Category       string             `json:"category" bson:"category"`
-	Price          float64            `json:"price" bson:"price"`
+	Price          money.Money        `json:"price" bson:"price"` // Disimpan sebagai Decimal128
 	Status         string             `json:"status" bson:"status"`
+	Stock          int                `json:"stock" bson:"stock"`       // Eksemplar yang masih bisa dibeli
+	Reserved       int                `json:"reserved" bson:"reserved"` // Eksemplar yang ditahan untuk transaksi pending
+	Holds          []string           `json:"-" bson:"holds,omitempty"` // ID reservasi yang sedang menahan stok buku ini
 	IsDonationOnly bool               `json:"is_donation_only" bson:"is_donation_only"`
 	Description    string             `json:"description" bson:"description"`
 	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status reservasi stok.
+const (
+	ReservationStatusPending    = "pending" // Stok sedang dipotong
+	ReservationStatusReserved   = "reserved"
+	ReservationStatusCommitting = "committing" // Stok sedang ditandai terjual
+	ReservationStatusCommitted  = "committed"
+	ReservationStatusReleasing  = "releasing" // Stok sedang dikembalikan
+	ReservationStatusReleased   = "released"
+)
+
+// ReservationItem adalah jumlah eksemplar satu buku yang ditahan.
+type ReservationItem struct {
+	BookID   primitive.ObjectID `json:"book_id" bson:"book_id"`
+	Quantity int                `json:"quantity" bson:"quantity"`
+}
+
+// Reservation menahan stok beberapa buku untuk satu transaksi.
+// ID ditentukan oleh pemanggil sehingga permintaan yang diulang tidak memotong stok dua kali.
+type Reservation struct {
+	ID        string            `json:"id" bson:"_id"`
+	Items     []ReservationItem `json:"items" bson:"items"`
+	Status    string            `json:"status" bson:"status"`
+	CreatedAt time.Time         `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at" bson:"updated_at"`
+}
@@ -15,6 +15,7 @@ type BookRepository interface {
 	FindAll(ctx context.Context) ([]model.Book, error)
 	FindByID(ctx context.Context, id primitive.ObjectID) (*model.Book, error)
 	Update(ctx context.Context, book *model.Book) error
+	SetStock(ctx context.Context, id primitive.ObjectID, stock int) error
 	Delete(ctx context.Context, id primitive.ObjectID) error
 }
 
@@ -62,16 +63,33 @@ func (r *bookRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*
 	return &book, nil
 }
 
-// Update memperbarui dokumen buku yang ada
+// Update memperbarui data katalog buku yang ada.
+// Field stock dan reserved tidak ikut ditimpa agar tidak bentrok dengan reservasi yang sedang berjalan.
 func (r *bookRepository) Update(ctx context.Context, book *model.Book) error {
 	filter := bson.M{"_id": book.ID}
-	// bson.M{"$set": book} akan memperbarui semua field di dokumen
-	update := bson.M{"$set": book}
+	update := bson.M{"$set": bson.M{
+		"title":            book.Title,
+		"author":           book.Author,
+		"publisher":        book.Publisher,
+		"year_published":   book.YearPublished,
+		"category":         book.Category,
+		"price":            book.Price,
+		"status":           book.Status,
+		"is_donation_only": book.IsDonationOnly,
+		"description":      book.Description,
+		"created_at":       book.CreatedAt,
+	}}
 
 	_, err := r.collection.UpdateOne(ctx, filter, update)
 	return err
 }
 
+// SetStock mengganti jumlah eksemplar yang bisa dibeli (misalnya setelah restock)
+func (r *bookRepository) SetStock(ctx context.Context, id primitive.ObjectID, stock int) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"stock": stock}})
+	return err
+}
+
 // Delete menghapus dokumen buku berdasarkan ID
 func (r *bookRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
 	filter := bson.M{"_id": id}
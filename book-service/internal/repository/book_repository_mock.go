@@ -48,6 +48,12 @@ func (m *MockBookRepository) Update(ctx context.Context, book *model.Book) error
 	return args.Error(0)
 }
 
+// SetStock adalah implementasi mock untuk mengganti stok buku.
+func (m *MockBookRepository) SetStock(ctx context.Context, id primitive.ObjectID, stock int) error {
+	args := m.Called(ctx, id, stock)
+	return args.Error(0)
+}
+
 // Delete adalah implementasi mock untuk menghapus buku.
 func (m *MockBookRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
 	args := m.Called(ctx, id)
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"shared/money"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var moneyType = reflect.TypeOf(money.Money(0))
+
+// NewRegistry membuat BSON registry yang menyimpan money.Money sebagai Decimal128 (eksak).
+// Dokumen lama yang masih menyimpan harga sebagai double atau integer tetap bisa dibaca.
+func NewRegistry() *bsoncodec.Registry {
+	registry := bson.NewRegistry()
+	registry.RegisterTypeEncoder(moneyType, bsoncodec.ValueEncoderFunc(encodeMoney))
+	registry.RegisterTypeDecoder(moneyType, bsoncodec.ValueDecoderFunc(decodeMoney))
+	return registry
+}
+
+func encodeMoney(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != moneyType {
+		return bsoncodec.ValueEncoderError{Name: "MoneyEncodeValue", Types: []reflect.Type{moneyType}, Received: val}
+	}
+	d, err := primitive.ParseDecimal128(money.Money(val.Int()).String())
+	if err != nil {
+		return err
+	}
+	return vw.WriteDecimal128(d)
+}
+
+func decodeMoney(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != moneyType {
+		return bsoncodec.ValueDecoderError{Name: "MoneyDecodeValue", Types: []reflect.Type{moneyType}, Received: val}
+	}
+
+	var m money.Money
+	switch vr.Type() {
+	case bsontype.Decimal128:
+		d, err := vr.ReadDecimal128()
+		if err != nil {
+			return err
+		}
+		m, err = decimalToMoney(d)
+		if err != nil {
+			return err
+		}
+	case bsontype.Double:
+		// Format lama: harga disimpan sebagai float64
+		f, err := vr.ReadDouble()
+		if err != nil {
+			return err
+		}
+		m = money.FromFloat(f)
+	case bsontype.Int32:
+		i, err := vr.ReadInt32()
+		if err != nil {
+			return err
+		}
+		m = money.FromRupiah(int64(i))
+	case bsontype.Int64:
+		i, err := vr.ReadInt64()
+		if err != nil {
+			return err
+		}
+		m = money.FromRupiah(i)
+	case bsontype.String:
+		s, err := vr.ReadString()
+		if err != nil {
+			return err
+		}
+		m, err = money.Parse(s)
+		if err != nil {
+			return err
+		}
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("cannot decode %v into money.Money", vr.Type())
+	}
+
+	val.SetInt(int64(m))
+	return nil
+}
+
+// decimalToMoney mengubah Decimal128 menjadi satuan terkecil tanpa melewati float.
+func decimalToMoney(d primitive.Decimal128) (money.Money, error) {
+	coefficient, exp, err := d.BigInt()
+	if err != nil {
+		return 0, err
+	}
+
+	// nilai = coefficient * 10^exp, satuan terkecil = nilai * 10^Scale
+	shift := exp + money.Scale
+	minor := new(big.Int).Set(coefficient)
+	if shift >= 0 {
+		minor.Mul(minor, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(shift)), nil))
+	} else {
+		divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-shift)), nil)
+		remainder := new(big.Int)
+		minor.QuoRem(minor, divisor, remainder)
+		if remainder.Sign() != 0 {
+			return 0, money.ErrTooManyDecimals
+		}
+	}
+	if !minor.IsInt64() {
+		return 0, fmt.Errorf("decimal %s out of range for money.Money", d.String())
+	}
+	return money.FromMinor(minor.Int64()), nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"shared/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type pricedDoc struct {
+	Price money.Money `bson:"price"`
+}
+
+// Tes bahwa harga disimpan sebagai Decimal128 dan terbaca kembali secara eksak
+func TestMoneyCodec_RoundTrip(t *testing.T) {
+	registry := NewRegistry()
+	price, _ := money.Parse("15000.50")
+
+	data, err := bson.MarshalWithRegistry(registry, pricedDoc{Price: price})
+	assert.NoError(t, err)
+
+	var raw bson.M
+	assert.NoError(t, bson.Unmarshal(data, &raw))
+	assert.Equal(t, "15000.50", raw["price"].(interface{ String() string }).String())
+
+	var decoded pricedDoc
+	assert.NoError(t, bson.UnmarshalWithRegistry(registry, data, &decoded))
+	assert.Equal(t, price, decoded.Price)
+}
+
+// Tes bahwa dokumen lama dengan harga double atau integer tetap terbaca
+func TestMoneyCodec_LegacyValues(t *testing.T) {
+	registry := NewRegistry()
+
+	cases := map[string]struct {
+		doc      bson.M
+		expected money.Money
+	}{
+		"double": {bson.M{"price": 19999.99}, money.FromMinor(1999999)},
+		"int32":  {bson.M{"price": int32(50000)}, money.FromRupiah(50000)},
+		"int64":  {bson.M{"price": int64(75000)}, money.FromRupiah(75000)},
+		"string": {bson.M{"price": "1250.5"}, money.FromMinor(125050)},
+	}
+	for name, tc := range cases {
+		data, err := bson.Marshal(tc.doc)
+		assert.NoError(t, err, name)
+
+		var decoded pricedDoc
+		assert.NoError(t, bson.UnmarshalWithRegistry(registry, data, &decoded), name)
+		assert.Equal(t, tc.expected, decoded.Price, name)
+	}
+}
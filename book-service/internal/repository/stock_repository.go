@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"book-service/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// StockRepository mendefinisikan operasi stok buku dan reservasinya.
+// Setiap perubahan stok adalah satu update atomik pada dokumen buku,
+// sehingga dua pembeli tidak bisa mengambil eksemplar terakhir yang sama.
+// Dokumen buku juga mencatat ID reservasi yang sedang menahan stoknya (field holds),
+// sehingga commit dan release untuk reservasi yang sama hanya berlaku sekali per buku.
+type StockRepository interface {
+	CreateReservation(ctx context.Context, reservation *model.Reservation) (bool, error)
+	FindReservation(ctx context.Context, id string) (*model.Reservation, error)
+	UpdateReservationStatus(ctx context.Context, id, fromStatus, toStatus string) (bool, error)
+	ReserveStock(ctx context.Context, bookID primitive.ObjectID, reservationID string, quantity int) (bool, error)
+	CommitStock(ctx context.Context, bookID primitive.ObjectID, reservationID string, quantity int) error
+	ReleaseStock(ctx context.Context, bookID primitive.ObjectID, reservationID string, quantity int) error
+	CountUninitializedStock(ctx context.Context) (int64, error)
+	InitStock(ctx context.Context, defaultStock int) (int64, error)
+}
+
+type stockRepository struct {
+	books        *mongo.Collection
+	reservations *mongo.Collection
+}
+
+func NewStockRepository(books, reservations *mongo.Collection) StockRepository {
+	return &stockRepository{books: books, reservations: reservations}
+}
+
+// CreateReservation menyimpan reservasi baru. Nilai bool bernilai false jika
+// reservasi dengan ID yang sama sudah ada.
+func (r *stockRepository) CreateReservation(ctx context.Context, reservation *model.Reservation) (bool, error) {
+	_, err := r.reservations.InsertOne(ctx, reservation)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// FindReservation mencari reservasi berdasarkan ID, (nil, nil) jika tidak ada
+func (r *stockRepository) FindReservation(ctx context.Context, id string) (*model.Reservation, error) {
+	var reservation model.Reservation
+	err := r.reservations.FindOne(ctx, bson.M{"_id": id}).Decode(&reservation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// UpdateReservationStatus mengubah status reservasi hanya jika status saat ini masih fromStatus
+func (r *stockRepository) UpdateReservationStatus(ctx context.Context, id, fromStatus, toStatus string) (bool, error) {
+	result, err := r.reservations.UpdateOne(ctx,
+		bson.M{"_id": id, "status": fromStatus},
+		bson.M{"$set": bson.M{"status": toStatus, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// ReserveStock memindahkan quantity eksemplar dari stock ke reserved jika stoknya cukup,
+// sekaligus mencatat reservationID di daftar holds buku.
+// Nilai bool bernilai false jika buku tidak tersedia atau stoknya kurang.
+func (r *stockRepository) ReserveStock(ctx context.Context, bookID primitive.ObjectID, reservationID string, quantity int) (bool, error) {
+	result, err := r.books.UpdateOne(ctx,
+		bson.M{"_id": bookID, "status": "available", "stock": bson.M{"$gte": quantity}, "holds": bson.M{"$ne": reservationID}},
+		bson.M{"$inc": bson.M{"stock": -quantity, "reserved": quantity}, "$push": bson.M{"holds": reservationID}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// CommitStock menandai eksemplar yang ditahan reservationID sebagai terjual.
+// Tidak mengubah apa pun jika reservasi sudah tidak menahan stok buku ini.
+func (r *stockRepository) CommitStock(ctx context.Context, bookID primitive.ObjectID, reservationID string, quantity int) error {
+	_, err := r.books.UpdateOne(ctx,
+		bson.M{"_id": bookID, "holds": reservationID},
+		bson.M{"$inc": bson.M{"reserved": -quantity}, "$pull": bson.M{"holds": reservationID}},
+	)
+	return err
+}
+
+// ReleaseStock mengembalikan eksemplar yang ditahan reservationID ke stok yang bisa dibeli.
+// Seperti CommitStock, aman dipanggil ulang.
+func (r *stockRepository) ReleaseStock(ctx context.Context, bookID primitive.ObjectID, reservationID string, quantity int) error {
+	_, err := r.books.UpdateOne(ctx,
+		bson.M{"_id": bookID, "holds": reservationID},
+		bson.M{"$inc": bson.M{"stock": quantity, "reserved": -quantity}, "$pull": bson.M{"holds": reservationID}},
+	)
+	return err
+}
+
+// CountUninitializedStock menghitung dokumen buku lama yang belum memiliki field stock
+func (r *stockRepository) CountUninitializedStock(ctx context.Context) (int64, error) {
+	return r.books.CountDocuments(ctx, bson.M{"stock": bson.M{"$exists": false}})
+}
+
+// InitStock mengisi field stock dan reserved pada dokumen buku lama yang belum memilikinya
+func (r *stockRepository) InitStock(ctx context.Context, defaultStock int) (int64, error) {
+	result, err := r.books.UpdateMany(ctx,
+		bson.M{"stock": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"stock": defaultStock, "reserved": 0}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
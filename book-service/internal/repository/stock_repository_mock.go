@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"book-service/internal/model"
+
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockStockRepository adalah implementasi mock dari StockRepository.
+type MockStockRepository struct {
+	mock.Mock
+}
+
+func (m *MockStockRepository) CreateReservation(ctx context.Context, reservation *model.Reservation) (bool, error) {
+	args := m.Called(ctx, reservation)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStockRepository) FindReservation(ctx context.Context, id string) (*model.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Reservation), args.Error(1)
+}
+
+func (m *MockStockRepository) UpdateReservationStatus(ctx context.Context, id, fromStatus, toStatus string) (bool, error) {
+	args := m.Called(ctx, id, fromStatus, toStatus)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStockRepository) ReserveStock(ctx context.Context, bookID primitive.ObjectID, reservationID string, quantity int) (bool, error) {
+	args := m.Called(ctx, bookID, reservationID, quantity)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStockRepository) CommitStock(ctx context.Context, bookID primitive.ObjectID, reservationID string, quantity int) error {
+	args := m.Called(ctx, bookID, reservationID, quantity)
+	return args.Error(0)
+}
+
+func (m *MockStockRepository) ReleaseStock(ctx context.Context, bookID primitive.ObjectID, reservationID string, quantity int) error {
+	args := m.Called(ctx, bookID, reservationID, quantity)
+	return args.Error(0)
+}
+
+func (m *MockStockRepository) CountUninitializedStock(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStockRepository) InitStock(ctx context.Context, defaultStock int) (int64, error) {
+	args := m.Called(ctx, defaultStock)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -9,11 +9,16 @@ import (
 // SetupRoutes mendaftarkan semua endpoint API untuk book-service.
 // Dengan tidak menggunakan group "/api", endpoint akan lebih sederhana dan
 // sesuai dengan yang diharapkan oleh gateway.
-func SetupRoutes(e *echo.Echo, bookHandler *handler.BookHandler) {
+func SetupRoutes(e *echo.Echo, bookHandler *handler.BookHandler, reservationHandler *handler.ReservationHandler) {
 	// Mendaftarkan endpoint langsung ke instance Echo 'e'
 	e.POST("/books", bookHandler.CreateBook)
 	e.GET("/books", bookHandler.GetAllBooks)
 	e.GET("/books/:id", bookHandler.GetBookByID)
 	e.PUT("/books/:id", bookHandler.UpdateBook)
 	e.DELETE("/books/:id", bookHandler.DeleteBook)
+
+	// Reservasi stok, dipanggil oleh transaction-service (tidak diekspos lewat gateway)
+	e.POST("/reservations", reservationHandler.ReserveStock)
+	e.POST("/reservations/:id/commit", reservationHandler.CommitReservation)
+	e.POST("/reservations/:id/release", reservationHandler.ReleaseReservation)
 }
\ No newline at end of file
@@ -37,6 +37,9 @@ func (s *bookService) CreateBook(ctx context.Context, req dto.CreateBookRequest)
 	if book.Title == "" {
 		return nil, errors.New("title cannot be empty")
 	}
+	if book.Stock < 0 {
+		return nil, errors.New("stock cannot be negative")
+	}
 	book.ID = primitive.NewObjectID()
 	book.Status = "available"
 	book.CreatedAt = time.Now()
@@ -96,15 +99,29 @@ func (s *bookService) UpdateBook(ctx context.Context, id string, req dto.UpdateB
 		return nil, errors.New("book not found")
 	}
 
+	if req.Stock != nil && *req.Stock < 0 {
+		return nil, errors.New("stock cannot be negative")
+	}
+
 	// Mapping dari DTO ke Model untuk update
 	updatedData := req.ToBookModel()
 	updatedData.ID = existingBook.ID
 	updatedData.CreatedAt = existingBook.CreatedAt
+	updatedData.Stock = existingBook.Stock
+	updatedData.Reserved = existingBook.Reserved
 
 	if err := s.repo.Update(ctx, updatedData); err != nil {
 		return nil, err
 	}
 
+	// Stok hanya diganti jika dikirim, sehingga edit katalog tidak mengubah stok
+	if req.Stock != nil {
+		if err := s.repo.SetStock(ctx, existingBook.ID, *req.Stock); err != nil {
+			return nil, err
+		}
+		updatedData.Stock = *req.Stock
+	}
+
 	// Mapping dari Model yang sudah diupdate ke DTO Response
 	response := dto.ToBookResponse(*updatedData)
 	return &response, nil
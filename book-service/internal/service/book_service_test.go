@@ -135,6 +135,30 @@ func TestUpdateBook_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUpdateBook_SetsStockOnlyWhenProvided(t *testing.T) {
+	mockRepo := new(repository.MockBookRepository)
+	bookID := primitive.NewObjectID()
+	stock := 12
+	req := dto.UpdateBookRequest{Title: "Judul Diupdate", Stock: &stock}
+
+	mockBook := &model.Book{ID: bookID, Title: "Judul Lama", Stock: 3, Reserved: 1, CreatedAt: time.Now()}
+
+	// Arrange
+	mockRepo.On("FindByID", mock.Anything, bookID).Return(mockBook, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*model.Book")).Return(nil)
+	mockRepo.On("SetStock", mock.Anything, bookID, 12).Return(nil)
+	bookService := NewBookService(mockRepo)
+
+	// Act
+	result, err := bookService.UpdateBook(context.Background(), bookID.Hex(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 12, result.Stock)
+	assert.Equal(t, 1, result.Reserved)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestUpdateBook_NotFound(t *testing.T) {
 	mockRepo := new(repository.MockBookRepository)
 	bookID := primitive.NewObjectID()
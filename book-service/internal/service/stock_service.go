@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"book-service/internal/dto"
+	"book-service/internal/model"
+	"book-service/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Error yang dikembalikan StockService.
+var (
+	ErrInvalidReservation  = errors.New("invalid reservation request")
+	ErrInsufficientStock   = errors.New("insufficient stock")
+	ErrReservationNotFound = errors.New("reservation not found")
+	ErrReservationClosed   = errors.New("reservation is already closed")
+	ErrReservationPending  = errors.New("reservation is still being processed, retry later")
+)
+
+// StockService mengatur reservasi stok: reserve saat transaksi dibuat,
+// commit saat pembayaran berhasil, dan release saat pembayaran gagal atau dibatalkan.
+type StockService interface {
+	Reserve(ctx context.Context, req dto.ReserveStockRequest) (*dto.ReservationResponse, error)
+	Commit(ctx context.Context, reservationID string) (*dto.ReservationResponse, error)
+	Release(ctx context.Context, reservationID string) (*dto.ReservationResponse, error)
+}
+
+type stockService struct {
+	repo repository.StockRepository
+}
+
+func NewStockService(repo repository.StockRepository) StockService {
+	return &stockService{repo: repo}
+}
+
+// Reserve menahan stok semua item atau tidak sama sekali. Jika salah satu buku
+// stoknya kurang, stok yang sudah terlanjur ditahan dikembalikan. Item dengan buku yang
+// sama digabung karena stok setiap buku hanya bisa ditahan sekali per reservasi.
+// Memanggil ulang dengan reservation_id yang sama mengembalikan reservasi yang sudah ada,
+// kecuali jika stoknya masih dipotong oleh percobaan sebelumnya (ErrReservationPending).
+func (s *stockService) Reserve(ctx context.Context, req dto.ReserveStockRequest) (*dto.ReservationResponse, error) {
+	if req.ReservationID == "" || len(req.Items) == 0 {
+		return nil, ErrInvalidReservation
+	}
+
+	now := time.Now()
+	reservation := &model.Reservation{
+		ID:        req.ReservationID,
+		Status:    model.ReservationStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	positions := make(map[primitive.ObjectID]int, len(req.Items))
+	for _, item := range req.Items {
+		bookID, err := primitive.ObjectIDFromHex(item.BookID)
+		if err != nil || item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: invalid item for book %q", ErrInvalidReservation, item.BookID)
+		}
+		if i, ok := positions[bookID]; ok {
+			reservation.Items[i].Quantity += item.Quantity
+			continue
+		}
+		positions[bookID] = len(reservation.Items)
+		reservation.Items = append(reservation.Items, model.ReservationItem{BookID: bookID, Quantity: item.Quantity})
+	}
+
+	created, err := s.repo.CreateReservation(ctx, reservation)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		existing, err := s.repo.FindReservation(ctx, req.ReservationID)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, ErrReservationNotFound
+		}
+		switch existing.Status {
+		case model.ReservationStatusReserved, model.ReservationStatusCommitting, model.ReservationStatusCommitted:
+			response := dto.ToReservationResponse(*existing)
+			return &response, nil
+		case model.ReservationStatusPending:
+			// Percobaan sebelumnya masih berjalan atau berhenti di tengah jalan, stok belum tentu ditahan
+			return nil, ErrReservationPending
+		default:
+			return nil, ErrReservationClosed
+		}
+	}
+
+	for i, item := range reservation.Items {
+		ok, err := s.repo.ReserveStock(ctx, item.BookID, reservation.ID, item.Quantity)
+		if err == nil && !ok {
+			err = fmt.Errorf("%w for book %s", ErrInsufficientStock, item.BookID.Hex())
+		}
+		if err != nil {
+			s.rollback(ctx, reservation, reservation.Items[:i])
+			return nil, err
+		}
+	}
+
+	if _, err := s.repo.UpdateReservationStatus(ctx, reservation.ID, model.ReservationStatusPending, model.ReservationStatusReserved); err != nil {
+		s.rollback(ctx, reservation, reservation.Items)
+		return nil, err
+	}
+
+	reservation.Status = model.ReservationStatusReserved
+	response := dto.ToReservationResponse(*reservation)
+	return &response, nil
+}
+
+// Commit menandai stok yang ditahan sebagai terjual.
+func (s *stockService) Commit(ctx context.Context, reservationID string) (*dto.ReservationResponse, error) {
+	return s.close(ctx, reservationID, model.ReservationStatusCommitting, model.ReservationStatusCommitted, s.repo.CommitStock)
+}
+
+// Release mengembalikan stok yang ditahan agar bisa dibeli orang lain.
+func (s *stockService) Release(ctx context.Context, reservationID string) (*dto.ReservationResponse, error) {
+	return s.close(ctx, reservationID, model.ReservationStatusReleasing, model.ReservationStatusReleased, s.repo.ReleaseStock)
+}
+
+// close memindahkan reservasi dari reserved ke closingStatus, menyesuaikan stok tiap buku, lalu
+// menandainya toStatus. Perubahan status yang atomik mencegah commit dan release berjalan untuk
+// reservasi yang sama. Jika penyesuaian stok gagal, reservasi tetap di closingStatus sehingga
+// pemanggilan ulang melanjutkannya; buku yang sudah disesuaikan tidak disesuaikan dua kali.
+func (s *stockService) close(ctx context.Context, reservationID, closingStatus, toStatus string, adjust func(context.Context, primitive.ObjectID, string, int) error) (*dto.ReservationResponse, error) {
+	reservation, err := s.repo.FindReservation(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if reservation == nil {
+		return nil, ErrReservationNotFound
+	}
+
+	if reservation.Status == model.ReservationStatusReserved {
+		updated, err := s.repo.UpdateReservationStatus(ctx, reservationID, model.ReservationStatusReserved, closingStatus)
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			reservation.Status = closingStatus
+		} else {
+			// Status diubah pemanggil lain sejak dibaca
+			if reservation, err = s.repo.FindReservation(ctx, reservationID); err != nil {
+				return nil, err
+			}
+			if reservation == nil {
+				return nil, ErrReservationNotFound
+			}
+		}
+	}
+
+	switch reservation.Status {
+	case toStatus:
+		// Pemanggilan ulang untuk status yang sama dianggap berhasil
+		response := dto.ToReservationResponse(*reservation)
+		return &response, nil
+	case closingStatus:
+	default:
+		return nil, ErrReservationClosed
+	}
+
+	for _, item := range reservation.Items {
+		if err := adjust(ctx, item.BookID, reservationID, item.Quantity); err != nil {
+			log.Printf("CRITICAL: Failed to adjust stock of book %s for reservation %s: %v", item.BookID.Hex(), reservationID, err)
+			return nil, err
+		}
+	}
+
+	if _, err := s.repo.UpdateReservationStatus(ctx, reservationID, closingStatus, toStatus); err != nil {
+		return nil, err
+	}
+
+	reservation.Status = toStatus
+	response := dto.ToReservationResponse(*reservation)
+	return &response, nil
+}
+
+// rollback mengembalikan stok item yang sudah terlanjur ditahan dan menutup reservasi.
+func (s *stockService) rollback(ctx context.Context, reservation *model.Reservation, items []model.ReservationItem) {
+	for _, item := range items {
+		if err := s.repo.ReleaseStock(ctx, item.BookID, reservation.ID, item.Quantity); err != nil {
+			log.Printf("CRITICAL: Failed to roll back stock of book %s for reservation %s: %v", item.BookID.Hex(), reservation.ID, err)
+		}
+	}
+	if _, err := s.repo.UpdateReservationStatus(ctx, reservation.ID, model.ReservationStatusPending, model.ReservationStatusReleased); err != nil {
+		log.Printf("Failed to close reservation %s: %v", reservation.ID, err)
+	}
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"book-service/internal/dto"
+	"book-service/internal/model"
+	"book-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// --- Test Reserve ---
+
+func TestReserve_Success(t *testing.T) {
+	mockRepo := new(repository.MockStockRepository)
+	bookA, bookB := primitive.NewObjectID(), primitive.NewObjectID()
+	req := dto.ReserveStockRequest{
+		ReservationID: "res-1",
+		Items:         []dto.ReserveStockItem{{BookID: bookA.Hex(), Quantity: 2}, {BookID: bookB.Hex(), Quantity: 1}},
+	}
+
+	// Arrange
+	mockRepo.On("CreateReservation", mock.Anything, mock.AnythingOfType("*model.Reservation")).Return(true, nil)
+	mockRepo.On("ReserveStock", mock.Anything, bookA, "res-1", 2).Return(true, nil)
+	mockRepo.On("ReserveStock", mock.Anything, bookB, "res-1", 1).Return(true, nil)
+	mockRepo.On("UpdateReservationStatus", mock.Anything, "res-1", model.ReservationStatusPending, model.ReservationStatusReserved).Return(true, nil)
+	stockService := NewStockService(mockRepo)
+
+	// Act
+	result, err := stockService.Reserve(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReservationStatusReserved, result.Status)
+	assert.Len(t, result.Items, 2)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReserve_MergesDuplicateBooks(t *testing.T) {
+	mockRepo := new(repository.MockStockRepository)
+	bookA, bookB := primitive.NewObjectID(), primitive.NewObjectID()
+	req := dto.ReserveStockRequest{
+		ReservationID: "res-1",
+		Items: []dto.ReserveStockItem{
+			{BookID: bookA.Hex(), Quantity: 1}, {BookID: bookB.Hex(), Quantity: 1}, {BookID: bookA.Hex(), Quantity: 2},
+		},
+	}
+
+	// Arrange: buku yang muncul di dua baris ditahan sekali dengan jumlah gabungan
+	mockRepo.On("CreateReservation", mock.Anything, mock.MatchedBy(func(r *model.Reservation) bool {
+		return len(r.Items) == 2 && r.Items[0].BookID == bookA && r.Items[0].Quantity == 3
+	})).Return(true, nil)
+	mockRepo.On("ReserveStock", mock.Anything, bookA, "res-1", 3).Return(true, nil).Once()
+	mockRepo.On("ReserveStock", mock.Anything, bookB, "res-1", 1).Return(true, nil).Once()
+	mockRepo.On("UpdateReservationStatus", mock.Anything, "res-1", model.ReservationStatusPending, model.ReservationStatusReserved).Return(true, nil)
+	stockService := NewStockService(mockRepo)
+
+	// Act
+	result, err := stockService.Reserve(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.ReserveStockItem{{BookID: bookA.Hex(), Quantity: 3}, {BookID: bookB.Hex(), Quantity: 1}}, result.Items)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReserve_InsufficientStockRollsBack(t *testing.T) {
+	mockRepo := new(repository.MockStockRepository)
+	bookA, bookB := primitive.NewObjectID(), primitive.NewObjectID()
+	req := dto.ReserveStockRequest{
+		ReservationID: "res-1",
+		Items:         []dto.ReserveStockItem{{BookID: bookA.Hex(), Quantity: 2}, {BookID: bookB.Hex(), Quantity: 1}},
+	}
+
+	// Arrange: buku kedua stoknya habis, stok buku pertama harus dikembalikan
+	mockRepo.On("CreateReservation", mock.Anything, mock.Anything).Return(true, nil)
+	mockRepo.On("ReserveStock", mock.Anything, bookA, "res-1", 2).Return(true, nil)
+	mockRepo.On("ReserveStock", mock.Anything, bookB, "res-1", 1).Return(false, nil)
+	mockRepo.On("ReleaseStock", mock.Anything, bookA, "res-1", 2).Return(nil)
+	mockRepo.On("UpdateReservationStatus", mock.Anything, "res-1", model.ReservationStatusPending, model.ReservationStatusReleased).Return(true, nil)
+	stockService := NewStockService(mockRepo)
+
+	// Act
+	result, err := stockService.Reserve(context.Background(), req)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+	assert.Nil(t, result)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "ReleaseStock", mock.Anything, bookB, mock.Anything, mock.Anything)
+}
+
+func TestReserve_Duplicate(t *testing.T) {
+	mockRepo := new(repository.MockStockRepository)
+	bookA := primitive.NewObjectID()
+	existing := &model.Reservation{ID: "res-1", Status: model.ReservationStatusReserved, Items: []model.ReservationItem{{BookID: bookA, Quantity: 1}}}
+
+	// Arrange: reservasi dengan ID yang sama sudah ada, stok tidak boleh dipotong lagi
+	mockRepo.On("CreateReservation", mock.Anything, mock.Anything).Return(false, nil)
+	mockRepo.On("FindReservation", mock.Anything, "res-1").Return(existing, nil)
+	stockService := NewStockService(mockRepo)
+
+	// Act
+	result, err := stockService.Reserve(context.Background(), dto.ReserveStockRequest{
+		ReservationID: "res-1",
+		Items:         []dto.ReserveStockItem{{BookID: bookA.Hex(), Quantity: 1}},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "res-1", result.ID)
+	mockRepo.AssertNotCalled(t, "ReserveStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReserve_PendingIsRetryable(t *testing.T) {
+	mockRepo := new(repository.MockStockRepository)
+	bookA := primitive.NewObjectID()
+	existing := &model.Reservation{ID: "res-1", Status: model.ReservationStatusPending, Items: []model.ReservationItem{{BookID: bookA, Quantity: 1}}}
+
+	// Arrange: percobaan pertama belum selesai memotong stok, belum boleh dilaporkan berhasil
+	mockRepo.On("CreateReservation", mock.Anything, mock.Anything).Return(false, nil)
+	mockRepo.On("FindReservation", mock.Anything, "res-1").Return(existing, nil)
+	stockService := NewStockService(mockRepo)
+
+	// Act
+	result, err := stockService.Reserve(context.Background(), dto.ReserveStockRequest{
+		ReservationID: "res-1",
+		Items:         []dto.ReserveStockItem{{BookID: bookA.Hex(), Quantity: 1}},
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrReservationPending)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "ReserveStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReserve_InvalidItem(t *testing.T) {
+	mockRepo := new(repository.MockStockRepository)
+	stockService := NewStockService(mockRepo)
+
+	_, err := stockService.Reserve(context.Background(), dto.ReserveStockRequest{
+		ReservationID: "res-1",
+		Items:         []dto.ReserveStockItem{{BookID: "bukan-id", Quantity: 1}},
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidReservation)
+	mockRepo.AssertNotCalled(t, "CreateReservation", mock.Anything, mock.Anything)
+}
+
+// --- Test Commit & Release ---
+
+func TestCommit_Success(t *testing.T) {
+	mockRepo := new(repository.MockStockRepository)
+	bookA := primitive.NewObjectID()
+	reservation := &model.Reservation{ID: "res-1", Status: model.ReservationStatusReserved, Items: []model.ReservationItem{{BookID: bookA, Quantity: 2}}}
+
+	mockRepo.On("FindReservation", mock.Anything, "res-1").Return(reservation, nil)
+	mockRepo.On("UpdateReservationStatus", mock.Anything, "res-1", model.ReservationStatusReserved, model.ReservationStatusCommitting).Return(true, nil)
+	mockRepo.On("CommitStock", mock.Anything, bookA, "res-1", 2).Return(nil)
+	mockRepo.On("UpdateReservationStatus", mock.Anything, "res-1", model.ReservationStatusCommitting, model.ReservationStatusCommitted).Return(true, nil)
+	stockService := NewStockService(mockRepo)
+
+	result, err := stockService.Commit(context.Background(), "res-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReservationStatusCommitted, result.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCommit_ResumesAfterFailedAdjustment(t *testing.T) {
+	mockRepo := new(repository.MockStockRepository)
+	bookA, bookB := primitive.NewObjectID(), primitive.NewObjectID()
+	items := []model.ReservationItem{{BookID: bookA, Quantity: 2}, {BookID: bookB, Quantity: 1}}
+
+	// Arrange: commit stok buku kedua gagal, reservasi tetap committing
+	mockRepo.On("FindReservation", mock.Anything, "res-1").
+		Return(&model.Reservation{ID: "res-1", Status: model.ReservationStatusReserved, Items: items}, nil).Once()
+	mockRepo.On("UpdateReservationStatus", mock.Anything, "res-1", model.ReservationStatusReserved, model.ReservationStatusCommitting).Return(true, nil).Once()
+	mockRepo.On("CommitStock", mock.Anything, bookA, "res-1", 2).Return(nil)
+	mockRepo.On("CommitStock", mock.Anything, bookB, "res-1", 1).Return(errors.New("connection reset")).Once()
+	stockService := NewStockService(mockRepo)
+
+	_, err := stockService.Commit(context.Background(), "res-1")
+	assert.Error(t, err)
+
+	// Arrange: pemanggilan ulang melanjutkan commit dan baru menandai reservasi committed
+	mockRepo.On("FindReservation", mock.Anything, "res-1").
+		Return(&model.Reservation{ID: "res-1", Status: model.ReservationStatusCommitting, Items: items}, nil).Once()
+	mockRepo.On("CommitStock", mock.Anything, bookB, "res-1", 1).Return(nil).Once()
+	mockRepo.On("UpdateReservationStatus", mock.Anything, "res-1", model.ReservationStatusCommitting, model.ReservationStatusCommitted).Return(true, nil).Once()
+
+	// Act
+	result, err := stockService.Commit(context.Background(), "res-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReservationStatusCommitted, result.Status)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNumberOfCalls(t, "UpdateReservationStatus", 2)
+}
+
+func TestRelease_AfterCommitIsRejected(t *testing.T) {
+	mockRepo := new(repository.MockStockRepository)
+	reservation := &model.Reservation{ID: "res-1", Status: model.ReservationStatusCommitted}
+
+	mockRepo.On("FindReservation", mock.Anything, "res-1").Return(reservation, nil)
+	stockService := NewStockService(mockRepo)
+
+	_, err := stockService.Release(context.Background(), "res-1")
+
+	assert.ErrorIs(t, err, ErrReservationClosed)
+	mockRepo.AssertNotCalled(t, "UpdateReservationStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "ReleaseStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRelease_Repeated(t *testing.T) {
+	mockRepo := new(repository.MockStockRepository)
+	reservation := &model.Reservation{ID: "res-1", Status: model.ReservationStatusReleased}
+
+	mockRepo.On("FindReservation", mock.Anything, "res-1").Return(reservation, nil)
+	stockService := NewStockService(mockRepo)
+
+	result, err := stockService.Release(context.Background(), "res-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReservationStatusReleased, result.Status)
+	mockRepo.AssertNotCalled(t, "ReleaseStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -109,9 +109,14 @@ func main() {
 	transactionHandler := handler.NewTransactionHandler(transactionClient)
 	walletHandler := handler.NewWalletHandler(walletClient)
 	giftingHandler := handler.NewGiftingHandler(giftingClient)
+	cartHandler := handler.NewCartHandler(transactionClient)
+	couponHandler := handler.NewCouponHandler(transactionClient)
+	reportHandler := handler.NewReportHandler(transactionClient)
+	reconciliationHandler := handler.NewReconciliationHandler(transactionClient)
+	libraryHandler := handler.NewLibraryHandler(transactionClient)
 
 	// Mendaftarkan semua route API dari file terpisah
-	route.SetupRoutes(e, authHandler, bookHandler, transactionHandler, walletHandler, giftingHandler)
+	route.SetupRoutes(e, authHandler, bookHandler, transactionHandler, walletHandler, giftingHandler, cartHandler, couponHandler, reportHandler, reconciliationHandler, libraryHandler)
 
 	// Mendaftarkan route untuk halaman dokumentasi Swagger
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
@@ -172,55 +172,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Meneruskan permintaan login ke Auth Service",
-                "consumes": [
-                    "application/json"
+        "/admin/coupons": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Gateway - Auth"
-                ],
-                "summary": "Login User",
-                "parameters": [
-                    {
-                        "description": "Data login user",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
-                        }
-                    }
+                    "Gateway - Coupon"
                 ],
+                "summary": "Ambil semua kupon (admin)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.TemplateLoginResponse"
+                            "$ref": "#/definitions/dto.CouponListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/auth/register": {
+            },
             "post": {
-                "description": "Meneruskan permintaan register ke Auth Service",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Kupon bisa berupa persentase atau potongan tetap, dengan batasan buku/kategori, minimum order, kuota, dan masa berlaku.",
                 "consumes": [
                     "application/json"
                 ],
@@ -228,17 +221,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gateway - Auth"
+                    "Gateway - Coupon"
                 ],
-                "summary": "Register User",
+                "summary": "Buat kupon diskon (admin)",
                 "parameters": [
                     {
-                        "description": "Data pendaftaran user",
-                        "name": "request",
+                        "description": "Data kupon",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RegisterRequest"
+                            "$ref": "#/definitions/dto.CouponRequest"
                         }
                     }
                 ],
@@ -246,7 +239,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.TemplateRegisterResponse"
+                            "$ref": "#/definitions/dto.CouponResponseApi"
                         }
                     },
                     "400": {
@@ -255,33 +248,67 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/books": {
+        "/admin/coupons/{id}": {
             "get": {
-                "description": "Retrieve list of all books",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "Gateway - Coupon"
+                ],
+                "summary": "Ambil satu kupon (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coupon ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all books",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.BookGetResponse"
-                            }
+                            "$ref": "#/definitions/dto.CouponResponseApi"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
@@ -291,32 +318,59 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/books/{id}": {
-            "get": {
-                "description": "Retrieve a single book by its ID",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengganti seluruh definisi kupon. Jumlah pemakaian tidak berubah.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "Gateway - Coupon"
                 ],
-                "summary": "Get a book by ID",
+                "summary": "Ubah kupon (admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Book ID",
+                        "description": "Coupon ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Data kupon",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CouponRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.BookCreateResponse"
+                            "$ref": "#/definitions/dto.CouponResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
@@ -324,54 +378,58 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/gifts": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Meneruskan permintaan pengiriman hadiah ke Gifting Service",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Gateway - Gifting"
+                    "Gateway - Coupon"
                 ],
-                "summary": "Kirim hadiah buku ke user lain",
+                "summary": "Hapus kupon (admin)",
                 "parameters": [
                     {
-                        "description": "Data pengiriman hadiah",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.SendGiftRequest"
-                        }
+                        "type": "string",
+                        "description": "Coupon ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.TemplateSendGiftResponseApi"
+                            "$ref": "#/definitions/dto.DeleteResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -385,30 +443,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions": {
+        "/admin/reconciliation/runs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil daftar semua transaksi berdasarkan user_id dari token JWT.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Gateway - Transaction"
+                    "Gateway - Reconciliation"
+                ],
+                "summary": "Daftar run rekonsiliasi (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman, dimulai dari 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah run per halaman (default 20, maksimal 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Ambil semua transaksi milik user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.TransactionListResponse"
+                            "$ref": "#/definitions/dto.ReconciliationRunListResponseApi"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -427,7 +504,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menerima list item buku dari user dan membuat transaksi baru (via gRPC ke transaction-service).",
+                "description": "Mencocokkan transaksi yang selesai dalam rentang waktu dengan debit dan refund di wallet. Tanpa body, rentangnya 24 jam terakhir sebelum jeda settle 1 jam.",
                 "consumes": [
                     "application/json"
                 ],
@@ -435,17 +512,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gateway - Transaction"
+                    "Gateway - Reconciliation"
                 ],
-                "summary": "Buat transaksi pembelian buku",
+                "summary": "Jalankan rekonsiliasi transaksi dengan wallet (admin)",
                 "parameters": [
                     {
-                        "description": "Data transaksi",
+                        "description": "Rentang waktu (opsional)",
                         "name": "body",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateTransactionRequest"
+                            "$ref": "#/definitions/dto.RunReconciliationRequest"
                         }
                     }
                 ],
@@ -453,7 +529,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.TransactionResponseApi"
+                            "$ref": "#/definitions/dto.ReconciliationRunResponseApi"
                         }
                     },
                     "400": {
@@ -462,8 +538,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -477,30 +553,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/wallet/balance": {
+        "/admin/reconciliation/runs/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengambil saldo wallet berdasarkan user_id dari token JWT",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Gateway - Wallet"
+                    "Gateway - Reconciliation"
+                ],
+                "summary": "Detail run rekonsiliasi beserta ketidakcocokannya (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Ambil saldo wallet user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.BalanceResponseApi"
+                            "$ref": "#/definitions/dto.ReconciliationRunResponseApi"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -514,40 +604,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/wallet/topup": {
-            "post": {
+        "/admin/reports/categories": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melakukan top up saldo wallet berdasarkan request user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Jumlah buku terjual, penjualan kotor (gross_sales, sebelum diskon kupon), dan penjualan bersih (net_sales) per kategori, diurutkan dari net_sales terbesar.\nDiskon kupon transaksi dibagi proporsional ke setiap buku, sehingga total net_sales sejalan dengan total_revenue di laporan pendapatan.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
-                    "Gateway - Wallet"
+                    "Gateway - Report"
                 ],
-                "summary": "Top up saldo wallet",
+                "summary": "Laporan penjualan per kategori (admin)",
                 "parameters": [
                     {
-                        "description": "Detail top up saldo",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.TopUpRequest"
-                        }
+                        "type": "string",
+                        "description": "Awal rentang waktu (RFC3339, inklusif)",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Akhir rentang waktu (RFC3339, eksklusif)",
+                        "name": "end_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) atau csv",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.TopUpResponseDto"
+                            "$ref": "#/definitions/dto.CategoryReportResponseApi"
                         }
                     },
                     "400": {
@@ -556,8 +653,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -570,46 +667,2976 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AuthResponse": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "description": "Alamat email pengguna.",
-                    "type": "string",
-                    "example": "john.doe@example.com"
-                },
-                "id": {
+        },
+        "/admin/reports/revenue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pendapatan (total yang ditagih setelah diskon kupon), jumlah transaksi, dan jumlah pembeli per hari, minggu, atau bulan. Gunakan format=csv untuk mengunduh CSV.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Gateway - Report"
+                ],
+                "summary": "Laporan pendapatan (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "day, week, atau month (default day)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Awal rentang waktu (RFC3339, inklusif)",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Akhir rentang waktu (RFC3339, eksklusif)",
+                        "name": "end_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) atau csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RevenueReportResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/top-books": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Buku dengan jumlah terjual terbanyak beserta penjualan kotor (gross_sales, sebelum diskon kupon) dan penjualan bersihnya (net_sales, setelah bagian diskon kupon transaksinya).",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Gateway - Report"
+                ],
+                "summary": "Laporan buku terlaris (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Jumlah buku (default 10, maksimal 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Awal rentang waktu (RFC3339, inklusif)",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Akhir rentang waktu (RFC3339, eksklusif)",
+                        "name": "end_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) atau csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TopBooksReportResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/wallets/{user_id}/ledger": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Dipakai support untuk menjelaskan saldo seorang user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Ambil riwayat ledger wallet user tertentu (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Awal rentang waktu (RFC3339, inklusif)",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Akhir rentang waktu (RFC3339, eksklusif)",
+                        "name": "end_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman, mulai dari 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah entri per halaman (default 20, maksimal 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LedgerResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/wallets/{user_id}/limits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil batas pengeluaran seorang user beserta pemakaiannya hari ini dan bulan ini",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Ambil batas pengeluaran wallet user tertentu (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpendingLimitsResponseApi"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengganti batas pengeluaran seorang user, mis. akun anak atau pelajar. Batas yang tidak diisi atau 0 berarti tidak dibatasi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Atur batas pengeluaran wallet user tertentu (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Batas pengeluaran",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpendingLimitsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpendingLimitsResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Meneruskan permintaan login ke Auth Service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Auth"
+                ],
+                "summary": "Login User",
+                "parameters": [
+                    {
+                        "description": "Data login user",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TemplateLoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Meneruskan permintaan register ke Auth Service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Auth"
+                ],
+                "summary": "Register User",
+                "parameters": [
+                    {
+                        "description": "Data pendaftaran user",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TemplateRegisterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books": {
+            "get": {
+                "description": "Retrieve list of all books",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get all books",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BookGetResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}": {
+            "get": {
+                "description": "Retrieve a single book by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get a book by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookCreateResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil isi keranjang dengan harga dan ketersediaan buku terbaru dari book-service.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Cart"
+                ],
+                "summary": "Ambil keranjang belanja user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CartResponseApi"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/checkout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat transaksi dari seluruh isi keranjang lalu mengosongkan keranjang.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Cart"
+                ],
+                "summary": "Checkout keranjang",
+                "parameters": [
+                    {
+                        "description": "Kode kupon (opsional)",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CheckoutCartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/items": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan buku ke keranjang. Jika buku sudah ada, quantity dijumlahkan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Cart"
+                ],
+                "summary": "Tambah buku ke keranjang",
+                "parameters": [
+                    {
+                        "description": "Buku yang ditambahkan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddCartItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CartResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/items/{book_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Cart"
+                ],
+                "summary": "Ubah quantity buku di keranjang",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Quantity baru",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCartItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CartResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Cart"
+                ],
+                "summary": "Hapus buku dari keranjang",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CartResponseApi"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/gifts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Meneruskan permintaan pengiriman hadiah ke Gifting Service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Gifting"
+                ],
+                "summary": "Kirim hadiah buku ke user lain",
+                "parameters": [
+                    {
+                        "description": "Data pengiriman hadiah",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SendGiftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TemplateSendGiftResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/gifts/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menerima hadiah yang dikirim ke email user yang login. Buku hadiah dicatat ke library user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Gifting"
+                ],
+                "summary": "Terima hadiah buku",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID hadiah",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AcceptGiftResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/library": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil buku yang dimiliki user, dari pembelian yang selesai maupun hadiah yang diterima, beserta metadata dari book-service.\nBuku yang terbaru dimiliki tampil lebih dulu, dengan pagination cursor.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Library"
+                ],
+                "summary": "Ambil library ebook user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Jumlah buku per halaman (default 20, maksimal 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari next_page_token pada respons sebelumnya",
+                        "name": "page_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LibraryResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/webhook": {
+            "post": {
+                "description": "Dipanggil oleh payment provider. Body diteruskan apa adanya ke wallet-service untuk verifikasi tanda tangan pada header X-Payment-Signature. Hanya pembayaran sukses yang menambah saldo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Webhook hasil pembayaran top up",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tanda tangan webhook dari provider",
+                        "name": "X-Payment-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentWebhookResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Top up sudah selesai dengan hasil berbeda",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar transaksi berdasarkan user_id dari token JWT, terbaru lebih dulu, dengan pagination cursor.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Transaction"
+                ],
+                "summary": "Ambil semua transaksi milik user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Jumlah transaksi per halaman (default 20, maksimal 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari next_page_token pada respons sebelumnya",
+                        "name": "page_token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status: pending, completed, cancelled, refunded",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Awal rentang waktu (RFC3339, inklusif)",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Akhir rentang waktu (RFC3339, eksklusif)",
+                        "name": "end_time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menerima list item buku dari user dan membuat transaksi baru (via gRPC ke transaction-service).\nJika quote_id dikirim, harga yang ditagih adalah harga di quote; items boleh dikosongkan.\nJika coupon_code dikirim, potongan harga ditampilkan di field discount.\nTotal langsung ditahan dari saldo wallet; jika saldo tersedia atau batas pengeluaran tidak cukup, transaksi ditolak dengan 409.\nUntuk batas pengeluaran, field code berisi batas yang terlampaui (per_transaction_limit_exceeded, daily_limit_exceeded, atau monthly_limit_exceeded).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Transaction"
+                ],
+                "summary": "Buat transaksi pembelian buku",
+                "parameters": [
+                    {
+                        "description": "Data transaksi",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/quote": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghitung harga keranjang saat ini dan mengembalikan quote_id bertanda tangan yang berlaku sampai expires_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Transaction"
+                ],
+                "summary": "Minta quote harga sebelum checkout",
+                "parameters": [
+                    {
+                        "description": "Item yang ingin dibeli",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.QuoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.QuoteResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil satu transaksi beserta detailnya, misalnya untuk memantau status pembayaran. Hanya pemilik transaksi yang bisa mengaksesnya.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Transaction"
+                ],
+                "summary": "Ambil detail satu transaksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Transaksi pending langsung dibatalkan; transaksi completed dananya dikembalikan ke wallet (status refunded). User hanya bisa membatalkan transaksinya sendiri, admin bisa membatalkan transaksi siapa saja.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Transaction"
+                ],
+                "summary": "Batalkan transaksi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alasan pembatalan",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CancelTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuka stream text/event-stream. Event ` + "`" + `status` + "`" + ` berisi dto.TransactionStatusEvent untuk status saat ini dan setiap perubahannya.\nSetelah transaksi tidak lagi pending, event ` + "`" + `done` + "`" + ` dikirim dengan status akhir lalu koneksi ditutup; client sebaiknya menutup EventSource saat menerimanya.\nJika stream terputus di tengah jalan, event ` + "`" + `error` + "`" + ` berisi dto.ErrorResponse dikirim dan client boleh menyambung ulang.\nHanya pemilik transaksi yang bisa memantaunya.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Gateway - Transaction"
+                ],
+                "summary": "Pantau status transaksi secara live (Server-Sent Events)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransactionStatusEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/balance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil saldo wallet berdasarkan user_id dari token JWT",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Ambil saldo wallet user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BalanceResponseApi"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/ledger": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil entri ledger (debit, credit, top up, refund, adjustment) milik user dari token JWT, terbaru lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Ambil riwayat ledger wallet user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Awal rentang waktu (RFC3339, inklusif)",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Akhir rentang waktu (RFC3339, eksklusif)",
+                        "name": "end_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman, mulai dari 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah entri per halaman (default 20, maksimal 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LedgerResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/limits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil batas pengeluaran harian, bulanan, dan per transaksi milik user dari token JWT beserta pemakaiannya hari ini dan bulan ini. Nilai 0 berarti tidak dibatasi.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Ambil batas pengeluaran wallet",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpendingLimitsResponseApi"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengganti batas pengeluaran milik user dari token JWT. Batas berlaku untuk pembelian dan transfer keluar; batas yang tidak diisi atau 0 berarti tidak dibatasi.\nUser hanya boleh memperketat batasnya sendiri. Menaikkan atau menghapus batas yang sudah ada ditolak dengan 403 dan hanya bisa dilakukan admin lewat /admin/wallets/{user_id}/limits.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Atur batas pengeluaran wallet",
+                "parameters": [
+                    {
+                        "description": "Batas pengeluaran",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpendingLimitsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpendingLimitsResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/topup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat top up berstatus pending beserta checkout_url di payment provider. Saldo baru bertambah setelah provider mengonfirmasi pembayaran lewat webhook.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Top up saldo wallet",
+                "parameters": [
+                    {
+                        "description": "Detail top up saldo",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TopUpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TopUpResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/topups": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil top up milik user dari token JWT, terbaru lebih dulu, beserta riwayat perubahan statusnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Ambil riwayat top up user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter status: pending, success, atau failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter metode top up",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman, mulai dari 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah top up per halaman (default 20, maksimal 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TopUpListResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/topups/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil detail satu top up milik user dari token JWT beserta riwayat perubahan statusnya",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Ambil bukti top up",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Top up ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TopUpDetailResponseApi"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memindahkan saldo dari user pada token JWT ke user lain. Debit dan kredit terjadi dalam satu transaksi database.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Transfer saldo ke user lain",
+                "parameters": [
+                    {
+                        "description": "Detail transfer",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Saldo tidak cukup",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengambil transfer masuk dan keluar milik user dari token JWT, terbaru lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gateway - Wallet"
+                ],
+                "summary": "Ambil riwayat transfer wallet user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman, mulai dari 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah transfer per halaman (default 20, maksimal 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferListResponseApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AcceptGiftResponse": {
+            "type": "object",
+            "properties": {
+                "accepted_at": {
+                    "type": "string"
+                },
+                "book_id": {
+                    "type": "string",
+                    "example": "68a1f0c2e4b0a1b2c3d4e5f6"
+                },
+                "gift_id": {
+                    "type": "string",
+                    "example": "7"
+                },
+                "recipient_user_id": {
+                    "type": "string",
+                    "example": "5"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "accepted"
+                }
+            }
+        },
+        "dto.AcceptGiftResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.AcceptGiftResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Accept gift successfully"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.AddCartItemRequest": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "68a1f0c2e4b0a1b2c3d4e5f6"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Alamat email pengguna.",
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                },
+                "id": {
                     "description": "ID pengguna, hanya disertakan jika ada (saat Register).",
                     "type": "integer",
                     "example": 1
                 },
-                "name": {
-                    "description": "Nama pengguna, hanya disertakan jika ada (saat Register).",
+                "name": {
+                    "description": "Nama pengguna, hanya disertakan jika ada (saat Register).",
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "token": {
+                    "description": "Token JWT, hanya disertakan jika ada (saat Login atau Register jika langsung login).",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1Ni..."
+                }
+            }
+        },
+        "dto.BalanceResponse": {
+            "type": "object",
+            "properties": {
+                "available_balance": {
+                    "description": "Saldo dikurangi dana yang sedang ditahan untuk pesanan yang belum dibayar",
+                    "type": "number"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BalanceResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.BalanceResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Get data success"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.BookCreateResponse": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.BookResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Create user success"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 201
+                }
+            }
+        },
+        "dto.BookGetResponse": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BookResponse"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Create user success"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 201
+                }
+            }
+        },
+        "dto.BookOrderItem": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BookResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_donation_only": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 85000.5
+                },
+                "publisher": {
+                    "type": "string"
+                },
+                "reserved": {
+                    "description": "Eksemplar yang ditahan untuk transaksi pending",
+                    "type": "integer",
+                    "example": 2
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stock": {
+                    "description": "Eksemplar yang masih bisa dibeli",
+                    "type": "integer",
+                    "example": 10
+                },
+                "title": {
+                    "type": "string"
+                },
+                "year_published": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BookSalesResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string",
+                    "example": "101"
+                },
+                "gross_sales": {
+                    "type": "number",
+                    "example": 250000
+                },
+                "net_sales": {
+                    "type": "number",
+                    "example": 225000
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "dto.CancelTransactionRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Salah pilih buku"
+                }
+            }
+        },
+        "dto.CartItemResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "price_at_add": {
+                    "type": "number"
+                },
+                "price_changed": {
+                    "type": "boolean"
+                },
+                "price_per_unit": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CartResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CartItemResponse"
+                    }
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CartResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.CartResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Get cart successfully"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.CategoryReportResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CategorySalesResponse"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Get category report successfully"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.CategorySalesResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "Fiction"
+                },
+                "gross_sales": {
+                    "type": "number",
+                    "example": 600000
+                },
+                "net_sales": {
+                    "type": "number",
+                    "example": 540000
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "dto.CheckoutCartRequest": {
+            "type": "object",
+            "properties": {
+                "coupon_code": {
+                    "type": "string",
+                    "example": "HEMAT10"
+                }
+            }
+        },
+        "dto.CouponListResponse": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CouponResponse"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Get coupons successfully"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.CouponRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Default true",
+                    "type": "boolean"
+                },
+                "amount_off": {
+                    "type": "number",
+                    "example": 15000
+                },
+                "book_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Fiction"
+                    ]
+                },
+                "code": {
+                    "type": "string",
+                    "example": "HEMAT10"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "min_order_amount": {
+                    "type": "number",
+                    "example": 100000
+                },
+                "per_user_limit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "percent_off": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed"
+                    ],
+                    "example": "percentage"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "dto.CouponResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "amount_off": {
+                    "type": "number"
+                },
+                "book_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "min_order_amount": {
+                    "type": "number"
+                },
+                "per_user_limit": {
+                    "type": "integer"
+                },
+                "percent_off": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "used_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CouponResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.CouponResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Get coupon successfully"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.CreateBookRequest": {
+            "type": "object",
+            "required": [
+                "author",
+                "title"
+            ],
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "is_donation_only": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 85000.5
+                },
+                "publisher": {
+                    "type": "string"
+                },
+                "stock": {
+                    "description": "Jumlah eksemplar awal",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "title": {
+                    "type": "string"
+                },
+                "year_published": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateTransactionRequest": {
+            "type": "object",
+            "properties": {
+                "coupon_code": {
+                    "type": "string",
+                    "example": "HEMAT10"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BookOrderItem"
+                    }
+                },
+                "quote_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DeleteResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DiscountLineResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "coupon_code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Kode error yang stabil untuk klien, jika ada",
+                    "type": "string",
+                    "example": "daily_limit_exceeded"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Pesan kesalahan yang deskriptif"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Internal Server Error"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 401
+                }
+            }
+        },
+        "dto.LedgerEntryResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": -50000
+                },
+                "balance_after": {
+                    "type": "number",
+                    "example": 150000
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-25T15:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Payment for transaction 42"
+                },
+                "entry_type": {
+                    "type": "string",
+                    "example": "debit"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "reference_id": {
+                    "type": "string",
+                    "example": "42"
+                },
+                "reference_type": {
+                    "type": "string",
+                    "example": "transaction"
+                }
+            }
+        },
+        "dto.LedgerResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LedgerEntryResponse"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
+        "dto.LedgerResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.LedgerResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Get data success"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.LibraryBookResponse": {
+            "type": "object",
+            "properties": {
+                "acquired_at": {
+                    "type": "string"
+                },
+                "author": {
+                    "type": "string",
+                    "example": "Andrea Hirata"
+                },
+                "book_id": {
+                    "type": "string",
+                    "example": "68a1f0c2e4b0a1b2c3d4e5f6"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Novel"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "publisher": {
+                    "type": "string",
+                    "example": "Bentang Pustaka"
+                },
+                "reference_id": {
+                    "description": "ID transaksi atau \"gift-\u003cid\u003e\"",
+                    "type": "string",
+                    "example": "11"
+                },
+                "source": {
+                    "description": "\"purchase\" atau \"gift\"",
+                    "type": "string",
+                    "example": "purchase"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Laskar Pelangi"
+                }
+            }
+        },
+        "dto.LibraryResponse": {
+            "type": "object",
+            "properties": {
+                "books": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LibraryBookResponse"
+                    }
+                },
+                "next_page_token": {
+                    "type": "string",
+                    "example": "MTc0MDgyMzIwMDAwMDAwMDAwMDoxMQ"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LibraryResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.LibraryResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Get library successfully"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Alamat email pengguna. ` + "`" + `required` + "`" + ` dan ` + "`" + `email` + "`" + `.",
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                },
+                "password": {
+                    "description": "Kata sandi pengguna. ` + "`" + `required` + "`" + `.",
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "dto.PaymentWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                },
+                "top_up_id": {
+                    "type": "string",
+                    "example": "12"
+                }
+            }
+        },
+        "dto.PaymentWebhookResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.PaymentWebhookResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Webhook processed"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.QuoteRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BookOrderItem"
+                    }
+                }
+            }
+        },
+        "dto.QuoteResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TransactionDetailResponse"
+                    }
+                },
+                "quote_id": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.QuoteResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.QuoteResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Success quote order"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.ReconciliationMismatchResponse": {
+            "type": "object",
+            "properties": {
+                "actual_amount": {
+                    "type": "number",
+                    "example": 0
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "expected_amount": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "id": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string",
+                    "example": "99"
+                },
+                "transaction_status": {
+                    "type": "string",
+                    "example": "completed"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "missing_debit"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
+        "dto.ReconciliationRunListResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReconciliationRunResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReconciliationRunListResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.ReconciliationRunListResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Get reconciliation runs successfully"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.ReconciliationRunResponse": {
+            "type": "object",
+            "properties": {
+                "checked_count": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mismatch_count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "mismatches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReconciliationMismatchResponse"
+                    }
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "completed"
                 },
-                "token": {
-                    "description": "Token JWT, hanya disertakan jika ada (saat Login atau Register jika langsung login).",
+                "trigger": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1Ni..."
-                }
-            }
-        },
-        "dto.BalanceResponse": {
-            "type": "object",
-            "properties": {
-                "balance": {
-                    "type": "number"
+                    "example": "scheduled"
                 },
-                "user_id": {
+                "window_end": {
+                    "type": "string"
+                },
+                "window_start": {
                     "type": "string"
                 }
             }
         },
-        "dto.BalanceResponseApi": {
+        "dto.ReconciliationRunResponseApi": {
             "type": "object",
             "required": [
                 "message",
@@ -617,11 +3644,11 @@ const docTemplate = `{
             ],
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/dto.BalanceResponse"
+                    "$ref": "#/definitions/dto.ReconciliationRunResponse"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Get data success"
+                    "example": "Get reconciliation run successfully"
                 },
                 "status_code": {
                     "type": "integer",
@@ -629,261 +3656,277 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.BookCreateResponse": {
+        "dto.RegisterRequest": {
             "type": "object",
             "required": [
-                "message",
-                "status_code"
+                "email",
+                "name",
+                "password"
             ],
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/dto.BookResponse"
+                "email": {
+                    "description": "Alamat email pengguna. ` + "`" + `required` + "`" + ` dan ` + "`" + `email` + "`" + ` (format email valid).",
+                    "type": "string",
+                    "example": "john.doe@example.com"
                 },
-                "message": {
+                "name": {
+                    "description": "Nama lengkap pengguna. ` + "`" + `required` + "`" + ` berarti tidak boleh kosong.",
                     "type": "string",
-                    "example": "Create user success"
+                    "example": "John Doe"
                 },
-                "status_code": {
-                    "type": "integer",
-                    "example": 201
+                "password": {
+                    "description": "Kata sandi pengguna. ` + "`" + `required` + "`" + ` dan ` + "`" + `min=8` + "`" + ` (minimal 8 karakter).",
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "password123"
                 }
             }
         },
-        "dto.BookGetResponse": {
+        "dto.RegisterResponse": {
             "type": "object",
             "required": [
-                "message",
-                "status_code"
+                "email",
+                "id",
+                "name"
             ],
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.BookResponse"
-                    }
-                },
-                "message": {
+                "email": {
                     "type": "string",
-                    "example": "Create user success"
+                    "example": "john.doe@example.com"
                 },
-                "status_code": {
+                "id": {
                     "type": "integer",
-                    "example": 201
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
                 }
             }
         },
-        "dto.BookOrderItem": {
+        "dto.RevenueBucketResponse": {
             "type": "object",
             "properties": {
-                "book_id": {
+                "buyer_count": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "period_start": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
+                "revenue": {
+                    "type": "number",
+                    "example": 150000
+                },
+                "transaction_count": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "dto.BookResponse": {
+        "dto.RevenueReportResponse": {
             "type": "object",
             "properties": {
-                "author": {
-                    "type": "string"
-                },
-                "category": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RevenueBucketResponse"
+                    }
                 },
-                "description": {
-                    "type": "string"
+                "period": {
+                    "type": "string",
+                    "example": "day"
                 },
-                "id": {
-                    "type": "string"
+                "total_buyers": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "is_donation_only": {
-                    "type": "boolean"
+                "total_revenue": {
+                    "type": "number",
+                    "example": 150000
                 },
-                "price": {
-                    "type": "number"
+                "total_transactions": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.RevenueReportResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.RevenueReportResponse"
                 },
-                "publisher": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "Get revenue report successfully"
                 },
-                "status": {
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.RunReconciliationRequest": {
+            "type": "object",
+            "properties": {
+                "end_time": {
                     "type": "string"
                 },
-                "title": {
+                "start_time": {
                     "type": "string"
-                },
-                "year_published": {
-                    "type": "integer"
                 }
             }
         },
-        "dto.CreateBookRequest": {
+        "dto.SendGiftRequest": {
             "type": "object",
             "required": [
-                "author",
-                "title"
+                "book_id",
+                "recipient_email"
             ],
             "properties": {
-                "author": {
-                    "type": "string"
-                },
-                "category": {
-                    "type": "string"
-                },
-                "description": {
+                "book_id": {
                     "type": "string"
                 },
-                "is_donation_only": {
-                    "type": "boolean"
-                },
-                "price": {
-                    "type": "number",
-                    "minimum": 0
-                },
-                "publisher": {
+                "message": {
                     "type": "string"
                 },
-                "title": {
+                "recipient_email": {
                     "type": "string"
-                },
-                "year_published": {
-                    "type": "integer"
                 }
             }
         },
-        "dto.CreateTransactionRequest": {
+        "dto.SpendingLimitsRequest": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.BookOrderItem"
-                    }
+                "daily_limit": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "monthly_limit": {
+                    "type": "number",
+                    "example": 500000
+                },
+                "per_transaction_limit": {
+                    "type": "number",
+                    "example": 25000
                 }
             }
         },
-        "dto.DeleteResponse": {
+        "dto.SpendingLimitsResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "daily_limit": {
+                    "type": "number",
+                    "example": 50000
                 },
-                "message": {
-                    "type": "string"
+                "monthly_limit": {
+                    "type": "number",
+                    "example": 500000
+                },
+                "per_transaction_limit": {
+                    "type": "number",
+                    "example": 25000
+                },
+                "spent_this_month": {
+                    "type": "number",
+                    "example": 120000
+                },
+                "spent_today": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "3"
                 }
             }
         },
-        "dto.ErrorResponse": {
+        "dto.SpendingLimitsResponseApi": {
             "type": "object",
             "required": [
                 "message",
                 "status_code"
             ],
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "Pesan kesalahan yang deskriptif"
+                "data": {
+                    "$ref": "#/definitions/dto.SpendingLimitsResponse"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Internal Server Error"
+                    "example": "Get data success"
                 },
                 "status_code": {
                     "type": "integer",
-                    "example": 401
-                }
-            }
-        },
-        "dto.LoginRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "description": "Alamat email pengguna. ` + "`" + `required` + "`" + ` dan ` + "`" + `email` + "`" + `.",
-                    "type": "string",
-                    "example": "john.doe@example.com"
-                },
-                "password": {
-                    "description": "Kata sandi pengguna. ` + "`" + `required` + "`" + `.",
-                    "type": "string",
-                    "example": "password123"
+                    "example": 200
                 }
             }
         },
-        "dto.RegisterRequest": {
+        "dto.TemplateLoginResponse": {
             "type": "object",
             "required": [
-                "email",
-                "name",
-                "password"
+                "message",
+                "status_code"
             ],
             "properties": {
-                "email": {
-                    "description": "Alamat email pengguna. ` + "`" + `required` + "`" + ` dan ` + "`" + `email` + "`" + ` (format email valid).",
-                    "type": "string",
-                    "example": "john.doe@example.com"
+                "data": {
+                    "$ref": "#/definitions/dto.AuthResponse"
                 },
-                "name": {
-                    "description": "Nama lengkap pengguna. ` + "`" + `required` + "`" + ` berarti tidak boleh kosong.",
+                "message": {
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "Create user success"
                 },
-                "password": {
-                    "description": "Kata sandi pengguna. ` + "`" + `required` + "`" + ` dan ` + "`" + `min=8` + "`" + ` (minimal 8 karakter).",
-                    "type": "string",
-                    "minLength": 8,
-                    "example": "password123"
+                "status_code": {
+                    "type": "integer",
+                    "example": 201
                 }
             }
         },
-        "dto.RegisterResponse": {
-            "type": "object",
-            "required": [
-                "email",
-                "id",
-                "name"
+        "dto.TemplateRegisterResponse": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
             ],
             "properties": {
-                "email": {
+                "data": {
+                    "$ref": "#/definitions/dto.RegisterResponse"
+                },
+                "message": {
                     "type": "string",
-                    "example": "john.doe@example.com"
+                    "example": "Create user success"
                 },
-                "id": {
+                "status_code": {
                     "type": "integer",
-                    "example": 1
-                },
-                "name": {
-                    "type": "string",
-                    "example": "John Doe"
+                    "example": 201
                 }
             }
         },
-        "dto.SendGiftRequest": {
+        "dto.TemplateSendGiftResponseApi": {
             "type": "object",
             "required": [
-                "book_id",
-                "recipient_email"
+                "message",
+                "status_code"
             ],
             "properties": {
-                "book_id": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/dto.SendGiftRequest"
                 },
                 "message": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "Create data success"
                 },
-                "recipient_email": {
-                    "type": "string"
+                "status_code": {
+                    "type": "integer",
+                    "example": 201
                 }
             }
         },
-        "dto.TemplateLoginResponse": {
+        "dto.TopBooksReportResponseApi": {
             "type": "object",
             "required": [
                 "message",
@@ -891,19 +3934,73 @@ const docTemplate = `{
             ],
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/dto.AuthResponse"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BookSalesResponse"
+                    }
                 },
                 "message": {
                     "type": "string",
-                    "example": "Create user success"
+                    "example": "Get top books report successfully"
                 },
                 "status_code": {
                     "type": "integer",
-                    "example": 201
+                    "example": 200
                 }
             }
         },
-        "dto.TemplateRegisterResponse": {
+        "dto.TopUpDetailResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "checkout_url": {
+                    "type": "string",
+                    "example": "http://localhost:8000/fake-checkout/fake_12_a1b2c3d4e5f6"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-25T15:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "Transfer"
+                },
+                "payment_reference": {
+                    "type": "string",
+                    "example": "fake_12_a1b2c3d4e5f6"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "fake"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TopUpStatusChangeResponse"
+                    }
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-07-25T15:02:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
+        "dto.TopUpDetailResponseApi": {
             "type": "object",
             "required": [
                 "message",
@@ -911,19 +4008,42 @@ const docTemplate = `{
             ],
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/dto.RegisterResponse"
+                    "$ref": "#/definitions/dto.TopUpDetailResponse"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Create user success"
+                    "example": "Get data success"
                 },
                 "status_code": {
                     "type": "integer",
-                    "example": 201
+                    "example": 200
                 }
             }
         },
-        "dto.TemplateSendGiftResponseApi": {
+        "dto.TopUpListResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "top_ups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TopUpDetailResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "dto.TopUpListResponseApi": {
             "type": "object",
             "required": [
                 "message",
@@ -931,15 +4051,15 @@ const docTemplate = `{
             ],
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/dto.SendGiftRequest"
+                    "$ref": "#/definitions/dto.TopUpListResponse"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Create data success"
+                    "example": "Get data success"
                 },
                 "status_code": {
                     "type": "integer",
-                    "example": 201
+                    "example": 200
                 }
             }
         },
@@ -951,7 +4071,8 @@ const docTemplate = `{
             ],
             "properties": {
                 "amount": {
-                    "type": "number"
+                    "type": "number",
+                    "example": 50000
                 },
                 "method": {
                     "type": "string"
@@ -965,25 +4086,26 @@ const docTemplate = `{
                     "type": "number",
                     "example": 50000
                 },
-                "created_at": {
+                "checkout_url": {
                     "type": "string",
-                    "example": "2025-07-25T15:00:00Z"
+                    "example": "http://localhost:8000/fake-checkout/fake_12_a1b2c3d4e5f6"
                 },
-                "method": {
+                "payment_reference": {
+                    "description": "Top-up dibuat pending; saldo bertambah setelah pembayaran di CheckoutURL dikonfirmasi provider",
                     "type": "string",
-                    "example": "credit_card"
+                    "example": "fake_12_a1b2c3d4e5f6"
                 },
                 "status": {
                     "type": "string",
-                    "example": "success"
+                    "example": "pending"
                 },
-                "transaction_id": {
+                "top_up_id": {
                     "type": "string",
-                    "example": "trx123"
+                    "example": "12"
                 },
                 "user_id": {
                     "type": "string",
-                    "example": "user123"
+                    "example": "1"
                 }
             }
         },
@@ -1007,6 +4129,19 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TopUpStatusChangeResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-25T15:02:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
         "dto.TransactionDetailResponse": {
             "type": "object",
             "properties": {
@@ -1038,9 +4173,17 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Create data success"
                 },
+                "next_page_token": {
+                    "type": "string",
+                    "example": "MTc0MDgyMzIwMDAwMDAwMDAwMDoxMQ"
+                },
                 "status_code": {
                     "type": "integer",
                     "example": 200
+                },
+                "total_count": {
+                    "type": "integer",
+                    "example": 42
                 }
             }
         },
@@ -1053,9 +4196,21 @@ const docTemplate = `{
                         "$ref": "#/definitions/dto.TransactionDetailResponse"
                     }
                 },
+                "discount": {
+                    "$ref": "#/definitions/dto.DiscountLineResponse"
+                },
+                "failure_code": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "string"
                 },
+                "subtotal_amount": {
+                    "type": "number"
+                },
                 "total_amount": {
                     "type": "number"
                 },
@@ -1090,6 +4245,154 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TransactionStatusEvent": {
+            "type": "object",
+            "properties": {
+                "failure_code": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "completed"
+                },
+                "transaction_id": {
+                    "type": "string",
+                    "example": "11"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TransferListResponse": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "transfers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TransferResponse"
+                    }
+                }
+            }
+        },
+        "dto.TransferListResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.TransferListResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Get data success"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.TransferRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "to_user_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 25000
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Uang jajan minggu ini"
+                },
+                "to_user_id": {
+                    "type": "string",
+                    "example": "2"
+                }
+            }
+        },
+        "dto.TransferResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 25000
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-07-25T15:00:00Z"
+                },
+                "from_user_id": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Uang jajan minggu ini"
+                },
+                "to_user_id": {
+                    "type": "string",
+                    "example": "2"
+                }
+            }
+        },
+        "dto.TransferResponseApi": {
+            "type": "object",
+            "required": [
+                "message",
+                "status_code"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.TransferResultResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Transfer success"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "dto.TransferResultResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number",
+                    "example": 75000
+                },
+                "transfer": {
+                    "$ref": "#/definitions/dto.TransferResponse"
+                }
+            }
+        },
         "dto.UpdateBookRequest": {
             "type": "object",
             "required": [
@@ -1111,7 +4414,8 @@ const docTemplate = `{
                 },
                 "price": {
                     "type": "number",
-                    "minimum": 0
+                    "minimum": 0,
+                    "example": 85000.5
                 },
                 "publisher": {
                     "type": "string"
@@ -1124,6 +4428,12 @@ const docTemplate = `{
                         "unavailable"
                     ]
                 },
+                "stock": {
+                    "description": "Kosongkan jika stok tidak diubah",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                },
                 "title": {
                     "type": "string"
                 },
@@ -1131,6 +4441,15 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "dto.UpdateCartItemRequest": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
         }
     },
     "securityDefinitions": {
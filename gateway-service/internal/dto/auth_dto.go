@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"shared/money"
+	"time"
+)
 
 // RegisterRequest merepresentasikan struktur body permintaan untuk pendaftaran pengguna baru.
 // Struct ini digunakan untuk binding data JSON dari permintaan HTTP.
@@ -41,6 +44,7 @@ type ErrorResponse struct {
 	StatusCode int `json:"status_code" validate:"required" example:"401"`
 	Message string `json:"message" validate:"required" example:"Internal Server Error"`
 	Error string `json:"error" example:"Pesan kesalahan yang deskriptif"`
+	Code string `json:"code,omitempty" example:"daily_limit_exceeded"` // Kode error yang stabil untuk klien, jika ada
 }
 
 type UserResponse struct {
@@ -48,7 +52,7 @@ type UserResponse struct {
 	Name      string    `json:"name"`
 	Email     string    `json:"email"`
 	Role      string    `json:"role"`
-	Saldo     float64   `json:"saldo"`
+	Saldo     money.Money `json:"saldo" swaggertype:"number"`
 	CreatedAt time.Time`json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"shared/money"
+	"time"
+)
 
 // CreateBookRequest adalah DTO untuk membuat buku baru.
 // Tidak ada ID, Status, atau CreatedAt karena itu diatur oleh server.
@@ -10,7 +13,8 @@ type CreateBookRequest struct {
 	Publisher      string  `json:"publisher"`
 	YearPublished  int     `json:"year_published"`
 	Category       string  `json:"category"`
-	Price          float64 `json:"price" validate:"gte=0"`
+	Price          money.Money `json:"price" validate:"gte=0" swaggertype:"number" example:"85000.50"`
+	Stock          int     `json:"stock" validate:"gte=0" example:"10"` // Jumlah eksemplar awal
 	IsDonationOnly bool    `json:"is_donation_only"`
 	Description    string  `json:"description"`
 }
@@ -23,8 +27,9 @@ type UpdateBookRequest struct {
 	Publisher      string  `json:"publisher"`
 	YearPublished  int     `json:"year_published"`
 	Category       string  `json:"category"`
-	Price          float64 `json:"price" validate:"gte=0"`
+	Price          money.Money `json:"price" validate:"gte=0" swaggertype:"number" example:"85000.50"`
 	Status         string  `json:"status" validate:"oneof=available unavailable"` // Validasi status
+	Stock          *int    `json:"stock,omitempty" validate:"omitempty,gte=0" example:"10"` // Kosongkan jika stok tidak diubah
 	IsDonationOnly bool    `json:"is_donation_only"`
 	Description    string  `json:"description"`
 }
@@ -38,8 +43,10 @@ type BookResponse struct {
 	Publisher      string    `json:"publisher"`
 	YearPublished  int       `json:"year_published"`
 	Category       string    `json:"category"`
-	Price          float64   `json:"price"`
+	Price          money.Money `json:"price" swaggertype:"number" example:"85000.50"`
 	Status         string    `json:"status"`
+	Stock          int       `json:"stock" example:"10"`   // Eksemplar yang masih bisa dibeli
+	Reserved       int       `json:"reserved" example:"2"` // Eksemplar yang ditahan untuk transaksi pending
 	IsDonationOnly bool      `json:"is_donation_only"`
 	Description    string    `json:"description"`
 	CreatedAt      time.Time `json:"created_at"`
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"shared/money"
+	pb "transaction-service/proto"
+)
+
+// DTO untuk request menambah buku ke keranjang
+type AddCartItemRequest struct {
+	BookID   string `json:"book_id" example:"68a1f0c2e4b0a1b2c3d4e5f6"`
+	Quantity int    `json:"quantity" example:"1"`
+}
+
+// DTO untuk request mengganti quantity buku di keranjang
+type UpdateCartItemRequest struct {
+	Quantity int `json:"quantity" example:"2"`
+}
+
+// DTO untuk response keranjang ke client (JSON)
+type CartItemResponse struct {
+	BookID       string      `json:"book_id"`
+	Title        string      `json:"title"`
+	Quantity     int         `json:"quantity"`
+	PricePerUnit money.Money `json:"price_per_unit" swaggertype:"number"`
+	PriceAtAdd   money.Money `json:"price_at_add" swaggertype:"number"`
+	Subtotal     money.Money `json:"subtotal" swaggertype:"number"`
+	Available    bool        `json:"available"`
+	PriceChanged bool        `json:"price_changed"`
+}
+
+type CartResponse struct {
+	UserID      string             `json:"user_id"`
+	Items       []CartItemResponse `json:"items"`
+	TotalAmount money.Money        `json:"total_amount" swaggertype:"number"`
+}
+
+type CartResponseApi struct {
+	StatusCode 	int              	`json:"status_code" validate:"required" example:"200"`
+	Message    	string           	`json:"message" validate:"required" example:"Get cart successfully"`
+	Data 		CartResponse 		`json:"data"`
+}
+
+// Mapper dari gRPC response ke DTO response
+func ToCartResponse(grpcResp *pb.CartResponse) *CartResponse {
+	items := make([]CartItemResponse, len(grpcResp.Items))
+	for i, item := range grpcResp.Items {
+		items[i] = CartItemResponse{
+			BookID:       item.BookId,
+			Title:        item.Title,
+			Quantity:     int(item.Quantity),
+			PricePerUnit: money.FromMinor(item.PricePerUnit.GetMinorUnits()),
+			PriceAtAdd:   money.FromMinor(item.PriceAtAdd.GetMinorUnits()),
+			Subtotal:     money.FromMinor(item.Subtotal.GetMinorUnits()),
+			Available:    item.Available,
+			PriceChanged: item.PriceChanged,
+		}
+	}
+
+	return &CartResponse{
+		UserID:      grpcResp.UserId,
+		Items:       items,
+		TotalAmount: money.FromMinor(grpcResp.TotalAmount.GetMinorUnits()),
+	}
+}
@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"shared/money"
+	"time"
+	pb "transaction-service/proto"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DTO untuk request membuat/mengubah kupon (admin)
+type CouponRequest struct {
+	Code           string      `json:"code" example:"HEMAT10"`
+	Type           string      `json:"type" example:"percentage" enums:"percentage,fixed"`
+	PercentOff     int         `json:"percent_off,omitempty" example:"10"`
+	AmountOff      money.Money `json:"amount_off,omitempty" swaggertype:"number" example:"15000"`
+	MinOrderAmount money.Money `json:"min_order_amount,omitempty" swaggertype:"number" example:"100000"`
+	BookIDs        []string    `json:"book_ids,omitempty"`
+	Categories     []string    `json:"categories,omitempty" example:"Fiction"`
+	UsageLimit     int         `json:"usage_limit,omitempty" example:"100"`
+	PerUserLimit   int         `json:"per_user_limit,omitempty" example:"1"`
+	StartsAt       *time.Time  `json:"starts_at,omitempty"`
+	EndsAt         *time.Time  `json:"ends_at,omitempty"`
+	Active         *bool       `json:"active,omitempty"` // Default true
+}
+
+// DTO untuk response kupon ke client (JSON)
+type CouponResponse struct {
+	ID             string      `json:"id"`
+	Code           string      `json:"code"`
+	Type           string      `json:"type"`
+	PercentOff     int         `json:"percent_off"`
+	AmountOff      money.Money `json:"amount_off" swaggertype:"number"`
+	MinOrderAmount money.Money `json:"min_order_amount" swaggertype:"number"`
+	BookIDs        []string    `json:"book_ids"`
+	Categories     []string    `json:"categories"`
+	UsageLimit     int         `json:"usage_limit"`
+	PerUserLimit   int         `json:"per_user_limit"`
+	UsedCount      int         `json:"used_count"`
+	StartsAt       *time.Time  `json:"starts_at,omitempty"`
+	EndsAt         *time.Time  `json:"ends_at,omitempty"`
+	Active         bool        `json:"active"`
+}
+
+type CouponResponseApi struct {
+	StatusCode 	int              	`json:"status_code" validate:"required" example:"200"`
+	Message    	string           	`json:"message" validate:"required" example:"Get coupon successfully"`
+	Data 		CouponResponse 		`json:"data"`
+}
+
+type CouponListResponse struct {
+	StatusCode 	int              	`json:"status_code" validate:"required" example:"200"`
+	Message    	string           	`json:"message" validate:"required" example:"Get coupons successfully"`
+	Data 		[]CouponResponse 	`json:"data"`
+}
+
+// ToCouponProto mengubah request admin menjadi pesan gRPC
+func (r CouponRequest) ToCouponProto(id string) *pb.Coupon {
+	coupon := &pb.Coupon{
+		Id:             id,
+		Code:           r.Code,
+		Type:           r.Type,
+		PercentOff:     int32(r.PercentOff),
+		AmountOff:      r.AmountOff.ToProto(),
+		MinOrderAmount: r.MinOrderAmount.ToProto(),
+		BookIds:        r.BookIDs,
+		Categories:     r.Categories,
+		UsageLimit:     int32(r.UsageLimit),
+		PerUserLimit:   int32(r.PerUserLimit),
+		Active:         r.Active == nil || *r.Active,
+	}
+	if r.StartsAt != nil {
+		coupon.StartsAt = timestamppb.New(*r.StartsAt)
+	}
+	if r.EndsAt != nil {
+		coupon.EndsAt = timestamppb.New(*r.EndsAt)
+	}
+	return coupon
+}
+
+// Mapper dari gRPC response ke DTO response
+func ToCouponResponse(c *pb.Coupon) *CouponResponse {
+	resp := &CouponResponse{
+		ID:             c.Id,
+		Code:           c.Code,
+		Type:           c.Type,
+		PercentOff:     int(c.PercentOff),
+		AmountOff:      money.FromMinor(c.AmountOff.GetMinorUnits()),
+		MinOrderAmount: money.FromMinor(c.MinOrderAmount.GetMinorUnits()),
+		BookIDs:        c.BookIds,
+		Categories:     c.Categories,
+		UsageLimit:     int(c.UsageLimit),
+		PerUserLimit:   int(c.PerUserLimit),
+		UsedCount:      int(c.UsedCount),
+		Active:         c.Active,
+	}
+	if c.StartsAt != nil {
+		startsAt := c.StartsAt.AsTime()
+		resp.StartsAt = &startsAt
+	}
+	if c.EndsAt != nil {
+		endsAt := c.EndsAt.AsTime()
+		resp.EndsAt = &endsAt
+	}
+	return resp
+}
@@ -1,6 +1,9 @@
 package dto
 
-import gifting_pb "gifting-service/proto"
+import (
+	gifting_pb "gifting-service/proto"
+	"time"
+)
 
 // SendGiftRequest adalah DTO untuk request body saat mengirim hadiah.
 type SendGiftRequest struct {
@@ -27,4 +30,30 @@ type TemplateSendGiftResponse struct {
 	StatusCode int              	`json:"status_code" validate:"required" example:"201"`
 	Message    string           	`json:"message" validate:"required" example:"Create data success"`
 	Data       *gifting_pb.SendGiftResponse 	`json:"data"`
-}
\ No newline at end of file
+}
+
+// DTO untuk response menerima hadiah (JSON)
+type AcceptGiftResponse struct {
+	GiftID          string    `json:"gift_id" example:"7"`
+	BookID          string    `json:"book_id" example:"68a1f0c2e4b0a1b2c3d4e5f6"`
+	RecipientUserID string    `json:"recipient_user_id" example:"5"`
+	Status          string    `json:"status" example:"accepted"`
+	AcceptedAt      time.Time `json:"accepted_at"`
+}
+
+type AcceptGiftResponseApi struct {
+	StatusCode 	int              	`json:"status_code" validate:"required" example:"200"`
+	Message    	string           	`json:"message" validate:"required" example:"Accept gift successfully"`
+	Data 		AcceptGiftResponse 	`json:"data"`
+}
+
+// Mapper dari gRPC response ke DTO response
+func ToAcceptGiftResponse(grpcResp *gifting_pb.AcceptGiftResponse) *AcceptGiftResponse {
+	return &AcceptGiftResponse{
+		GiftID:          grpcResp.GiftId,
+		BookID:          grpcResp.BookId,
+		RecipientUserID: grpcResp.RecipientUserId,
+		Status:          grpcResp.Status,
+		AcceptedAt:      grpcResp.AcceptedAt.AsTime(),
+	}
+}
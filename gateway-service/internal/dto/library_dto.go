@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	pb "transaction-service/proto"
+)
+
+// DTO untuk satu buku di library user (JSON)
+type LibraryBookResponse struct {
+	BookID      string    `json:"book_id" example:"68a1f0c2e4b0a1b2c3d4e5f6"`
+	Title       string    `json:"title" example:"Laskar Pelangi"`
+	Author      string    `json:"author" example:"Andrea Hirata"`
+	Publisher   string    `json:"publisher" example:"Bentang Pustaka"`
+	Category    string    `json:"category" example:"Novel"`
+	Description string    `json:"description"`
+	Source      string    `json:"source" example:"purchase"` // "purchase" atau "gift"
+	ReferenceID string    `json:"reference_id" example:"11"` // ID transaksi atau "gift-<id>"
+	AcquiredAt  time.Time `json:"acquired_at"`
+}
+
+type LibraryResponse struct {
+	UserID        string                `json:"user_id"`
+	Books         []LibraryBookResponse `json:"books"`
+	NextPageToken string                `json:"next_page_token,omitempty" example:"MTc0MDgyMzIwMDAwMDAwMDAwMDoxMQ"`
+}
+
+type LibraryResponseApi struct {
+	StatusCode 	int              	`json:"status_code" validate:"required" example:"200"`
+	Message    	string           	`json:"message" validate:"required" example:"Get library successfully"`
+	Data 		LibraryResponse 	`json:"data"`
+}
+
+// Mapper dari gRPC response ke DTO response
+func ToLibraryResponse(grpcResp *pb.ListLibraryResponse) *LibraryResponse {
+	books := make([]LibraryBookResponse, len(grpcResp.Books))
+	for i, book := range grpcResp.Books {
+		books[i] = LibraryBookResponse{
+			BookID:      book.Entitlement.GetBookId(),
+			Title:       book.Title,
+			Author:      book.Author,
+			Publisher:   book.Publisher,
+			Category:    book.Category,
+			Description: book.Description,
+			Source:      book.Entitlement.GetSource(),
+			ReferenceID: book.Entitlement.GetReferenceId(),
+			AcquiredAt:  book.Entitlement.GetGrantedAt().AsTime(),
+		}
+	}
+
+	return &LibraryResponse{
+		UserID:        grpcResp.UserId,
+		Books:         books,
+		NextPageToken: grpcResp.NextPageToken,
+	}
+}
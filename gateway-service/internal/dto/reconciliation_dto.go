@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"shared/money"
+	"time"
+	pb "transaction-service/proto"
+)
+
+// DTO untuk menjalankan rekonsiliasi manual (admin). Rentang waktu opsional.
+type RunReconciliationRequest struct {
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+}
+
+// DTO untuk satu ketidakcocokan antara transaksi dan wallet
+type ReconciliationMismatchResponse struct {
+	ID                string      `json:"id"`
+	TransactionID     string      `json:"transaction_id" example:"99"`
+	UserID            string      `json:"user_id" example:"1"`
+	TransactionStatus string      `json:"transaction_status" example:"completed"`
+	Type              string      `json:"type" example:"missing_debit"`
+	ExpectedAmount    money.Money `json:"expected_amount" swaggertype:"number" example:"50000"`
+	ActualAmount      money.Money `json:"actual_amount" swaggertype:"number" example:"0"`
+	Detail            string      `json:"detail"`
+}
+
+// DTO untuk satu run rekonsiliasi
+type ReconciliationRunResponse struct {
+	ID            string                           `json:"id"`
+	Trigger       string                           `json:"trigger" example:"scheduled"`
+	Status        string                           `json:"status" example:"completed"`
+	WindowStart   time.Time                        `json:"window_start"`
+	WindowEnd     time.Time                        `json:"window_end"`
+	CheckedCount  int                              `json:"checked_count" example:"120"`
+	MismatchCount int                              `json:"mismatch_count" example:"1"`
+	Error         string                           `json:"error,omitempty"`
+	StartedAt     time.Time                        `json:"started_at"`
+	FinishedAt    *time.Time                       `json:"finished_at,omitempty"`
+	Mismatches    []ReconciliationMismatchResponse `json:"mismatches,omitempty"`
+}
+
+type ReconciliationRunResponseApi struct {
+	StatusCode 	int              			`json:"status_code" validate:"required" example:"200"`
+	Message    	string           			`json:"message" validate:"required" example:"Get reconciliation run successfully"`
+	Data 		ReconciliationRunResponse 	`json:"data"`
+}
+
+// DTO untuk satu halaman daftar run rekonsiliasi
+type ReconciliationRunListResponse struct {
+	Runs     []ReconciliationRunResponse `json:"runs"`
+	Total    int64                       `json:"total"`
+	Page     int                         `json:"page"`
+	PageSize int                         `json:"page_size"`
+}
+
+type ReconciliationRunListResponseApi struct {
+	StatusCode 	int              				`json:"status_code" validate:"required" example:"200"`
+	Message    	string           				`json:"message" validate:"required" example:"Get reconciliation runs successfully"`
+	Data 		ReconciliationRunListResponse 	`json:"data"`
+}
+
+// Mapper dari gRPC response ke DTO response
+func ToReconciliationRunResponse(r *pb.ReconciliationRun) *ReconciliationRunResponse {
+	resp := &ReconciliationRunResponse{
+		ID:            r.Id,
+		Trigger:       r.Trigger,
+		Status:        r.Status,
+		WindowStart:   r.WindowStart.AsTime(),
+		WindowEnd:     r.WindowEnd.AsTime(),
+		CheckedCount:  int(r.CheckedCount),
+		MismatchCount: int(r.MismatchCount),
+		Error:         r.Error,
+		StartedAt:     r.StartedAt.AsTime(),
+	}
+	if r.FinishedAt != nil {
+		finishedAt := r.FinishedAt.AsTime()
+		resp.FinishedAt = &finishedAt
+	}
+	for _, m := range r.Mismatches {
+		resp.Mismatches = append(resp.Mismatches, ReconciliationMismatchResponse{
+			ID:                m.Id,
+			TransactionID:     m.TransactionId,
+			UserID:            m.UserId,
+			TransactionStatus: m.TransactionStatus,
+			Type:              m.Type,
+			ExpectedAmount:    money.FromMinor(m.ExpectedAmount.GetMinorUnits()),
+			ActualAmount:      money.FromMinor(m.ActualAmount.GetMinorUnits()),
+			Detail:            m.Detail,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"shared/money"
+	"time"
+	pb "transaction-service/proto"
+)
+
+// DTO untuk pendapatan dalam satu periode
+type RevenueBucketResponse struct {
+	PeriodStart      time.Time   `json:"period_start"`
+	Revenue          money.Money `json:"revenue" swaggertype:"number" example:"150000"`
+	TransactionCount int64       `json:"transaction_count" example:"3"`
+	BuyerCount       int64       `json:"buyer_count" example:"2"`
+}
+
+// DTO untuk laporan pendapatan (admin)
+type RevenueReportResponse struct {
+	Period            string                  `json:"period" example:"day"`
+	Buckets           []RevenueBucketResponse `json:"buckets"`
+	TotalRevenue      money.Money             `json:"total_revenue" swaggertype:"number" example:"150000"`
+	TotalTransactions int64                   `json:"total_transactions" example:"3"`
+	TotalBuyers       int64                   `json:"total_buyers" example:"2"`
+}
+
+type RevenueReportResponseApi struct {
+	StatusCode 	int              		`json:"status_code" validate:"required" example:"200"`
+	Message    	string           		`json:"message" validate:"required" example:"Get revenue report successfully"`
+	Data 		RevenueReportResponse 	`json:"data"`
+}
+
+// DTO untuk penjualan satu buku. GrossSales adalah penjualan kotor (harga x quantity, sebelum
+// diskon kupon). NetSales sudah dikurangi bagian diskon kupon transaksinya, sejalan dengan
+// revenue di laporan pendapatan.
+type BookSalesResponse struct {
+	BookID     string      `json:"book_id" example:"101"`
+	Quantity   int64       `json:"quantity" example:"5"`
+	GrossSales money.Money `json:"gross_sales" swaggertype:"number" example:"250000"`
+	NetSales   money.Money `json:"net_sales" swaggertype:"number" example:"225000"`
+}
+
+type TopBooksReportResponseApi struct {
+	StatusCode 	int              	`json:"status_code" validate:"required" example:"200"`
+	Message    	string           	`json:"message" validate:"required" example:"Get top books report successfully"`
+	Data 		[]BookSalesResponse `json:"data"`
+}
+
+// DTO untuk penjualan satu kategori, dengan GrossSales dan NetSales seperti BookSalesResponse
+type CategorySalesResponse struct {
+	Category   string      `json:"category" example:"Fiction"`
+	Quantity   int64       `json:"quantity" example:"12"`
+	GrossSales money.Money `json:"gross_sales" swaggertype:"number" example:"600000"`
+	NetSales   money.Money `json:"net_sales" swaggertype:"number" example:"540000"`
+}
+
+type CategoryReportResponseApi struct {
+	StatusCode 	int              		`json:"status_code" validate:"required" example:"200"`
+	Message    	string           		`json:"message" validate:"required" example:"Get category report successfully"`
+	Data 		[]CategorySalesResponse `json:"data"`
+}
+
+// Mapper dari gRPC response ke DTO response
+func ToRevenueReportResponse(r *pb.RevenueReportResponse) *RevenueReportResponse {
+	resp := &RevenueReportResponse{
+		Period:            r.Period,
+		Buckets:           make([]RevenueBucketResponse, len(r.Buckets)),
+		TotalRevenue:      money.FromMinor(r.TotalRevenue.GetMinorUnits()),
+		TotalTransactions: r.TotalTransactions,
+		TotalBuyers:       r.TotalBuyers,
+	}
+	for i, b := range r.Buckets {
+		resp.Buckets[i] = RevenueBucketResponse{
+			PeriodStart:      b.PeriodStart.AsTime(),
+			Revenue:          money.FromMinor(b.Revenue.GetMinorUnits()),
+			TransactionCount: b.TransactionCount,
+			BuyerCount:       b.BuyerCount,
+		}
+	}
+	return resp
+}
+
+func ToBookSalesResponses(books []*pb.BookSales) []BookSalesResponse {
+	resp := make([]BookSalesResponse, len(books))
+	for i, b := range books {
+		resp[i] = BookSalesResponse{
+			BookID:     b.BookId,
+			Quantity:   b.Quantity,
+			GrossSales: money.FromMinor(b.GrossSales.GetMinorUnits()),
+			NetSales:   money.FromMinor(b.NetSales.GetMinorUnits()),
+		}
+	}
+	return resp
+}
+
+func ToCategorySalesResponses(categories []*pb.CategorySales) []CategorySalesResponse {
+	resp := make([]CategorySalesResponse, len(categories))
+	for i, c := range categories {
+		resp[i] = CategorySalesResponse{
+			Category:   c.Category,
+			Quantity:   c.Quantity,
+			GrossSales: money.FromMinor(c.GrossSales.GetMinorUnits()),
+			NetSales:   money.FromMinor(c.NetSales.GetMinorUnits()),
+		}
+	}
+	return resp
+}
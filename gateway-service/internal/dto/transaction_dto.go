@@ -1,38 +1,90 @@
 package dto
 
 import (
+	"shared/money"
 	"time"
 	pb "transaction-service/proto"
 )
 
 // DTO untuk request dari client (JSON)
 type CreateTransactionRequest struct {
-	Items  []BookOrderItem `json:"items"`
+	Items      []BookOrderItem `json:"items"`
+	QuoteID    string          `json:"quote_id,omitempty"`
+	CouponCode string          `json:"coupon_code,omitempty" example:"HEMAT10"`
 }
 type BookOrderItem struct {
 	BookID   string `json:"book_id"`
 	Quantity int    `json:"quantity"`
 }
 
+// DTO untuk request quote harga
+type QuoteRequest struct {
+	Items []BookOrderItem `json:"items"`
+}
+
+// DTO untuk request checkout keranjang (body opsional)
+type CheckoutCartRequest struct {
+	CouponCode string `json:"coupon_code,omitempty" example:"HEMAT10"`
+}
+
+// DTO untuk request pembatalan transaksi (body opsional)
+type CancelTransactionRequest struct {
+	Reason string `json:"reason" example:"Salah pilih buku"`
+}
+
 // DTO untuk response ke client (JSON)
 type TransactionResponse struct {
 	TransactionID   string                      `json:"transaction_id"`
 	UserID          string                      `json:"user_id"`
 	TransactionDate time.Time                   `json:"transaction_date"`
-	TotalAmount     float64                     `json:"total_amount"`
+	SubtotalAmount  money.Money                 `json:"subtotal_amount" swaggertype:"number"`
+	Discount        *DiscountLineResponse       `json:"discount,omitempty"`
+	TotalAmount     money.Money                 `json:"total_amount" swaggertype:"number"`
 	Status          string                      `json:"status"`
+	FailureReason   string                      `json:"failure_reason,omitempty"`
+	FailureCode     string                      `json:"failure_code,omitempty"`
 	Details         []TransactionDetailResponse `json:"details"`
 }
+type DiscountLineResponse struct {
+	CouponCode  string      `json:"coupon_code"`
+	Description string      `json:"description"`
+	Amount      money.Money `json:"amount" swaggertype:"number"`
+}
 type TransactionDetailResponse struct {
 	BookID       string  `json:"book_id"`
 	Quantity     int     `json:"quantity"`
-	PricePerUnit float64 `json:"price_per_unit"`
+	PricePerUnit money.Money `json:"price_per_unit" swaggertype:"number"`
+}
+
+// DTO untuk data event status di stream Server-Sent Events transaksi
+type TransactionStatusEvent struct {
+	TransactionID string    `json:"transaction_id" example:"11"`
+	Status        string    `json:"status" example:"completed"`
+	FailureCode   string    `json:"failure_code,omitempty"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type QuoteResponse struct {
+	QuoteID     string                      `json:"quote_id"`
+	UserID      string                      `json:"user_id"`
+	Items       []TransactionDetailResponse `json:"items"`
+	TotalAmount money.Money                 `json:"total_amount" swaggertype:"number"`
+	ExpiresAt   time.Time                   `json:"expires_at"`
+}
+
+type QuoteResponseApi struct {
+	StatusCode 	int              	`json:"status_code" validate:"required" example:"200"`
+	Message    	string           	`json:"message" validate:"required" example:"Success quote order"`
+	Data 		QuoteResponse 		`json:"data"`
 }
 
 type TransactionListResponse struct {
 	StatusCode 	int              	`json:"status_code" validate:"required" example:"200"`
 	Message    	string           	`json:"message" validate:"required" example:"Create data success"`
 	Data 		[]*TransactionResponse `json:"data"`
+	NextPageToken	string			`json:"next_page_token,omitempty" example:"MTc0MDgyMzIwMDAwMDAwMDAwMDoxMQ"`
+	TotalCount	int64			`json:"total_count" example:"42"`
 }
 
 type TransactionResponseApi struct {
@@ -48,18 +100,58 @@ func ToTransactionResponse(grpcResp *pb.TransactionResponse) *TransactionRespons
 		details[i] = TransactionDetailResponse{
 			BookID:       d.BookId,
 			Quantity:     int(d.Quantity),
-			PricePerUnit: d.PricePerUnit,
+			PricePerUnit: money.FromMinor(d.PricePerUnit.GetMinorUnits()),
 		}
 	}
 
-	return &TransactionResponse{
+	resp := &TransactionResponse{
 		TransactionID:   grpcResp.TransactionId,
 		UserID:          grpcResp.UserId,
 		TransactionDate: grpcResp.TransactionDate.AsTime(),
-		TotalAmount:     grpcResp.TotalAmount,
+		SubtotalAmount:  money.FromMinor(grpcResp.SubtotalAmount.GetMinorUnits()),
+		TotalAmount:     money.FromMinor(grpcResp.TotalAmount.GetMinorUnits()),
 		Status:          grpcResp.Status,
+		FailureReason:   grpcResp.FailureReason,
+		FailureCode:     grpcResp.FailureCode,
 		Details:         details,
 	}
+	if grpcResp.Discount != nil {
+		resp.Discount = &DiscountLineResponse{
+			CouponCode:  grpcResp.Discount.CouponCode,
+			Description: grpcResp.Discount.Description,
+			Amount:      money.FromMinor(grpcResp.Discount.Amount.GetMinorUnits()),
+		}
+	}
+	return resp
+}
+
+func ToTransactionStatusEvent(grpcResp *pb.TransactionStatusEvent) *TransactionStatusEvent {
+	return &TransactionStatusEvent{
+		TransactionID: grpcResp.TransactionId,
+		Status:        grpcResp.Status,
+		FailureCode:   grpcResp.FailureCode,
+		FailureReason: grpcResp.FailureReason,
+		UpdatedAt:     grpcResp.UpdatedAt.AsTime(),
+	}
+}
+
+func ToQuoteResponse(grpcResp *pb.QuoteResponse) *QuoteResponse {
+	items := make([]TransactionDetailResponse, len(grpcResp.Items))
+	for i, d := range grpcResp.Items {
+		items[i] = TransactionDetailResponse{
+			BookID:       d.BookId,
+			Quantity:     int(d.Quantity),
+			PricePerUnit: money.FromMinor(d.PricePerUnit.GetMinorUnits()),
+		}
+	}
+
+	return &QuoteResponse{
+		QuoteID:     grpcResp.QuoteId,
+		UserID:      grpcResp.UserId,
+		Items:       items,
+		TotalAmount: money.FromMinor(grpcResp.TotalAmount.GetMinorUnits()),
+		ExpiresAt:   grpcResp.ExpiresAt.AsTime(),
+	}
 }
 
 func ToTransactionListResponse(grpcResp *pb.GetUserTransactionsResponse) []*TransactionResponse {
@@ -1,33 +1,45 @@
 package dto
 
-import wallet_pb "wallet-service/proto"
+import (
+	"shared/money"
+	"time"
+	wallet_pb "wallet-service/proto"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
 
 // TopUpRequest adalah DTO untuk request body saat melakukan top-up.
 type TopUpRequest struct {
-	Amount float64 `json:"amount" validate:"required,gt=0"`
+	Amount money.Money `json:"amount" validate:"required,gt=0" swaggertype:"number" example:"50000"`
 	Method string  `json:"method" validate:"required"`
 }
 
 // BalanceResponse adalah DTO untuk menampilkan saldo ke client.
 type BalanceResponse struct {
 	UserID  string  `json:"user_id"`
-	Balance float64 `json:"balance"`
+	Balance money.Money `json:"balance" swaggertype:"number"`
+	// Saldo dikurangi dana yang sedang ditahan untuk pesanan yang belum dibayar
+	AvailableBalance money.Money `json:"available_balance" swaggertype:"number"`
 }
 
-// TopUpResponse dummy struct untuk Swagger docs
+// TopUpResponse adalah DTO hasil top-up. Nama field JSON sama dengan response lama
+// agar klien yang sudah ada tetap bisa membaca nominal sebagai angka.
 type TopUpResponse struct {
-	TransactionID string  `json:"transaction_id" example:"trx123"`
-	UserID        string  `json:"user_id" example:"user123"`
-	Amount        float64 `json:"amount" example:"50000"`
-	Method        string  `json:"method" example:"credit_card"`
-	Status        string  `json:"status" example:"success"`
-	CreatedAt     string  `json:"created_at" example:"2025-07-25T15:00:00Z"`
+	TopUpID   string                 `json:"top_up_id,omitempty" example:"12"`
+	UserID    string                 `json:"user_id,omitempty" example:"1"`
+	Amount    money.Money            `json:"amount,omitempty" swaggertype:"number" example:"50000"`
+	Status    string                 `json:"status,omitempty" example:"pending"`
+	TopUpDate *timestamppb.Timestamp `json:"top_up_date,omitempty" swaggerignore:"true"`
+
+	// Top-up dibuat pending; saldo bertambah setelah pembayaran di CheckoutURL dikonfirmasi provider
+	PaymentReference string `json:"payment_reference,omitempty" example:"fake_12_a1b2c3d4e5f6"`
+	CheckoutURL      string `json:"checkout_url,omitempty" example:"http://localhost:8000/fake-checkout/fake_12_a1b2c3d4e5f6"`
 }
 
 type TopUpResponseApi struct {
 	StatusCode 	int              		`json:"status_code" validate:"required" example:"201"`
 	Message    	string           		`json:"message" validate:"required" example:"Create data success"`
-	Data 		*wallet_pb.TopUpResponse `json:"data"`
+	Data 		TopUpResponse `json:"data"`
 }
 
 type TopUpResponseDto struct {
@@ -40,4 +52,266 @@ type BalanceResponseApi struct {
 	StatusCode 	int              	`json:"status_code" validate:"required" example:"200"`
 	Message    	string           	`json:"message" validate:"required" example:"Get data success"`
 	Data 		BalanceResponse	`json:"data"`
-}
\ No newline at end of file
+}
+
+// LedgerEntryResponse adalah DTO untuk satu entri ledger wallet.
+type LedgerEntryResponse struct {
+	ID            string    `json:"id" example:"12"`
+	EntryType     string    `json:"entry_type" example:"debit"`
+	Amount        money.Money `json:"amount" swaggertype:"number" example:"-50000"`
+	BalanceAfter  money.Money `json:"balance_after" swaggertype:"number" example:"150000"`
+	ReferenceType string    `json:"reference_type,omitempty" example:"transaction"`
+	ReferenceID   string    `json:"reference_id,omitempty" example:"42"`
+	Description   string    `json:"description,omitempty" example:"Payment for transaction 42"`
+	CreatedAt     time.Time `json:"created_at" example:"2025-07-25T15:00:00Z"`
+}
+
+// LedgerResponse adalah DTO untuk satu halaman ledger wallet.
+type LedgerResponse struct {
+	UserID   string                `json:"user_id" example:"1"`
+	Entries  []LedgerEntryResponse `json:"entries"`
+	Total    int64                 `json:"total" example:"35"`
+	Page     int32                 `json:"page" example:"1"`
+	PageSize int32                 `json:"page_size" example:"20"`
+}
+
+type LedgerResponseApi struct {
+	StatusCode 	int              	`json:"status_code" validate:"required" example:"200"`
+	Message    	string           	`json:"message" validate:"required" example:"Get data success"`
+	Data 		LedgerResponse	`json:"data"`
+}
+
+// ToLedgerResponse memetakan response gRPC GetLedger ke DTO.
+func ToLedgerResponse(userID string, grpcResp *wallet_pb.GetLedgerResponse) LedgerResponse {
+	entries := make([]LedgerEntryResponse, len(grpcResp.Entries))
+	for i, e := range grpcResp.Entries {
+		entries[i] = LedgerEntryResponse{
+			ID:            e.Id,
+			EntryType:     e.EntryType,
+			Amount:        money.FromMinor(e.Amount.GetMinorUnits()),
+			BalanceAfter:  money.FromMinor(e.BalanceAfter.GetMinorUnits()),
+			ReferenceType: e.ReferenceType,
+			ReferenceID:   e.ReferenceId,
+			Description:   e.Description,
+			CreatedAt:     e.CreatedAt.AsTime(),
+		}
+	}
+
+	return LedgerResponse{
+		UserID:   userID,
+		Entries:  entries,
+		Total:    grpcResp.Total,
+		Page:     grpcResp.Page,
+		PageSize: grpcResp.PageSize,
+	}
+}
+
+// ToTopUpResponse memetakan response gRPC TopUp ke DTO.
+func ToTopUpResponse(grpcResp *wallet_pb.TopUpResponse) TopUpResponse {
+	return TopUpResponse{
+		TopUpID:   grpcResp.TopUpId,
+		UserID:    grpcResp.UserId,
+		Amount:    money.FromMinor(grpcResp.Amount.GetMinorUnits()),
+		Status:    grpcResp.Status,
+		TopUpDate: grpcResp.TopUpDate,
+
+		PaymentReference: grpcResp.PaymentReference,
+		CheckoutURL:      grpcResp.CheckoutUrl,
+	}
+}
+
+// TopUpStatusChangeResponse adalah DTO untuk satu perubahan status top-up.
+type TopUpStatusChangeResponse struct {
+	Status    string    `json:"status" example:"success"`
+	CreatedAt time.Time `json:"created_at" example:"2025-07-25T15:02:00Z"`
+}
+
+// TopUpDetailResponse adalah DTO untuk riwayat dan bukti top-up.
+type TopUpDetailResponse struct {
+	ID               string                      `json:"id" example:"12"`
+	UserID           string                      `json:"user_id" example:"1"`
+	Amount           money.Money                 `json:"amount" swaggertype:"number" example:"50000"`
+	Method           string                      `json:"method" example:"Transfer"`
+	Status           string                      `json:"status" example:"success"`
+	Provider         string                      `json:"provider,omitempty" example:"fake"`
+	PaymentReference string                      `json:"payment_reference,omitempty" example:"fake_12_a1b2c3d4e5f6"`
+	CheckoutURL      string                      `json:"checkout_url,omitempty" example:"http://localhost:8000/fake-checkout/fake_12_a1b2c3d4e5f6"`
+	CreatedAt        time.Time                   `json:"created_at" example:"2025-07-25T15:00:00Z"`
+	UpdatedAt        time.Time                   `json:"updated_at" example:"2025-07-25T15:02:00Z"`
+	Transitions      []TopUpStatusChangeResponse `json:"transitions"`
+}
+
+// TopUpListResponse adalah DTO untuk satu halaman riwayat top-up.
+type TopUpListResponse struct {
+	TopUps   []TopUpDetailResponse `json:"top_ups"`
+	Total    int64                 `json:"total" example:"4"`
+	Page     int32                 `json:"page" example:"1"`
+	PageSize int32                 `json:"page_size" example:"20"`
+}
+
+type TopUpDetailResponseApi struct {
+	StatusCode 	int              		`json:"status_code" validate:"required" example:"200"`
+	Message    	string           		`json:"message" validate:"required" example:"Get data success"`
+	Data 		TopUpDetailResponse	`json:"data"`
+}
+
+type TopUpListResponseApi struct {
+	StatusCode 	int              	`json:"status_code" validate:"required" example:"200"`
+	Message    	string           	`json:"message" validate:"required" example:"Get data success"`
+	Data 		TopUpListResponse	`json:"data"`
+}
+
+// ToTopUpDetailResponse memetakan pesan gRPC TopUp ke DTO.
+func ToTopUpDetailResponse(topUp *wallet_pb.TopUp) TopUpDetailResponse {
+	transitions := make([]TopUpStatusChangeResponse, len(topUp.Transitions))
+	for i, change := range topUp.Transitions {
+		transitions[i] = TopUpStatusChangeResponse{Status: change.Status, CreatedAt: change.CreatedAt.AsTime()}
+	}
+
+	return TopUpDetailResponse{
+		ID:               topUp.Id,
+		UserID:           topUp.UserId,
+		Amount:           money.FromMinor(topUp.Amount.GetMinorUnits()),
+		Method:           topUp.Method,
+		Status:           topUp.Status,
+		Provider:         topUp.Provider,
+		PaymentReference: topUp.PaymentReference,
+		CheckoutURL:      topUp.CheckoutUrl,
+		CreatedAt:        topUp.CreatedAt.AsTime(),
+		UpdatedAt:        topUp.UpdatedAt.AsTime(),
+		Transitions:      transitions,
+	}
+}
+
+// ToTopUpListResponse memetakan response gRPC ListTopUps ke DTO.
+func ToTopUpListResponse(grpcResp *wallet_pb.ListTopUpsResponse) TopUpListResponse {
+	topUps := make([]TopUpDetailResponse, len(grpcResp.TopUps))
+	for i, topUp := range grpcResp.TopUps {
+		topUps[i] = ToTopUpDetailResponse(topUp)
+	}
+
+	return TopUpListResponse{
+		TopUps:   topUps,
+		Total:    grpcResp.Total,
+		Page:     grpcResp.Page,
+		PageSize: grpcResp.PageSize,
+	}
+}
+
+// SpendingLimitsRequest adalah DTO untuk mengatur batas pengeluaran wallet.
+// Batas yang tidak diisi atau 0 berarti tidak dibatasi.
+type SpendingLimitsRequest struct {
+	DailyLimit          money.Money `json:"daily_limit" swaggertype:"number" example:"50000"`
+	MonthlyLimit        money.Money `json:"monthly_limit" swaggertype:"number" example:"500000"`
+	PerTransactionLimit money.Money `json:"per_transaction_limit" swaggertype:"number" example:"25000"`
+}
+
+// SpendingLimitsResponse adalah DTO batas pengeluaran beserta pemakaiannya.
+type SpendingLimitsResponse struct {
+	UserID              string      `json:"user_id" example:"3"`
+	DailyLimit          money.Money `json:"daily_limit" swaggertype:"number" example:"50000"`
+	MonthlyLimit        money.Money `json:"monthly_limit" swaggertype:"number" example:"500000"`
+	PerTransactionLimit money.Money `json:"per_transaction_limit" swaggertype:"number" example:"25000"`
+	SpentToday          money.Money `json:"spent_today" swaggertype:"number" example:"10000"`
+	SpentThisMonth      money.Money `json:"spent_this_month" swaggertype:"number" example:"120000"`
+}
+
+type SpendingLimitsResponseApi struct {
+	StatusCode 	int              		`json:"status_code" validate:"required" example:"200"`
+	Message    	string           		`json:"message" validate:"required" example:"Get data success"`
+	Data 		SpendingLimitsResponse	`json:"data"`
+}
+
+// ToSpendingLimitsResponse memetakan response gRPC batas pengeluaran ke DTO.
+func ToSpendingLimitsResponse(grpcResp *wallet_pb.SpendingLimitsResponse) SpendingLimitsResponse {
+	return SpendingLimitsResponse{
+		UserID:              grpcResp.UserId,
+		DailyLimit:          money.FromMinor(grpcResp.DailyLimit.GetMinorUnits()),
+		MonthlyLimit:        money.FromMinor(grpcResp.MonthlyLimit.GetMinorUnits()),
+		PerTransactionLimit: money.FromMinor(grpcResp.PerTransactionLimit.GetMinorUnits()),
+		SpentToday:          money.FromMinor(grpcResp.SpentToday.GetMinorUnits()),
+		SpentThisMonth:      money.FromMinor(grpcResp.SpentThisMonth.GetMinorUnits()),
+	}
+}
+
+// PaymentWebhookResponse adalah DTO hasil pemrosesan webhook payment provider.
+type PaymentWebhookResponse struct {
+	TopUpID string `json:"top_up_id" example:"12"`
+	Status  string `json:"status" example:"success"`
+}
+
+type PaymentWebhookResponseApi struct {
+	StatusCode 	int              		`json:"status_code" validate:"required" example:"200"`
+	Message    	string           		`json:"message" validate:"required" example:"Webhook processed"`
+	Data 		PaymentWebhookResponse	`json:"data"`
+}
+
+// TransferRequest adalah DTO untuk request body saat mentransfer saldo ke user lain.
+type TransferRequest struct {
+	ToUserID string      `json:"to_user_id" validate:"required" example:"2"`
+	Amount   money.Money `json:"amount" validate:"required,gt=0" swaggertype:"number" example:"25000"`
+	Note     string      `json:"note,omitempty" validate:"max=255" example:"Uang jajan minggu ini"`
+}
+
+// TransferResponse adalah DTO untuk satu transfer saldo.
+type TransferResponse struct {
+	ID         string      `json:"id" example:"7"`
+	FromUserID string      `json:"from_user_id" example:"1"`
+	ToUserID   string      `json:"to_user_id" example:"2"`
+	Amount     money.Money `json:"amount" swaggertype:"number" example:"25000"`
+	Note       string      `json:"note,omitempty" example:"Uang jajan minggu ini"`
+	CreatedAt  time.Time   `json:"created_at" example:"2025-07-25T15:00:00Z"`
+}
+
+// TransferResultResponse adalah DTO hasil transfer beserta saldo pengirim setelahnya.
+type TransferResultResponse struct {
+	Transfer TransferResponse `json:"transfer"`
+	Balance  money.Money      `json:"balance" swaggertype:"number" example:"75000"`
+}
+
+// TransferListResponse adalah DTO untuk satu halaman riwayat transfer.
+type TransferListResponse struct {
+	Transfers []TransferResponse `json:"transfers"`
+	Total     int64              `json:"total" example:"3"`
+	Page      int32              `json:"page" example:"1"`
+	PageSize  int32              `json:"page_size" example:"20"`
+}
+
+type TransferResponseApi struct {
+	StatusCode 	int              		`json:"status_code" validate:"required" example:"200"`
+	Message    	string           		`json:"message" validate:"required" example:"Transfer success"`
+	Data 		TransferResultResponse	`json:"data"`
+}
+
+type TransferListResponseApi struct {
+	StatusCode 	int              		`json:"status_code" validate:"required" example:"200"`
+	Message    	string           		`json:"message" validate:"required" example:"Get data success"`
+	Data 		TransferListResponse	`json:"data"`
+}
+
+// ToTransferResponse memetakan pesan gRPC Transfer ke DTO.
+func ToTransferResponse(transfer *wallet_pb.Transfer) TransferResponse {
+	return TransferResponse{
+		ID:         transfer.Id,
+		FromUserID: transfer.FromUserId,
+		ToUserID:   transfer.ToUserId,
+		Amount:     money.FromMinor(transfer.Amount.GetMinorUnits()),
+		Note:       transfer.Note,
+		CreatedAt:  transfer.CreatedAt.AsTime(),
+	}
+}
+
+// ToTransferListResponse memetakan response gRPC ListTransfers ke DTO.
+func ToTransferListResponse(grpcResp *wallet_pb.ListTransfersResponse) TransferListResponse {
+	transfers := make([]TransferResponse, len(grpcResp.Transfers))
+	for i, transfer := range grpcResp.Transfers {
+		transfers[i] = ToTransferResponse(transfer)
+	}
+
+	return TransferListResponse{
+		Transfers: transfers,
+		Total:     grpcResp.Total,
+		Page:      grpcResp.Page,
+		PageSize:  grpcResp.PageSize,
+	}
+}
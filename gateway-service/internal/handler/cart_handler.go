@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"gateway-service/internal/dto"
+	pb "transaction-service/proto"
+)
+
+// CartHandler menerjemahkan request keranjang ke gRPC transaction-service.
+type CartHandler struct {
+	transactionClient pb.TransactionServiceClient
+}
+
+func NewCartHandler(client pb.TransactionServiceClient) *CartHandler {
+	return &CartHandler{transactionClient: client}
+}
+
+// GetCart godoc
+// @Summary      Ambil keranjang belanja user
+// @Description  Mengambil isi keranjang dengan harga dan ketersediaan buku terbaru dari book-service.
+// @Tags         Gateway - Cart
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200   {object}  dto.CartResponseApi
+// @Failure      401   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /cart [get]
+func (h *CartHandler) GetCart(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Invalid user ID in token",
+		})
+	}
+
+	grpcResp, err := h.transactionClient.GetCart(c.Request().Context(), &pb.GetCartRequest{UserId: userID})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get cart", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CartResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get cart successfully",
+		Data: *dto.ToCartResponse(grpcResp),
+	})
+}
+
+// AddCartItem godoc
+// @Summary      Tambah buku ke keranjang
+// @Description  Menambahkan buku ke keranjang. Jika buku sudah ada, quantity dijumlahkan.
+// @Tags         Gateway - Cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body  dto.AddCartItemRequest  true  "Buku yang ditambahkan"
+// @Success      200   {object}  dto.CartResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      401   {object}  dto.ErrorResponse
+// @Failure      409   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /cart/items [post]
+func (h *CartHandler) AddCartItem(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Invalid user ID in token",
+		})
+	}
+
+	var req dto.AddCartItemRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error: err.Error(),
+		})
+	}
+	if req.BookID == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "book_id is required",
+		})
+	}
+
+	grpcResp, err := h.transactionClient.AddCartItem(c.Request().Context(), &pb.CartItemRequest{
+		UserId:   userID,
+		BookId:   req.BookID,
+		Quantity: int32(req.Quantity),
+	})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to add book to cart", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CartResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Book added to cart",
+		Data: *dto.ToCartResponse(grpcResp),
+	})
+}
+
+// UpdateCartItem godoc
+// @Summary      Ubah quantity buku di keranjang
+// @Tags         Gateway - Cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        book_id  path  string  true  "Book ID"
+// @Param        body  body  dto.UpdateCartItemRequest  true  "Quantity baru"
+// @Success      200   {object}  dto.CartResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      401   {object}  dto.ErrorResponse
+// @Failure      404   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /cart/items/{book_id} [put]
+func (h *CartHandler) UpdateCartItem(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Invalid user ID in token",
+		})
+	}
+
+	var req dto.UpdateCartItemRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error: err.Error(),
+		})
+	}
+
+	grpcResp, err := h.transactionClient.UpdateCartItem(c.Request().Context(), &pb.CartItemRequest{
+		UserId:   userID,
+		BookId:   c.Param("book_id"),
+		Quantity: int32(req.Quantity),
+	})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to update cart", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CartResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Cart updated",
+		Data: *dto.ToCartResponse(grpcResp),
+	})
+}
+
+// RemoveCartItem godoc
+// @Summary      Hapus buku dari keranjang
+// @Tags         Gateway - Cart
+// @Produce      json
+// @Security     BearerAuth
+// @Param        book_id  path  string  true  "Book ID"
+// @Success      200   {object}  dto.CartResponseApi
+// @Failure      401   {object}  dto.ErrorResponse
+// @Failure      404   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /cart/items/{book_id} [delete]
+func (h *CartHandler) RemoveCartItem(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Invalid user ID in token",
+		})
+	}
+
+	grpcResp, err := h.transactionClient.RemoveCartItem(c.Request().Context(), &pb.RemoveCartItemRequest{
+		UserId: userID,
+		BookId: c.Param("book_id"),
+	})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to remove book from cart", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CartResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Book removed from cart",
+		Data: *dto.ToCartResponse(grpcResp),
+	})
+}
+
+// CheckoutCart godoc
+// @Summary      Checkout keranjang
+// @Description  Membuat transaksi dari seluruh isi keranjang lalu mengosongkan keranjang.
+// @Tags         Gateway - Cart
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body  dto.CheckoutCartRequest  false  "Kode kupon (opsional)"
+// @Success      201   {object}  dto.TransactionResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      401   {object}  dto.ErrorResponse
+// @Failure      409   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /cart/checkout [post]
+func (h *CartHandler) CheckoutCart(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Invalid user ID in token",
+		})
+	}
+
+	// Body bersifat opsional, hanya berisi kode kupon
+	var req dto.CheckoutCartRequest
+	if c.Request().ContentLength > 0 {
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message: "Invalid request body",
+				Error: err.Error(),
+			})
+		}
+	}
+
+	grpcResp, err := h.transactionClient.CheckoutCart(c.Request().Context(), &pb.CheckoutCartRequest{
+		UserId:     userID,
+		CouponCode: req.CouponCode,
+	})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to checkout cart", err)
+	}
+
+	return c.JSON(http.StatusCreated, dto.TransactionResponseApi{
+		StatusCode: http.StatusCreated,
+		Message: "Success create transaction",
+		Data: *dto.ToTransactionResponse(grpcResp),
+	})
+}
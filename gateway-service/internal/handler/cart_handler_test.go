@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gateway-service/internal/dto"
+	mock_proto "gateway-service/proto"
+	"shared/money"
+	pb "transaction-service/proto"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Skenario 1: Tes GetCart mengembalikan isi keranjang
+func TestGetCart_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/cart", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("GetCart", mock.Anything, &pb.GetCartRequest{UserId: "1"}).Return(&pb.CartResponse{
+		UserId: "1",
+		Items: []*pb.CartItem{{
+			BookId:       "book-123",
+			Quantity:     2,
+			PricePerUnit: money.FromRupiah(60000).ToProto(),
+			PriceAtAdd:   money.FromRupiah(50000).ToProto(),
+			Subtotal:     money.FromRupiah(120000).ToProto(),
+			Available:    true,
+			PriceChanged: true,
+		}},
+		TotalAmount: money.FromRupiah(120000).ToProto(),
+	}, nil)
+	h := NewCartHandler(mockClient)
+
+	err := h.GetCart(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.CartResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Len(t, resp.Data.Items, 1)
+	assert.True(t, resp.Data.Items[0].PriceChanged)
+	assert.Equal(t, money.FromRupiah(120000), resp.Data.TotalAmount)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 2: Tes AddCartItem meneruskan buku ke transaction-service
+func TestAddCartItem_Success(t *testing.T) {
+	jsonBody, _ := json.Marshal(dto.AddCartItemRequest{BookID: "book-123", Quantity: 1})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/cart/items", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("AddCartItem", mock.Anything, &pb.CartItemRequest{UserId: "1", BookId: "book-123", Quantity: 1}).
+		Return(&pb.CartResponse{UserId: "1"}, nil)
+	h := NewCartHandler(mockClient)
+
+	err := h.AddCartItem(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 3: Tes UpdateCartItem untuk buku yang tidak ada di keranjang
+func TestUpdateCartItem_NotFound(t *testing.T) {
+	jsonBody, _ := json.Marshal(dto.UpdateCartItemRequest{Quantity: 3})
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/cart/items/book-999", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+	c.SetParamNames("book_id")
+	c.SetParamValues("book-999")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("UpdateCartItem", mock.Anything, &pb.CartItemRequest{UserId: "1", BookId: "book-999", Quantity: 3}).
+		Return(nil, status.Error(codes.NotFound, "book is not in the cart"))
+	h := NewCartHandler(mockClient)
+
+	err := h.UpdateCartItem(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// Skenario 4: Tes CheckoutCart membuat transaksi dan menolak keranjang kosong
+func TestCheckoutCart(t *testing.T) {
+	newContext := func() (echo.Context, *httptest.ResponseRecorder) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/cart/checkout", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user_id", "1")
+		return c, rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		c, rec := newContext()
+		mockClient := new(mock_proto.MockTransactionServiceClient)
+		mockClient.On("CheckoutCart", mock.Anything, &pb.CheckoutCartRequest{UserId: "1"}).Return(&pb.TransactionResponse{
+			TransactionId:   "99",
+			UserId:          "1",
+			Status:          "pending",
+			TransactionDate: timestamppb.Now(),
+			TotalAmount:     money.FromRupiah(100000).ToProto(),
+		}, nil)
+
+		err := NewCartHandler(mockClient).CheckoutCart(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"transaction_id":"99"`)
+	})
+
+	t.Run("empty cart", func(t *testing.T) {
+		c, rec := newContext()
+		mockClient := new(mock_proto.MockTransactionServiceClient)
+		mockClient.On("CheckoutCart", mock.Anything, mock.Anything).Return(nil, status.Error(codes.FailedPrecondition, "cart is empty"))
+
+		err := NewCartHandler(mockClient).CheckoutCart(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Contains(t, rec.Body.String(), "cart is empty")
+	})
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"gateway-service/internal/dto"
+	pb "transaction-service/proto"
+)
+
+// CouponHandler menerjemahkan request manajemen kupon (admin) ke gRPC transaction-service.
+type CouponHandler struct {
+	transactionClient pb.TransactionServiceClient
+}
+
+func NewCouponHandler(client pb.TransactionServiceClient) *CouponHandler {
+	return &CouponHandler{transactionClient: client}
+}
+
+// CreateCoupon godoc
+// @Summary      Buat kupon diskon (admin)
+// @Description  Kupon bisa berupa persentase atau potongan tetap, dengan batasan buku/kategori, minimum order, kuota, dan masa berlaku.
+// @Tags         Gateway - Coupon
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body  dto.CouponRequest  true  "Data kupon"
+// @Success      201   {object}  dto.CouponResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      403   {object}  dto.ErrorResponse
+// @Failure      409   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /admin/coupons [post]
+func (h *CouponHandler) CreateCoupon(c echo.Context) error {
+	var req dto.CouponRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error: err.Error(),
+		})
+	}
+
+	grpcResp, err := h.transactionClient.CreateCoupon(c.Request().Context(), &pb.CreateCouponRequest{
+		Coupon: req.ToCouponProto(""),
+	})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to create coupon", err)
+	}
+
+	return c.JSON(http.StatusCreated, dto.CouponResponseApi{
+		StatusCode: http.StatusCreated,
+		Message: "Create coupon successfully",
+		Data: *dto.ToCouponResponse(grpcResp),
+	})
+}
+
+// ListCoupons godoc
+// @Summary      Ambil semua kupon (admin)
+// @Tags         Gateway - Coupon
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200   {object}  dto.CouponListResponse
+// @Failure      403   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /admin/coupons [get]
+func (h *CouponHandler) ListCoupons(c echo.Context) error {
+	grpcResp, err := h.transactionClient.ListCoupons(c.Request().Context(), &pb.ListCouponsRequest{})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get coupons", err)
+	}
+
+	coupons := make([]dto.CouponResponse, len(grpcResp.Coupons))
+	for i, coupon := range grpcResp.Coupons {
+		coupons[i] = *dto.ToCouponResponse(coupon)
+	}
+	return c.JSON(http.StatusOK, dto.CouponListResponse{
+		StatusCode: http.StatusOK,
+		Message: "Get coupons successfully",
+		Data: coupons,
+	})
+}
+
+// GetCoupon godoc
+// @Summary      Ambil satu kupon (admin)
+// @Tags         Gateway - Coupon
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Coupon ID"
+// @Success      200   {object}  dto.CouponResponseApi
+// @Failure      403   {object}  dto.ErrorResponse
+// @Failure      404   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /admin/coupons/{id} [get]
+func (h *CouponHandler) GetCoupon(c echo.Context) error {
+	grpcResp, err := h.transactionClient.GetCoupon(c.Request().Context(), &pb.GetCouponRequest{CouponId: c.Param("id")})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get coupon", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CouponResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get coupon successfully",
+		Data: *dto.ToCouponResponse(grpcResp),
+	})
+}
+
+// UpdateCoupon godoc
+// @Summary      Ubah kupon (admin)
+// @Description  Mengganti seluruh definisi kupon. Jumlah pemakaian tidak berubah.
+// @Tags         Gateway - Coupon
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path  string  true  "Coupon ID"
+// @Param        body  body  dto.CouponRequest  true  "Data kupon"
+// @Success      200   {object}  dto.CouponResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      403   {object}  dto.ErrorResponse
+// @Failure      404   {object}  dto.ErrorResponse
+// @Failure      409   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /admin/coupons/{id} [put]
+func (h *CouponHandler) UpdateCoupon(c echo.Context) error {
+	var req dto.CouponRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error: err.Error(),
+		})
+	}
+
+	grpcResp, err := h.transactionClient.UpdateCoupon(c.Request().Context(), &pb.UpdateCouponRequest{
+		Coupon: req.ToCouponProto(c.Param("id")),
+	})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to update coupon", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CouponResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Update coupon successfully",
+		Data: *dto.ToCouponResponse(grpcResp),
+	})
+}
+
+// DeleteCoupon godoc
+// @Summary      Hapus kupon (admin)
+// @Tags         Gateway - Coupon
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Coupon ID"
+// @Success      200   {object}  dto.DeleteResponse
+// @Failure      403   {object}  dto.ErrorResponse
+// @Failure      404   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /admin/coupons/{id} [delete]
+func (h *CouponHandler) DeleteCoupon(c echo.Context) error {
+	_, err := h.transactionClient.DeleteCoupon(c.Request().Context(), &pb.DeleteCouponRequest{CouponId: c.Param("id")})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to delete coupon", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.DeleteResponse{
+		Code:    http.StatusOK,
+		Message: "Coupon deleted successfully",
+	})
+}
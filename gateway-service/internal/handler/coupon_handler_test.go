@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gateway-service/internal/dto"
+	mock_proto "gateway-service/proto"
+	"shared/money"
+	pb "transaction-service/proto"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Skenario 1: Tes CreateCoupon meneruskan definisi kupon ke transaction-service
+func TestCreateCoupon_Success(t *testing.T) {
+	jsonBody := []byte(`{"code":"HEMAT10","type":"percentage","percent_off":10,"min_order_amount":100000,"categories":["Fiction"],"per_user_limit":1}`)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/coupons", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("CreateCoupon", mock.Anything, mock.MatchedBy(func(in *pb.CreateCouponRequest) bool {
+		coupon := in.Coupon
+		return coupon.Code == "HEMAT10" && coupon.PercentOff == 10 && coupon.Active &&
+			coupon.MinOrderAmount.MinorUnits == money.FromRupiah(100000).Minor() &&
+			coupon.PerUserLimit == 1 && coupon.Categories[0] == "Fiction"
+	})).Return(&pb.Coupon{Id: "7", Code: "HEMAT10", Type: "percentage", PercentOff: 10, Active: true}, nil)
+	h := NewCouponHandler(mockClient)
+
+	err := h.CreateCoupon(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var resp dto.CouponResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Equal(t, "7", resp.Data.ID)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 2: Tes error dari transaction-service diterjemahkan ke HTTP status
+func TestCouponHandler_Errors(t *testing.T) {
+	t.Run("invalid coupon", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/coupons", bytes.NewReader([]byte(`{"code":"X","type":"bogo"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockClient := new(mock_proto.MockTransactionServiceClient)
+		mockClient.On("CreateCoupon", mock.Anything, mock.Anything).Return(nil, status.Error(codes.InvalidArgument, "invalid coupon: type must be percentage or fixed"))
+
+		err := NewCouponHandler(mockClient).CreateCoupon(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("coupon not found", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodDelete, "/api/admin/coupons/404", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("404")
+
+		mockClient := new(mock_proto.MockTransactionServiceClient)
+		mockClient.On("DeleteCoupon", mock.Anything, &pb.DeleteCouponRequest{CouponId: "404"}).Return(nil, status.Error(codes.NotFound, "coupon not found"))
+
+		err := NewCouponHandler(mockClient).DeleteCoupon(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -76,3 +76,45 @@ func (h *GiftingHandler) SendGift(c echo.Context) error {
 		Data: grpcResp,
 	})
 }
+
+// AcceptGift godoc
+// @Summary Terima hadiah buku
+// @Description Menerima hadiah yang dikirim ke email user yang login. Buku hadiah dicatat ke library user.
+// @Tags Gateway - Gifting
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "ID hadiah"
+// @Success 200 {object} dto.AcceptGiftResponseApi
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /gifts/{id}/accept [post]
+func (h *GiftingHandler) AcceptGift(c echo.Context) error {
+	// Hadiah hanya bisa diterima oleh user dengan email penerima
+	userID, ok := c.Get("user_id").(string)
+	email, emailOk := c.Get("email").(string)
+	if !ok || userID == "" || !emailOk || email == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	grpcResp, err := h.giftingClient.AcceptGift(c.Request().Context(), &gifting_pb.AcceptGiftRequest{
+		GiftId:    c.Param("id"),
+		UserId:    userID,
+		UserEmail: email,
+	})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to accept gift", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.AcceptGiftResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Accept gift successfully",
+		Data: *dto.ToAcceptGiftResponse(grpcResp),
+	})
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Skenario 1: Tes jika pengiriman hadiah berhasil
@@ -56,10 +59,10 @@ func TestSendGift_Success(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, rec.Code)
 
 	// Verifikasi isi response
-	var resp pb.SendGiftResponse
+	var resp dto.TemplateSendGiftResponse
 	json.Unmarshal(rec.Body.Bytes(), &resp)
-	assert.Equal(t, "gift-789", resp.GiftId)
-	assert.Equal(t, "penerima@example.com", resp.RecipientEmail)
+	assert.Equal(t, "gift-789", resp.Data.GiftId)
+	assert.Equal(t, "penerima@example.com", resp.Data.RecipientEmail)
 
 	mockClient.AssertExpectations(t)
 }
@@ -99,3 +102,76 @@ func TestSendGift_ServiceError(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "gifting service is down")
 	mockClient.AssertExpectations(t)
 }
+
+// Skenario 3: Tes penerima menerima hadiah dengan user ID dan email dari token
+func TestAcceptGift_Success(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/gifts/7/accept", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	c.Set("user_id", "5")
+	c.Set("email", "penerima@example.com")
+
+	mockClient := new(mock_proto.MockGiftingServiceClient)
+	mockClient.On("AcceptGift", mock.Anything, &pb.AcceptGiftRequest{GiftId: "7", UserId: "5", UserEmail: "penerima@example.com"}).
+		Return(&pb.AcceptGiftResponse{GiftId: "7", BookId: "book-123", RecipientUserId: "5", Status: "accepted", AcceptedAt: timestamppb.Now()}, nil)
+	h := NewGiftingHandler(mockClient)
+
+	// --- Act ---
+	err := h.AcceptGift(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.AcceptGiftResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Equal(t, "accepted", resp.Data.Status)
+	assert.Equal(t, "book-123", resp.Data.BookID)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 4: Tes hadiah untuk email lain ditolak dengan 403
+func TestAcceptGift_NotRecipient(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/gifts/7/accept", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	c.Set("user_id", "6")
+	c.Set("email", "lain@example.com")
+
+	mockClient := new(mock_proto.MockGiftingServiceClient)
+	mockClient.On("AcceptGift", mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.PermissionDenied, "gift is addressed to another email"))
+	h := NewGiftingHandler(mockClient)
+
+	err := h.AcceptGift(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "gift is addressed to another email")
+}
+
+// Skenario 5: Tes token tanpa email tidak bisa menerima hadiah
+func TestAcceptGift_MissingEmail(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/gifts/7/accept", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	c.Set("user_id", "5")
+
+	mockClient := new(mock_proto.MockGiftingServiceClient)
+	h := NewGiftingHandler(mockClient)
+
+	err := h.AcceptGift(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockClient.AssertNotCalled(t, "AcceptGift", mock.Anything, mock.Anything)
+}
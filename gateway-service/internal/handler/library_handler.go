@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"gateway-service/internal/dto"
+	pb "transaction-service/proto"
+)
+
+// LibraryHandler menerjemahkan request library ebook ke gRPC transaction-service.
+type LibraryHandler struct {
+	transactionClient pb.TransactionServiceClient
+}
+
+func NewLibraryHandler(client pb.TransactionServiceClient) *LibraryHandler {
+	return &LibraryHandler{transactionClient: client}
+}
+
+// GetLibrary godoc
+// @Summary      Ambil library ebook user
+// @Description  Mengambil buku yang dimiliki user, dari pembelian yang selesai maupun hadiah yang diterima, beserta metadata dari book-service.
+// @Description  Buku yang terbaru dimiliki tampil lebih dulu, dengan pagination cursor.
+// @Tags         Gateway - Library
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page_size   query  int     false  "Jumlah buku per halaman (default 20, maksimal 100)"
+// @Param        page_token  query  string  false  "Cursor dari next_page_token pada respons sebelumnya"
+// @Success      200   {object}  dto.LibraryResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      401   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /library [get]
+func (h *LibraryHandler) GetLibrary(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Invalid user ID in token",
+		})
+	}
+
+	grpcReq := &pb.ListLibraryRequest{
+		UserId:    userID,
+		PageToken: c.QueryParam("page_token"),
+	}
+	if value := c.QueryParam("page_size"); value != "" {
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 1 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message: "Invalid page_size",
+			})
+		}
+		grpcReq.PageSize = int32(n)
+	}
+
+	grpcResp, err := h.transactionClient.ListLibrary(c.Request().Context(), grpcReq)
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get library", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.LibraryResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get library successfully",
+		Data: *dto.ToLibraryResponse(grpcResp),
+	})
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gateway-service/internal/dto"
+	mock_proto "gateway-service/proto"
+	pb "transaction-service/proto"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Skenario 1: Tes GetLibrary mengembalikan buku milik user beserta metadatanya
+func TestGetLibrary_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/library", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+
+	grantedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("ListLibrary", mock.Anything, &pb.ListLibraryRequest{UserId: "1"}).Return(&pb.ListLibraryResponse{
+		UserId: "1",
+		Books: []*pb.LibraryBook{{
+			Entitlement: &pb.Entitlement{UserId: "1", BookId: "book-123", Source: "gift", ReferenceId: "gift-7", GrantedAt: timestamppb.New(grantedAt)},
+			Title:       "Laskar Pelangi",
+			Author:      "Andrea Hirata",
+		}},
+	}, nil)
+	h := NewLibraryHandler(mockClient)
+
+	err := h.GetLibrary(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.LibraryResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Len(t, resp.Data.Books, 1)
+	assert.Equal(t, "book-123", resp.Data.Books[0].BookID)
+	assert.Equal(t, "Laskar Pelangi", resp.Data.Books[0].Title)
+	assert.Equal(t, "gift", resp.Data.Books[0].Source)
+	assert.True(t, grantedAt.Equal(resp.Data.Books[0].AcquiredAt))
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 2: Tes error dari transaction-service diteruskan dengan status HTTP yang sesuai
+func TestGetLibrary_ServiceError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/library", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("ListLibrary", mock.Anything, mock.Anything).Return(nil, status.Error(codes.Unavailable, "transaction-service unavailable"))
+	h := NewLibraryHandler(mockClient)
+
+	err := h.GetLibrary(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "transaction-service unavailable")
+}
+
+// Skenario 3: Tes page_size dan page_token diteruskan dan next_page_token dikembalikan
+func TestGetLibrary_Pagination(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/library?page_size=10&page_token=abc", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("ListLibrary", mock.Anything, &pb.ListLibraryRequest{UserId: "1", PageSize: 10, PageToken: "abc"}).
+		Return(&pb.ListLibraryResponse{UserId: "1", NextPageToken: "def"}, nil)
+	h := NewLibraryHandler(mockClient)
+
+	err := h.GetLibrary(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.LibraryResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Equal(t, "def", resp.Data.NextPageToken)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 4: Tes page_size yang tidak valid ditolak tanpa memanggil transaction-service
+func TestGetLibrary_InvalidPageSize(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/library?page_size=0", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	h := NewLibraryHandler(mockClient)
+
+	err := h.GetLibrary(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockClient.AssertNotCalled(t, "ListLibrary", mock.Anything, mock.Anything)
+}
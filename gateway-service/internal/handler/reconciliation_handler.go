@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"gateway-service/internal/dto"
+	pb "transaction-service/proto"
+)
+
+// ReconciliationHandler menerjemahkan request rekonsiliasi (admin) ke gRPC transaction-service.
+type ReconciliationHandler struct {
+	transactionClient pb.TransactionServiceClient
+}
+
+func NewReconciliationHandler(client pb.TransactionServiceClient) *ReconciliationHandler {
+	return &ReconciliationHandler{transactionClient: client}
+}
+
+// RunReconciliation godoc
+// @Summary      Jalankan rekonsiliasi transaksi dengan wallet (admin)
+// @Description  Mencocokkan transaksi yang selesai dalam rentang waktu dengan debit dan refund di wallet. Tanpa body, rentangnya 24 jam terakhir sebelum jeda settle 1 jam.
+// @Tags         Gateway - Reconciliation
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body  dto.RunReconciliationRequest  false  "Rentang waktu (opsional)"
+// @Success      201   {object}  dto.ReconciliationRunResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      403   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /admin/reconciliation/runs [post]
+func (h *ReconciliationHandler) RunReconciliation(c echo.Context) error {
+	var req dto.RunReconciliationRequest
+	if c.Request().ContentLength > 0 {
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message: "Invalid request body",
+				Error: err.Error(),
+			})
+		}
+	}
+
+	grpcReq := &pb.RunReconciliationRequest{}
+	if req.StartTime != nil {
+		grpcReq.StartTime = timestamppb.New(*req.StartTime)
+	}
+	if req.EndTime != nil {
+		grpcReq.EndTime = timestamppb.New(*req.EndTime)
+	}
+
+	grpcResp, err := h.transactionClient.RunReconciliation(c.Request().Context(), grpcReq)
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to run reconciliation", err)
+	}
+
+	return c.JSON(http.StatusCreated, dto.ReconciliationRunResponseApi{
+		StatusCode: http.StatusCreated,
+		Message: "Reconciliation run finished",
+		Data: *dto.ToReconciliationRunResponse(grpcResp),
+	})
+}
+
+// ListReconciliationRuns godoc
+// @Summary      Daftar run rekonsiliasi (admin)
+// @Tags         Gateway - Reconciliation
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page       query  int  false  "Halaman, dimulai dari 1"
+// @Param        page_size  query  int  false  "Jumlah run per halaman (default 20, maksimal 100)"
+// @Success      200   {object}  dto.ReconciliationRunListResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      403   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /admin/reconciliation/runs [get]
+func (h *ReconciliationHandler) ListReconciliationRuns(c echo.Context) error {
+	grpcReq := &pb.ListReconciliationRunsRequest{}
+	for name, target := range map[string]*int32{
+		"page":      &grpcReq.Page,
+		"page_size": &grpcReq.PageSize,
+	} {
+		value := c.QueryParam(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 1 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message: "Invalid " + name,
+			})
+		}
+		*target = int32(n)
+	}
+
+	grpcResp, err := h.transactionClient.ListReconciliationRuns(c.Request().Context(), grpcReq)
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get reconciliation runs", err)
+	}
+
+	runs := make([]dto.ReconciliationRunResponse, len(grpcResp.Runs))
+	for i, run := range grpcResp.Runs {
+		runs[i] = *dto.ToReconciliationRunResponse(run)
+	}
+	return c.JSON(http.StatusOK, dto.ReconciliationRunListResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get reconciliation runs successfully",
+		Data: dto.ReconciliationRunListResponse{
+			Runs:     runs,
+			Total:    grpcResp.Total,
+			Page:     int(grpcResp.Page),
+			PageSize: int(grpcResp.PageSize),
+		},
+	})
+}
+
+// GetReconciliationRun godoc
+// @Summary      Detail run rekonsiliasi beserta ketidakcocokannya (admin)
+// @Tags         Gateway - Reconciliation
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Run ID"
+// @Success      200   {object}  dto.ReconciliationRunResponseApi
+// @Failure      403   {object}  dto.ErrorResponse
+// @Failure      404   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /admin/reconciliation/runs/{id} [get]
+func (h *ReconciliationHandler) GetReconciliationRun(c echo.Context) error {
+	grpcResp, err := h.transactionClient.GetReconciliationRun(c.Request().Context(), &pb.GetReconciliationRunRequest{RunId: c.Param("id")})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get reconciliation run", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.ReconciliationRunResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get reconciliation run successfully",
+		Data: *dto.ToReconciliationRunResponse(grpcResp),
+	})
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gateway-service/internal/dto"
+	mock_proto "gateway-service/proto"
+	"shared/money"
+	pb "transaction-service/proto"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Skenario 1: Tes menjalankan rekonsiliasi manual dengan rentang waktu
+func TestRunReconciliation_Success(t *testing.T) {
+	jsonBody := []byte(`{"start_time":"2025-06-01T00:00:00Z","end_time":"2025-06-02T00:00:00Z"}`)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/reconciliation/runs", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("RunReconciliation", mock.Anything, mock.MatchedBy(func(in *pb.RunReconciliationRequest) bool {
+		return in.StartTime.AsTime().Equal(start) && in.EndTime.AsTime().Equal(end)
+	})).Return(&pb.ReconciliationRun{
+		Id: "5", Trigger: "manual", Status: "completed",
+		WindowStart: timestamppb.New(start), WindowEnd: timestamppb.New(end), StartedAt: timestamppb.New(end),
+		CheckedCount: 2, MismatchCount: 1,
+	}, nil)
+	h := NewReconciliationHandler(mockClient)
+
+	err := h.RunReconciliation(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var resp dto.ReconciliationRunResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Equal(t, "5", resp.Data.ID)
+	assert.Equal(t, 1, resp.Data.MismatchCount)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 2: Tes detail run beserta ketidakcocokannya
+func TestGetReconciliationRun(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/reconciliation/runs/5", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("5")
+
+		mockClient := new(mock_proto.MockTransactionServiceClient)
+		mockClient.On("GetReconciliationRun", mock.Anything, &pb.GetReconciliationRunRequest{RunId: "5"}).Return(&pb.ReconciliationRun{
+			Id: "5", Status: "completed", StartedAt: timestamppb.Now(),
+			Mismatches: []*pb.ReconciliationMismatch{{
+				TransactionId: "99", Type: "missing_debit",
+				ExpectedAmount: money.FromRupiah(50000).ToProto(), ActualAmount: money.FromRupiah(0).ToProto(),
+			}},
+		}, nil)
+		h := NewReconciliationHandler(mockClient)
+
+		err := h.GetReconciliationRun(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp dto.ReconciliationRunResponseApi
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.Len(t, resp.Data.Mismatches, 1)
+		assert.Equal(t, money.FromRupiah(50000), resp.Data.Mismatches[0].ExpectedAmount)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/reconciliation/runs/42", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("42")
+
+		mockClient := new(mock_proto.MockTransactionServiceClient)
+		mockClient.On("GetReconciliationRun", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.NotFound, "reconciliation run not found"))
+		h := NewReconciliationHandler(mockClient)
+
+		err := h.GetReconciliationRun(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"gateway-service/internal/dto"
+	pb "transaction-service/proto"
+)
+
+// ReportHandler menerjemahkan request laporan penjualan (admin) ke gRPC transaction-service.
+// Semua laporan hanya menghitung transaksi yang sudah completed.
+type ReportHandler struct {
+	transactionClient pb.TransactionServiceClient
+}
+
+func NewReportHandler(client pb.TransactionServiceClient) *ReportHandler {
+	return &ReportHandler{transactionClient: client}
+}
+
+// GetRevenueReport godoc
+// @Summary      Laporan pendapatan (admin)
+// @Description  Pendapatan (total yang ditagih setelah diskon kupon), jumlah transaksi, dan jumlah pembeli per hari, minggu, atau bulan. Gunakan format=csv untuk mengunduh CSV.
+// @Tags         Gateway - Report
+// @Produce      json
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        period      query  string  false  "day, week, atau month (default day)"
+// @Param        start_time  query  string  false  "Awal rentang waktu (RFC3339, inklusif)"
+// @Param        end_time    query  string  false  "Akhir rentang waktu (RFC3339, eksklusif)"
+// @Param        format      query  string  false  "json (default) atau csv"
+// @Success      200   {object}  dto.RevenueReportResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      403   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /admin/reports/revenue [get]
+func (h *ReportHandler) GetRevenueReport(c echo.Context) error {
+	grpcReq := &pb.RevenueReportRequest{Period: c.QueryParam("period")}
+	if err := parseReportRange(c, &grpcReq.StartTime, &grpcReq.EndTime); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "Invalid time range",
+			Error: err.Error(),
+		})
+	}
+
+	grpcResp, err := h.transactionClient.GetRevenueReport(c.Request().Context(), grpcReq)
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get revenue report", err)
+	}
+	report := dto.ToRevenueReportResponse(grpcResp)
+
+	if c.QueryParam("format") == "csv" {
+		rows := [][]string{{"period_start", "revenue", "transaction_count", "buyer_count"}}
+		for _, b := range report.Buckets {
+			rows = append(rows, []string{
+				b.PeriodStart.Format(time.RFC3339),
+				b.Revenue.String(),
+				strconv.FormatInt(b.TransactionCount, 10),
+				strconv.FormatInt(b.BuyerCount, 10),
+			})
+		}
+		return writeCSV(c, "revenue-report.csv", rows)
+	}
+
+	return c.JSON(http.StatusOK, dto.RevenueReportResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get revenue report successfully",
+		Data: *report,
+	})
+}
+
+// GetTopBooksReport godoc
+// @Summary      Laporan buku terlaris (admin)
+// @Description  Buku dengan jumlah terjual terbanyak beserta penjualan kotor (gross_sales, sebelum diskon kupon) dan penjualan bersihnya (net_sales, setelah bagian diskon kupon transaksinya).
+// @Tags         Gateway - Report
+// @Produce      json
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        limit       query  int     false  "Jumlah buku (default 10, maksimal 100)"
+// @Param        start_time  query  string  false  "Awal rentang waktu (RFC3339, inklusif)"
+// @Param        end_time    query  string  false  "Akhir rentang waktu (RFC3339, eksklusif)"
+// @Param        format      query  string  false  "json (default) atau csv"
+// @Success      200   {object}  dto.TopBooksReportResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      403   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /admin/reports/top-books [get]
+func (h *ReportHandler) GetTopBooksReport(c echo.Context) error {
+	grpcReq := &pb.TopBooksReportRequest{}
+	if value := c.QueryParam("limit"); value != "" {
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 1 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message: "Invalid limit",
+			})
+		}
+		grpcReq.Limit = int32(n)
+	}
+	if err := parseReportRange(c, &grpcReq.StartTime, &grpcReq.EndTime); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "Invalid time range",
+			Error: err.Error(),
+		})
+	}
+
+	grpcResp, err := h.transactionClient.GetTopBooksReport(c.Request().Context(), grpcReq)
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get top books report", err)
+	}
+	books := dto.ToBookSalesResponses(grpcResp.Books)
+
+	if c.QueryParam("format") == "csv" {
+		rows := [][]string{{"book_id", "quantity", "gross_sales", "net_sales"}}
+		for _, b := range books {
+			rows = append(rows, []string{b.BookID, strconv.FormatInt(b.Quantity, 10), b.GrossSales.String(), b.NetSales.String()})
+		}
+		return writeCSV(c, "top-books-report.csv", rows)
+	}
+
+	return c.JSON(http.StatusOK, dto.TopBooksReportResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get top books report successfully",
+		Data: books,
+	})
+}
+
+// GetCategoryReport godoc
+// @Summary      Laporan penjualan per kategori (admin)
+// @Description  Jumlah buku terjual, penjualan kotor (gross_sales, sebelum diskon kupon), dan penjualan bersih (net_sales) per kategori, diurutkan dari net_sales terbesar.
+// @Description  Diskon kupon transaksi dibagi proporsional ke setiap buku, sehingga total net_sales sejalan dengan total_revenue di laporan pendapatan.
+// @Tags         Gateway - Report
+// @Produce      json
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        start_time  query  string  false  "Awal rentang waktu (RFC3339, inklusif)"
+// @Param        end_time    query  string  false  "Akhir rentang waktu (RFC3339, eksklusif)"
+// @Param        format      query  string  false  "json (default) atau csv"
+// @Success      200   {object}  dto.CategoryReportResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      403   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /admin/reports/categories [get]
+func (h *ReportHandler) GetCategoryReport(c echo.Context) error {
+	grpcReq := &pb.CategoryReportRequest{}
+	if err := parseReportRange(c, &grpcReq.StartTime, &grpcReq.EndTime); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "Invalid time range",
+			Error: err.Error(),
+		})
+	}
+
+	grpcResp, err := h.transactionClient.GetCategoryReport(c.Request().Context(), grpcReq)
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get category report", err)
+	}
+	categories := dto.ToCategorySalesResponses(grpcResp.Categories)
+
+	if c.QueryParam("format") == "csv" {
+		rows := [][]string{{"category", "quantity", "gross_sales", "net_sales"}}
+		for _, cat := range categories {
+			rows = append(rows, []string{cat.Category, strconv.FormatInt(cat.Quantity, 10), cat.GrossSales.String(), cat.NetSales.String()})
+		}
+		return writeCSV(c, "category-report.csv", rows)
+	}
+
+	return c.JSON(http.StatusOK, dto.CategoryReportResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get category report successfully",
+		Data: categories,
+	})
+}
+
+// parseReportRange membaca query start_time dan end_time (RFC3339) ke request gRPC.
+func parseReportRange(c echo.Context, start, end **timestamppb.Timestamp) error {
+	for name, target := range map[string]**timestamppb.Timestamp{
+		"start_time": start,
+		"end_time":   end,
+	} {
+		value := c.QueryParam(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fmt.Errorf("invalid %s, expected RFC3339: %w", name, err)
+		}
+		*target = timestamppb.New(t)
+	}
+	return nil
+}
+
+// writeCSV menulis laporan sebagai file CSV yang bisa diunduh.
+func writeCSV(c echo.Context, filename string, rows [][]string) error {
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	c.Response().WriteHeader(http.StatusOK)
+
+	return csv.NewWriter(c.Response()).WriteAll(rows)
+}
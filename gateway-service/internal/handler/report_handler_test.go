@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gateway-service/internal/dto"
+	mock_proto "gateway-service/proto"
+	"shared/money"
+	pb "transaction-service/proto"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Skenario 1: Tes laporan pendapatan dalam format JSON
+func TestGetRevenueReport_JSON(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/reports/revenue?period=month&start_time=2025-01-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	periodStart := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("GetRevenueReport", mock.Anything, mock.MatchedBy(func(in *pb.RevenueReportRequest) bool {
+		return in.Period == "month" && in.StartTime.AsTime().Equal(periodStart) && in.EndTime == nil
+	})).Return(&pb.RevenueReportResponse{
+		Period: "month",
+		Buckets: []*pb.RevenueBucket{
+			{PeriodStart: timestamppb.New(periodStart), Revenue: money.FromRupiah(150000).ToProto(), TransactionCount: 3, BuyerCount: 2},
+		},
+		TotalRevenue:      money.FromRupiah(150000).ToProto(),
+		TotalTransactions: 3,
+		TotalBuyers:       2,
+	}, nil)
+	h := NewReportHandler(mockClient)
+
+	err := h.GetRevenueReport(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.RevenueReportResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Len(t, resp.Data.Buckets, 1)
+	assert.Equal(t, money.FromRupiah(150000), resp.Data.TotalRevenue)
+	assert.Equal(t, int64(2), resp.Data.TotalBuyers)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 2: Tes laporan buku terlaris diunduh sebagai CSV
+func TestGetTopBooksReport_CSV(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/reports/top-books?limit=5&format=csv", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("GetTopBooksReport", mock.Anything, mock.MatchedBy(func(in *pb.TopBooksReportRequest) bool {
+		return in.Limit == 5
+	})).Return(&pb.TopBooksReportResponse{Books: []*pb.BookSales{
+		{BookId: "101", Quantity: 5, GrossSales: money.FromRupiah(250000).ToProto(), NetSales: money.FromRupiah(225000).ToProto()},
+	}}, nil)
+	h := NewReportHandler(mockClient)
+
+	err := h.GetTopBooksReport(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/csv")
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "top-books-report.csv")
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	assert.Equal(t, "book_id,quantity,gross_sales,net_sales", lines[0])
+	assert.Equal(t, "101,5,"+money.FromRupiah(250000).String()+","+money.FromRupiah(225000).String(), lines[1])
+}
+
+// Skenario 3: Tes laporan dengan parameter tidak valid
+func TestReportHandler_InvalidRequest(t *testing.T) {
+	t.Run("invalid start_time", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/reports/categories?start_time=kemarin", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockClient := new(mock_proto.MockTransactionServiceClient)
+		h := NewReportHandler(mockClient)
+
+		err := h.GetCategoryReport(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockClient.AssertNotCalled(t, "GetCategoryReport", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/reports/revenue?period=year", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockClient := new(mock_proto.MockTransactionServiceClient)
+		mockClient.On("GetRevenueReport", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.InvalidArgument, "period must be day, week, or month"))
+		h := NewReportHandler(mockClient)
+
+		err := h.GetRevenueReport(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -1,9 +1,19 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	// Import DTO dan gRPC client
 	"gateway-service/internal/dto"
 	pb "transaction-service/proto"
@@ -21,6 +31,10 @@ func NewTransactionHandler(client pb.TransactionServiceClient) *TransactionHandl
 // CreateTransaction godoc
 // @Summary      Buat transaksi pembelian buku
 // @Description  Menerima list item buku dari user dan membuat transaksi baru (via gRPC ke transaction-service).
+// @Description  Jika quote_id dikirim, harga yang ditagih adalah harga di quote; items boleh dikosongkan.
+// @Description  Jika coupon_code dikirim, potongan harga ditampilkan di field discount.
+// @Description  Total langsung ditahan dari saldo wallet; jika saldo tersedia atau batas pengeluaran tidak cukup, transaksi ditolak dengan 409.
+// @Description  Untuk batas pengeluaran, field code berisi batas yang terlampaui (per_transaction_limit_exceeded, daily_limit_exceeded, atau monthly_limit_exceeded).
 // @Tags         Gateway - Transaction
 // @Accept       json
 // @Produce      json
@@ -29,6 +43,7 @@ func NewTransactionHandler(client pb.TransactionServiceClient) *TransactionHandl
 // @Success      201   {object}  dto.TransactionResponseApi
 // @Failure      400   {object}  dto.ErrorResponse
 // @Failure      401   {object}  dto.ErrorResponse
+// @Failure      409   {object}  dto.ErrorResponse
 // @Failure      500   {object}  dto.ErrorResponse
 // @Router       /transactions [post]
 func (h *TransactionHandler) CreateTransaction(c echo.Context) error {
@@ -60,13 +75,25 @@ func (h *TransactionHandler) CreateTransaction(c echo.Context) error {
 		}
 	}
 	grpcReq := &pb.CreateTransactionRequest{
-		UserId: userID,
-		Items:  grpcItems,
+		UserId:     userID,
+		Items:      grpcItems,
+		QuoteId:    req.QuoteID,
+		CouponCode: req.CouponCode,
 	}
 
 	// 3. Panggil service gRPC
 	grpcResp, err := h.transactionClient.CreateTransaction(c.Request().Context(), grpcReq)
 	if err != nil {
+		// Stok habis atau quote kedaluwarsa dilaporkan sebagai FailedPrecondition,
+		// quote tidak valid sebagai InvalidArgument oleh transaction-service
+		if code := httpStatusFromGrpc(err); code != http.StatusInternalServerError {
+			return c.JSON(code, dto.ErrorResponse{
+				StatusCode: code,
+				Message: "Failed to create transaction",
+				Error: status.Convert(err).Message(),
+				Code: grpcErrorCode(err),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			StatusCode: http.StatusInternalServerError,
 			Message: "Internal server error",
@@ -85,13 +112,84 @@ func (h *TransactionHandler) CreateTransaction(c echo.Context) error {
 	})
 }
 
+// QuoteOrder godoc
+// @Summary      Minta quote harga sebelum checkout
+// @Description  Menghitung harga keranjang saat ini dan mengembalikan quote_id bertanda tangan yang berlaku sampai expires_at.
+// @Tags         Gateway - Transaction
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body  dto.QuoteRequest  true  "Item yang ingin dibeli"
+// @Success      200   {object}  dto.QuoteResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      401   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /transactions/quote [post]
+func (h *TransactionHandler) QuoteOrder(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Invalid user ID in token",
+		})
+	}
+
+	var req dto.QuoteRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error: err.Error(),
+		})
+	}
+	if len(req.Items) == 0 {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "Items must not be empty",
+		})
+	}
+
+	grpcItems := make([]*pb.BookOrderItem, len(req.Items))
+	for i, item := range req.Items {
+		grpcItems[i] = &pb.BookOrderItem{
+			BookId:   item.BookID,
+			Quantity: int32(item.Quantity),
+		}
+	}
+
+	grpcResp, err := h.transactionClient.QuoteOrder(c.Request().Context(), &pb.QuoteOrderRequest{
+		UserId: userID,
+		Items:  grpcItems,
+	})
+	if err != nil {
+		code := httpStatusFromGrpc(err)
+		return c.JSON(code, dto.ErrorResponse{
+			StatusCode: code,
+			Message: "Failed to quote order",
+			Error: status.Convert(err).Message(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.QuoteResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Success quote order",
+		Data: *dto.ToQuoteResponse(grpcResp),
+	})
+}
+
 // GetTransactions godoc
 // @Summary      Ambil semua transaksi milik user
-// @Description  Mengambil daftar semua transaksi berdasarkan user_id dari token JWT.
+// @Description  Mengambil daftar transaksi berdasarkan user_id dari token JWT, terbaru lebih dulu, dengan pagination cursor.
 // @Tags         Gateway - Transaction
 // @Produce      json
 // @Security     BearerAuth
+// @Param        page_size   query  int     false  "Jumlah transaksi per halaman (default 20, maksimal 100)"
+// @Param        page_token  query  string  false  "Cursor dari next_page_token pada respons sebelumnya"
+// @Param        status      query  string  false  "Filter status: pending, completed, cancelled, refunded"
+// @Param        start_time  query  string  false  "Awal rentang waktu (RFC3339, inklusif)"
+// @Param        end_time    query  string  false  "Akhir rentang waktu (RFC3339, eksklusif)"
 // @Success      200   {object}  dto.TransactionListResponse
+// @Failure      400   {object}  dto.ErrorResponse
 // @Failure      401   {object}  dto.ErrorResponse
 // @Failure      500   {object}  dto.ErrorResponse
 // @Router       /transactions [get]
@@ -105,12 +203,53 @@ func (h *TransactionHandler) GetTransactions(c echo.Context) error {
 		})
 	}
 
-	// Buat request gRPC
-	grpcReq := &pb.GetUserTransactionsRequest{UserId: userID}
+	// Buat request gRPC beserta filter dari query string
+	grpcReq := &pb.GetUserTransactionsRequest{
+		UserId:    userID,
+		PageToken: c.QueryParam("page_token"),
+		Status:    c.QueryParam("status"),
+	}
+
+	if value := c.QueryParam("page_size"); value != "" {
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 1 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message: "Invalid page_size",
+			})
+		}
+		grpcReq.PageSize = int32(n)
+	}
+
+	for name, target := range map[string]**timestamppb.Timestamp{
+		"start_time": &grpcReq.StartTime,
+		"end_time":   &grpcReq.EndTime,
+	} {
+		value := c.QueryParam(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message: "Invalid " + name + ", expected RFC3339",
+				Error: err.Error(),
+			})
+		}
+		*target = timestamppb.New(t)
+	}
 
 	// Panggil transaction-service
 	grpcResp, err := h.transactionClient.GetUserTransactions(c.Request().Context(), grpcReq)
 	if err != nil {
+		if code := httpStatusFromGrpc(err); code != http.StatusInternalServerError {
+			return c.JSON(code, dto.ErrorResponse{
+				StatusCode: code,
+				Message: "Invalid transaction query",
+				Error: status.Convert(err).Message(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			StatusCode: http.StatusInternalServerError,
 			Message: "Internal server error",
@@ -126,5 +265,254 @@ func (h *TransactionHandler) GetTransactions(c echo.Context) error {
 		StatusCode: http.StatusOK,
 		Message: "Get transaction list successfully",
 		Data: respDto,
+		NextPageToken: grpcResp.NextPageToken,
+		TotalCount: grpcResp.TotalCount,
 	})
 }
+
+// GetTransaction godoc
+// @Summary      Ambil detail satu transaksi
+// @Description  Mengambil satu transaksi beserta detailnya, misalnya untuk memantau status pembayaran. Hanya pemilik transaksi yang bisa mengaksesnya.
+// @Tags         Gateway - Transaction
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Transaction ID"
+// @Success      200  {object}  dto.TransactionResponseApi
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /transactions/{id} [get]
+func (h *TransactionHandler) GetTransaction(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Invalid user ID in token",
+		})
+	}
+
+	grpcReq := &pb.GetTransactionRequest{
+		TransactionId: c.Param("id"),
+		UserId:        userID,
+	}
+
+	grpcResp, err := h.transactionClient.GetTransaction(c.Request().Context(), grpcReq)
+	if err != nil {
+		code := httpStatusFromGrpc(err)
+		return c.JSON(code, dto.ErrorResponse{
+			StatusCode: code,
+			Message: "Failed to get transaction",
+			Error: status.Convert(err).Message(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.TransactionResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get transaction successfully",
+		Data: *dto.ToTransactionResponse(grpcResp),
+	})
+}
+
+// watchTransactionTimeout membatasi lama satu koneksi SSE. EventSource di browser otomatis
+// menyambung ulang jika koneksi ditutup sebelum event done diterima.
+const watchTransactionTimeout = 5 * time.Minute
+
+// WatchTransaction godoc
+// @Summary      Pantau status transaksi secara live (Server-Sent Events)
+// @Description  Membuka stream text/event-stream. Event `status` berisi dto.TransactionStatusEvent untuk status saat ini dan setiap perubahannya.
+// @Description  Setelah transaksi tidak lagi pending, event `done` dikirim dengan status akhir lalu koneksi ditutup; client sebaiknya menutup EventSource saat menerimanya.
+// @Description  Jika stream terputus di tengah jalan, event `error` berisi dto.ErrorResponse dikirim dan client boleh menyambung ulang.
+// @Description  Hanya pemilik transaksi yang bisa memantaunya.
+// @Tags         Gateway - Transaction
+// @Produce      text/event-stream
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Transaction ID"
+// @Success      200  {object}  dto.TransactionStatusEvent
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /transactions/{id}/events [get]
+func (h *TransactionHandler) WatchTransaction(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Invalid user ID in token",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), watchTransactionTimeout)
+	defer cancel()
+
+	grpcReq := &pb.WatchTransactionRequest{
+		TransactionId: c.Param("id"),
+		UserId:        userID,
+	}
+
+	var event *pb.TransactionStatusEvent
+	stream, err := h.transactionClient.WatchTransaction(ctx, grpcReq)
+	if err == nil {
+		// Error dari transaction-service (mis. bukan pemilik) baru diterima bersama pesan pertama,
+		// jadi baca pesan pertama sebelum header SSE dikirim agar error tetap berupa JSON biasa
+		event, err = stream.Recv()
+	}
+	if err != nil {
+		code := httpStatusFromGrpc(err)
+		return c.JSON(code, dto.ErrorResponse{
+			StatusCode: code,
+			Message: "Failed to watch transaction",
+			Error: status.Convert(err).Message(),
+		})
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no") // Matikan buffering di reverse proxy (nginx)
+	res.WriteHeader(http.StatusOK)
+
+	for {
+		data := dto.ToTransactionStatusEvent(event)
+		if err := writeServerSentEvent(res, "status", data); err != nil {
+			return nil // Client sudah menutup koneksi
+		}
+
+		event, err = stream.Recv()
+		switch {
+		case err == io.EOF:
+			writeServerSentEvent(res, "done", data)
+			return nil
+		case ctx.Err() != nil:
+			// Client pergi atau batas waktu koneksi habis; tutup tanpa done agar client menyambung ulang
+			return nil
+		case err != nil:
+			code := httpStatusFromGrpc(err)
+			writeServerSentEvent(res, "error", dto.ErrorResponse{
+				StatusCode: code,
+				Message: "Transaction stream interrupted",
+				Error: status.Convert(err).Message(),
+			})
+			return nil
+		}
+	}
+}
+
+// writeServerSentEvent menulis satu event SSE dengan data JSON lalu langsung mengirimnya ke client.
+func writeServerSentEvent(res *echo.Response, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
+
+// CancelTransaction godoc
+// @Summary      Batalkan transaksi
+// @Description  Transaksi pending langsung dibatalkan; transaksi completed dananya dikembalikan ke wallet (status refunded). User hanya bisa membatalkan transaksinya sendiri, admin bisa membatalkan transaksi siapa saja.
+// @Tags         Gateway - Transaction
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path  string                        true   "Transaction ID"
+// @Param        body  body  dto.CancelTransactionRequest  false  "Alasan pembatalan"
+// @Success      200   {object}  dto.TransactionResponseApi
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      401   {object}  dto.ErrorResponse
+// @Failure      403   {object}  dto.ErrorResponse
+// @Failure      404   {object}  dto.ErrorResponse
+// @Failure      409   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /transactions/{id}/cancel [post]
+func (h *TransactionHandler) CancelTransaction(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Invalid user ID in token",
+		})
+	}
+
+	// Body bersifat opsional, hanya berisi alasan pembatalan
+	var req dto.CancelTransactionRequest
+	if c.Request().ContentLength > 0 {
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message: "Invalid request body",
+				Error: err.Error(),
+			})
+		}
+	}
+
+	grpcReq := &pb.CancelTransactionRequest{
+		TransactionId: c.Param("id"),
+		UserId:        userID,
+		IsAdmin:       c.Get("role") == "admin",
+		Reason:        req.Reason,
+	}
+
+	grpcResp, err := h.transactionClient.CancelTransaction(c.Request().Context(), grpcReq)
+	if err != nil {
+		code := httpStatusFromGrpc(err)
+		return c.JSON(code, dto.ErrorResponse{
+			StatusCode: code,
+			Message: "Failed to cancel transaction",
+			Error: status.Convert(err).Message(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.TransactionResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Transaction cancelled successfully",
+		Data: *dto.ToTransactionResponse(grpcResp),
+	})
+}
+
+// httpStatusFromGrpc menerjemahkan kode error gRPC ke HTTP status code.
+func httpStatusFromGrpc(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.FailedPrecondition:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// grpcErrorResponse mengirim error gRPC dari transaction-service dengan HTTP status yang sesuai.
+func grpcErrorResponse(c echo.Context, message string, err error) error {
+	code := httpStatusFromGrpc(err)
+	return c.JSON(code, dto.ErrorResponse{
+		StatusCode: code,
+		Message: message,
+		Error: status.Convert(err).Message(),
+		Code: grpcErrorCode(err),
+	})
+}
+
+// grpcErrorCode mengambil kode error yang stabil (ErrorInfo.Reason) dari detail status gRPC,
+// mis. daily_limit_exceeded. Mengembalikan string kosong jika service tidak mengirimkannya.
+func grpcErrorCode(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
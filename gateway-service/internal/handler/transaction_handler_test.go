@@ -6,15 +6,21 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"gateway-service/internal/dto"
 	mock_proto "gateway-service/proto"
+	"shared/money"
 	pb "transaction-service/proto"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Skenario 1: Tes CreateTransaction jika service berhasil merespons
@@ -110,10 +116,10 @@ func TestGetTransactions_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
 
-	var resp []*dto.TransactionResponse
+	var resp dto.TransactionListResponse
 	json.Unmarshal(rec.Body.Bytes(), &resp)
-	assert.Len(t, resp, 2)
-	assert.Equal(t, "tx-1", resp[0].TransactionID)
+	assert.Len(t, resp.Data, 2)
+	assert.Equal(t, "tx-1", resp.Data[0].TransactionID)
 	mockClient.AssertExpectations(t)
 }
 
@@ -141,3 +147,450 @@ func TestGetTransactions_ServiceError(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "service down")
 	mockClient.AssertExpectations(t)
 }
+
+// Skenario 5: Tes CancelTransaction oleh pemilik transaksi
+func TestCancelTransaction_Success(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/transactions/99/cancel", bytes.NewReader([]byte(`{"reason":"salah pilih"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("99")
+	c.Set("user_id", "1")
+	c.Set("role", "customer")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("CancelTransaction", mock.Anything, mock.MatchedBy(func(in *pb.CancelTransactionRequest) bool {
+		return in.TransactionId == "99" && in.UserId == "1" && !in.IsAdmin && in.Reason == "salah pilih"
+	})).Return(&pb.TransactionResponse{TransactionId: "99", Status: "cancelled"}, nil)
+	h := NewTransactionHandler(mockClient)
+
+	// --- Act ---
+	err := h.CancelTransaction(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.TransactionResponseApi
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "cancelled", resp.Data.Status)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 6: Tes CancelTransaction oleh admin tanpa body
+func TestCancelTransaction_Admin(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/transactions/99/cancel", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("99")
+	c.Set("user_id", "7")
+	c.Set("role", "admin")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("CancelTransaction", mock.Anything, mock.MatchedBy(func(in *pb.CancelTransactionRequest) bool {
+		return in.IsAdmin && in.Reason == ""
+	})).Return(&pb.TransactionResponse{TransactionId: "99", Status: "refunded"}, nil)
+	h := NewTransactionHandler(mockClient)
+
+	err := h.CancelTransaction(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 7: Tes error gRPC diterjemahkan ke HTTP status yang sesuai
+func TestCancelTransaction_ErrorMapping(t *testing.T) {
+	cases := map[codes.Code]int{
+		codes.NotFound:           http.StatusNotFound,
+		codes.PermissionDenied:   http.StatusForbidden,
+		codes.FailedPrecondition: http.StatusConflict,
+		codes.Unavailable:        http.StatusInternalServerError,
+	}
+	for code, expected := range cases {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/transactions/99/cancel", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("99")
+		c.Set("user_id", "1")
+
+		mockClient := new(mock_proto.MockTransactionServiceClient)
+		mockClient.On("CancelTransaction", mock.Anything, mock.Anything).Return(nil, status.Error(code, "boom"))
+		h := NewTransactionHandler(mockClient)
+
+		err := h.CancelTransaction(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, rec.Code, code.String())
+	}
+}
+
+// Skenario 8: Tes GetTransaction untuk satu transaksi milik user
+func TestGetTransaction_Success(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions/99", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("99")
+	c.Set("user_id", "1")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("GetTransaction", mock.Anything, &pb.GetTransactionRequest{TransactionId: "99", UserId: "1"}).Return(&pb.TransactionResponse{
+		TransactionId: "99",
+		Status:        "pending",
+		Details:       []*pb.TransactionDetail{{BookId: "book-1", Quantity: 1}},
+	}, nil)
+	h := NewTransactionHandler(mockClient)
+
+	// --- Act ---
+	err := h.GetTransaction(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.TransactionResponseApi
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "pending", resp.Data.Status)
+	assert.Len(t, resp.Data.Details, 1)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 9: Tes GetTransaction untuk transaksi milik user lain
+func TestGetTransaction_Forbidden(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions/99", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("99")
+	c.Set("user_id", "2")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("GetTransaction", mock.Anything, mock.Anything).Return(nil, status.Error(codes.PermissionDenied, "transaction does not belong to user"))
+	h := NewTransactionHandler(mockClient)
+
+	err := h.GetTransaction(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "transaction does not belong to user")
+}
+
+// Skenario 10: Tes GetTransactions meneruskan filter dan cursor dari query string
+func TestGetTransactions_WithFilters(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions?page_size=2&page_token=abc&status=completed&start_time=2025-01-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("GetUserTransactions", mock.Anything, mock.MatchedBy(func(in *pb.GetUserTransactionsRequest) bool {
+		return in.UserId == "1" && in.PageSize == 2 && in.PageToken == "abc" && in.Status == "completed" &&
+			in.StartTime.AsTime().Year() == 2025 && in.EndTime == nil
+	})).Return(&pb.GetUserTransactionsResponse{
+		Transactions:  []*pb.TransactionResponse{{TransactionId: "12"}, {TransactionId: "11"}},
+		NextPageToken: "next",
+		TotalCount:    5,
+	}, nil)
+	h := NewTransactionHandler(mockClient)
+
+	// --- Act ---
+	err := h.GetTransactions(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.TransactionListResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Len(t, resp.Data, 2)
+	assert.Equal(t, "next", resp.NextPageToken)
+	assert.Equal(t, int64(5), resp.TotalCount)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 11: Tes GetTransactions menolak query yang tidak valid
+func TestGetTransactions_InvalidQuery(t *testing.T) {
+	for _, query := range []string{"page_size=0", "page_size=abc", "end_time=yesterday"} {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/transactions?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user_id", "1")
+
+		mockClient := new(mock_proto.MockTransactionServiceClient)
+		h := NewTransactionHandler(mockClient)
+
+		err := h.GetTransactions(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		mockClient.AssertNotCalled(t, "GetUserTransactions", mock.Anything, mock.Anything)
+	}
+}
+
+// Skenario 12: Tes CreateTransaction saat stok buku habis
+func TestCreateTransaction_OutOfStock(t *testing.T) {
+	requestBody := dto.CreateTransactionRequest{Items: []dto.BookOrderItem{{BookID: "book-123", Quantity: 5}}}
+	jsonBody, _ := json.Marshal(requestBody)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/transactions", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "user-456")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("CreateTransaction", mock.Anything, mock.Anything).Return(nil, status.Error(codes.FailedPrecondition, "some books are out of stock"))
+	h := NewTransactionHandler(mockClient)
+
+	err := h.CreateTransaction(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "out of stock")
+}
+
+// Skenario 12b: Tes kode batas pengeluaran yang terlampaui dikembalikan di body 409
+func TestCreateTransaction_SpendingLimitExceeded(t *testing.T) {
+	requestBody := dto.CreateTransactionRequest{Items: []dto.BookOrderItem{{BookID: "book-123", Quantity: 1}}}
+	jsonBody, _ := json.Marshal(requestBody)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/transactions", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "user-456")
+
+	limitStatus, _ := status.New(codes.FailedPrecondition, "payment could not be authorized: daily spending limit of Rp100.000 exceeded").
+		WithDetails(&errdetails.ErrorInfo{Reason: "daily_limit_exceeded", Domain: "transaction-service"})
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("CreateTransaction", mock.Anything, mock.Anything).Return(nil, limitStatus.Err())
+	h := NewTransactionHandler(mockClient)
+
+	err := h.CreateTransaction(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var resp dto.ErrorResponse
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Equal(t, "daily_limit_exceeded", resp.Code)
+	assert.Contains(t, resp.Error, "daily spending limit")
+}
+
+// Skenario 13: Tes QuoteOrder mengembalikan quote dari transaction-service
+func TestQuoteOrder_Success(t *testing.T) {
+	requestBody := dto.QuoteRequest{Items: []dto.BookOrderItem{{BookID: "book-123", Quantity: 2}}}
+	jsonBody, _ := json.Marshal(requestBody)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/transactions/quote", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "user-456")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	expectedGrpcReq := &pb.QuoteOrderRequest{
+		UserId: "user-456",
+		Items:  []*pb.BookOrderItem{{BookId: "book-123", Quantity: 2}},
+	}
+	mockClient.On("QuoteOrder", mock.Anything, expectedGrpcReq).Return(&pb.QuoteResponse{
+		QuoteId:     "quote-token",
+		UserId:      "user-456",
+		TotalAmount: money.FromRupiah(100000).ToProto(),
+		ExpiresAt:   timestamppb.Now(),
+	}, nil)
+	h := NewTransactionHandler(mockClient)
+
+	err := h.QuoteOrder(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.QuoteResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Equal(t, "quote-token", resp.Data.QuoteID)
+	assert.Equal(t, money.FromRupiah(100000), resp.Data.TotalAmount)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 14: Tes CreateTransaction dengan quote yang sudah kedaluwarsa
+func TestCreateTransaction_QuoteExpired(t *testing.T) {
+	requestBody := dto.CreateTransactionRequest{QuoteID: "quote-token"}
+	jsonBody, _ := json.Marshal(requestBody)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/transactions", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "user-456")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("CreateTransaction", mock.Anything, mock.MatchedBy(func(in *pb.CreateTransactionRequest) bool {
+		return in.QuoteId == "quote-token"
+	})).Return(nil, status.Error(codes.FailedPrecondition, "quote has expired, please request a new one"))
+	h := NewTransactionHandler(mockClient)
+
+	err := h.CreateTransaction(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "expired")
+}
+
+// Skenario 15: Tes CreateTransaction dengan quote yang tidak valid
+func TestCreateTransaction_InvalidQuote(t *testing.T) {
+	requestBody := dto.CreateTransactionRequest{QuoteID: "forged"}
+	jsonBody, _ := json.Marshal(requestBody)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/transactions", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "user-456")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("CreateTransaction", mock.Anything, mock.Anything).Return(nil, status.Error(codes.InvalidArgument, "invalid quote"))
+	h := NewTransactionHandler(mockClient)
+
+	err := h.CreateTransaction(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// Skenario 16: Tes CreateTransaction dengan kupon menampilkan diskon sebagai baris terpisah
+func TestCreateTransaction_WithCoupon(t *testing.T) {
+	requestBody := dto.CreateTransactionRequest{
+		Items:      []dto.BookOrderItem{{BookID: "book-123", Quantity: 2}},
+		CouponCode: "HEMAT10",
+	}
+	jsonBody, _ := json.Marshal(requestBody)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/transactions", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "user-456")
+
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("CreateTransaction", mock.Anything, mock.MatchedBy(func(in *pb.CreateTransactionRequest) bool {
+		return in.CouponCode == "HEMAT10"
+	})).Return(&pb.TransactionResponse{
+		TransactionId:   "tx-789",
+		UserId:          "user-456",
+		Status:          "pending",
+		TransactionDate: timestamppb.Now(),
+		SubtotalAmount:  money.FromRupiah(100000).ToProto(),
+		TotalAmount:     money.FromRupiah(90000).ToProto(),
+		Discount: &pb.DiscountLine{
+			CouponCode:  "HEMAT10",
+			Description: "Coupon HEMAT10",
+			Amount:      money.FromRupiah(10000).ToProto(),
+		},
+	}, nil)
+	h := NewTransactionHandler(mockClient)
+
+	err := h.CreateTransaction(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var resp dto.TransactionResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Equal(t, money.FromRupiah(100000), resp.Data.SubtotalAmount)
+	assert.Equal(t, money.FromRupiah(90000), resp.Data.TotalAmount)
+	assert.Equal(t, money.FromRupiah(10000), resp.Data.Discount.Amount)
+}
+
+func newWatchContext(userID string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions/99/events", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("99")
+	c.Set("user_id", userID)
+	return c, rec
+}
+
+// Skenario 17: Tes WatchTransaction meneruskan setiap perubahan status sebagai event SSE
+func TestWatchTransaction_StreamsStatusEvents(t *testing.T) {
+	// --- Arrange ---
+	c, rec := newWatchContext("1")
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("WatchTransaction", mock.Anything, &pb.WatchTransactionRequest{TransactionId: "99", UserId: "1"}).Return(&mock_proto.MockWatchTransactionStream{
+		Events: []*pb.TransactionStatusEvent{
+			{TransactionId: "99", Status: "pending"},
+			{TransactionId: "99", Status: "cancelled", FailureCode: "insufficient_funds"},
+		},
+	}, nil)
+	h := NewTransactionHandler(mockClient)
+
+	// --- Act ---
+	err := h.WatchTransaction(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get(echo.HeaderContentType))
+	assert.True(t, rec.Flushed)
+
+	events := strings.Split(strings.TrimSpace(rec.Body.String()), "\n\n")
+	assert.Len(t, events, 3)
+	assert.True(t, strings.HasPrefix(events[0], "event: status\ndata: "))
+	assert.Contains(t, events[0], `"status":"pending"`)
+	assert.Contains(t, events[1], `"failure_code":"insufficient_funds"`)
+	assert.True(t, strings.HasPrefix(events[2], "event: done\ndata: "))
+	assert.Contains(t, events[2], `"status":"cancelled"`)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 18: Tes WatchTransaction untuk transaksi milik user lain dijawab dengan JSON biasa
+func TestWatchTransaction_Forbidden(t *testing.T) {
+	c, rec := newWatchContext("2")
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("WatchTransaction", mock.Anything, mock.Anything).Return(&mock_proto.MockWatchTransactionStream{
+		Err: status.Error(codes.PermissionDenied, "transaction does not belong to user"),
+	}, nil)
+	h := NewTransactionHandler(mockClient)
+
+	err := h.WatchTransaction(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+	assert.Contains(t, rec.Body.String(), "transaction does not belong to user")
+}
+
+// Skenario 19: Tes stream yang terputus di tengah jalan mengirim event error
+func TestWatchTransaction_Interrupted(t *testing.T) {
+	c, rec := newWatchContext("1")
+	mockClient := new(mock_proto.MockTransactionServiceClient)
+	mockClient.On("WatchTransaction", mock.Anything, mock.Anything).Return(&mock_proto.MockWatchTransactionStream{
+		Events: []*pb.TransactionStatusEvent{{TransactionId: "99", Status: "pending"}},
+		Err:    status.Error(codes.Unavailable, "transaction-service is unavailable"),
+	}, nil)
+	h := NewTransactionHandler(mockClient)
+
+	err := h.WatchTransaction(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "event: error\ndata: ")
+	assert.Contains(t, rec.Body.String(), "transaction-service is unavailable")
+	assert.NotContains(t, rec.Body.String(), "event: done")
+}
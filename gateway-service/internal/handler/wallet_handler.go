@@ -1,12 +1,24 @@
 package handler
 
 import (
+	"io"
 	"net/http"
+	"shared/money"
+	"strconv"
+	"time"
 
 	"gateway-service/internal/dto"
 	wallet_pb "wallet-service/proto"
 
 	"github.com/labstack/echo/v4"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Header tempat payment provider mengirim tanda tangan webhook
+	paymentSignatureHeader = "X-Payment-Signature"
+	// Batas ukuran body webhook yang dibaca
+	maxWebhookBodySize = 64 << 10
 )
 
 type WalletHandler struct {
@@ -55,8 +67,9 @@ func (h *WalletHandler) GetBalance(c echo.Context) error {
 
 	// 4. Buat response DTO dan kirim sebagai JSON
 	response := dto.BalanceResponse{
-		UserID:  grpcResp.UserId,
-		Balance: grpcResp.Balance,
+		UserID:           grpcResp.UserId,
+		Balance:          money.FromMinor(grpcResp.Balance.GetMinorUnits()),
+		AvailableBalance: money.FromMinor(grpcResp.AvailableBalance.GetMinorUnits()),
 	}
 
 	return c.JSON(http.StatusOK, dto.BalanceResponseApi{
@@ -69,7 +82,7 @@ func (h *WalletHandler) GetBalance(c echo.Context) error {
 // TopUp menangani POST /api/wallet/topup
 // TopUp godoc
 // @Summary      Top up saldo wallet
-// @Description  Melakukan top up saldo wallet berdasarkan request user
+// @Description  Membuat top up berstatus pending beserta checkout_url di payment provider. Saldo baru bertambah setelah provider mengonfirmasi pembayaran lewat webhook.
 // @Tags         Gateway - Wallet
 // @Accept       json
 // @Produce      json
@@ -105,7 +118,7 @@ func (h *WalletHandler) TopUp(c echo.Context) error {
 	// Buat request gRPC
 	grpcReq := &wallet_pb.TopUpRequest{
 		UserId: userID,
-		Amount: req.Amount,
+		Amount: req.Amount.ToProto(),
 		Method: req.Method,
 	}
 
@@ -118,7 +131,502 @@ func (h *WalletHandler) TopUp(c echo.Context) error {
 	// Kirim response
 	return c.JSON(http.StatusOK, dto.TopUpResponseApi{
 		StatusCode: http.StatusOK,
-		Message: "Top up success",
-		Data: grpcResp,
+		Message: "Top up created, waiting for payment",
+		Data: dto.ToTopUpResponse(grpcResp),
+	})
+}
+
+// ListTopUps menangani GET /api/wallet/topups
+// ListTopUps godoc
+// @Summary      Ambil riwayat top up user
+// @Description  Mengambil top up milik user dari token JWT, terbaru lebih dulu, beserta riwayat perubahan statusnya
+// @Tags         Gateway - Wallet
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status      query  string  false  "Filter status: pending, success, atau failed"
+// @Param        method      query  string  false  "Filter metode top up"
+// @Param        page        query  int     false  "Nomor halaman, mulai dari 1"
+// @Param        page_size   query  int     false  "Jumlah top up per halaman (default 20, maksimal 100)"
+// @Success      200  {object}  dto.TopUpListResponseApi
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /wallet/topups [get]
+func (h *WalletHandler) ListTopUps(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	grpcReq := &wallet_pb.ListTopUpsRequest{
+		UserId: userID,
+		Status: c.QueryParam("status"),
+		Method: c.QueryParam("method"),
+	}
+	for name, target := range map[string]*int32{
+		"page":      &grpcReq.Page,
+		"page_size": &grpcReq.PageSize,
+	} {
+		value := c.QueryParam(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 1 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message: "Invalid " + name,
+			})
+		}
+		*target = int32(n)
+	}
+
+	grpcResp, err := h.walletClient.ListTopUps(c.Request().Context(), grpcReq)
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get top ups", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.TopUpListResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get data success",
+		Data: dto.ToTopUpListResponse(grpcResp),
+	})
+}
+
+// GetTopUp menangani GET /api/wallet/topups/:id
+// GetTopUp godoc
+// @Summary      Ambil bukti top up
+// @Description  Mengambil detail satu top up milik user dari token JWT beserta riwayat perubahan statusnya
+// @Tags         Gateway - Wallet
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Top up ID"
+// @Success      200  {object}  dto.TopUpDetailResponseApi
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /wallet/topups/{id} [get]
+func (h *WalletHandler) GetTopUp(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	grpcResp, err := h.walletClient.GetTopUp(c.Request().Context(), &wallet_pb.GetTopUpRequest{
+		UserId:  userID,
+		TopUpId: c.Param("id"),
+	})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get top up", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.TopUpDetailResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get data success",
+		Data: dto.ToTopUpDetailResponse(grpcResp.TopUp),
+	})
+}
+
+// PaymentWebhook menangani POST /api/payments/webhook
+// PaymentWebhook godoc
+// @Summary      Webhook hasil pembayaran top up
+// @Description  Dipanggil oleh payment provider. Body diteruskan apa adanya ke wallet-service untuk verifikasi tanda tangan pada header X-Payment-Signature. Hanya pembayaran sukses yang menambah saldo.
+// @Tags         Gateway - Wallet
+// @Accept       json
+// @Produce      json
+// @Param        X-Payment-Signature  header  string  true  "Tanda tangan webhook dari provider"
+// @Success      200  {object}  dto.PaymentWebhookResponseApi
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse  "Top up sudah selesai dengan hasil berbeda"
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /payments/webhook [post]
+func (h *WalletHandler) PaymentWebhook(c echo.Context) error {
+	signature := c.Request().Header.Get(paymentSignatureHeader)
+	if signature == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Missing " + paymentSignatureHeader + " header",
+		})
+	}
+
+	// Tanda tangan dihitung dari body mentah, jadi body tidak boleh di-decode lalu di-encode ulang
+	payload, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBodySize))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error: err.Error(),
+		})
+	}
+
+	grpcResp, err := h.walletClient.HandlePaymentWebhook(c.Request().Context(), &wallet_pb.PaymentWebhookRequest{
+		Payload:   payload,
+		Signature: signature,
+	})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to process payment webhook", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.PaymentWebhookResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Webhook processed",
+		Data: dto.PaymentWebhookResponse{TopUpID: grpcResp.TopUpId, Status: grpcResp.Status},
+	})
+}
+
+// GetLedger menangani GET /api/wallet/ledger
+// GetLedger godoc
+// @Summary      Ambil riwayat ledger wallet user
+// @Description  Mengambil entri ledger (debit, credit, top up, refund, adjustment) milik user dari token JWT, terbaru lebih dulu
+// @Tags         Gateway - Wallet
+// @Produce      json
+// @Security     BearerAuth
+// @Param        start_time  query  string  false  "Awal rentang waktu (RFC3339, inklusif)"
+// @Param        end_time    query  string  false  "Akhir rentang waktu (RFC3339, eksklusif)"
+// @Param        page        query  int     false  "Nomor halaman, mulai dari 1"
+// @Param        page_size   query  int     false  "Jumlah entri per halaman (default 20, maksimal 100)"
+// @Success      200  {object}  dto.LedgerResponseApi
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /wallet/ledger [get]
+func (h *WalletHandler) GetLedger(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	return h.getLedger(c, userID)
+}
+
+// GetUserLedger menangani GET /api/admin/wallets/:user_id/ledger
+// GetUserLedger godoc
+// @Summary      Ambil riwayat ledger wallet user tertentu (admin)
+// @Description  Dipakai support untuk menjelaskan saldo seorang user
+// @Tags         Gateway - Wallet
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id     path   string  true   "User ID"
+// @Param        start_time  query  string  false  "Awal rentang waktu (RFC3339, inklusif)"
+// @Param        end_time    query  string  false  "Akhir rentang waktu (RFC3339, eksklusif)"
+// @Param        page        query  int     false  "Nomor halaman, mulai dari 1"
+// @Param        page_size   query  int     false  "Jumlah entri per halaman (default 20, maksimal 100)"
+// @Success      200  {object}  dto.LedgerResponseApi
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /admin/wallets/{user_id}/ledger [get]
+func (h *WalletHandler) GetUserLedger(c echo.Context) error {
+	return h.getLedger(c, c.Param("user_id"))
+}
+
+// getLedger membaca filter dari query string lalu memanggil wallet-service.
+func (h *WalletHandler) getLedger(c echo.Context, userID string) error {
+	grpcReq := &wallet_pb.GetLedgerRequest{UserId: userID}
+
+	for name, target := range map[string]**timestamppb.Timestamp{
+		"start_time": &grpcReq.StartTime,
+		"end_time":   &grpcReq.EndTime,
+	} {
+		value := c.QueryParam(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message: "Invalid " + name + ", expected RFC3339",
+				Error: err.Error(),
+			})
+		}
+		*target = timestamppb.New(t)
+	}
+
+	for name, target := range map[string]*int32{
+		"page":      &grpcReq.Page,
+		"page_size": &grpcReq.PageSize,
+	} {
+		value := c.QueryParam(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 1 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message: "Invalid " + name,
+			})
+		}
+		*target = int32(n)
+	}
+
+	grpcResp, err := h.walletClient.GetLedger(c.Request().Context(), grpcReq)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			StatusCode: http.StatusInternalServerError,
+			Message: "Internal server error",
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.LedgerResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get data success",
+		Data: dto.ToLedgerResponse(userID, grpcResp),
+	})
+}
+
+// Transfer menangani POST /api/wallet/transfer
+// Transfer godoc
+// @Summary      Transfer saldo ke user lain
+// @Description  Memindahkan saldo dari user pada token JWT ke user lain. Debit dan kredit terjadi dalam satu transaksi database.
+// @Tags         Gateway - Wallet
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body  dto.TransferRequest  true  "Detail transfer"
+// @Success      200  {object}  dto.TransferResponseApi
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse  "Saldo tidak cukup"
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /wallet/transfer [post]
+func (h *WalletHandler) Transfer(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	var req dto.TransferRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error: err.Error(),
+		})
+	}
+
+	grpcResp, err := h.walletClient.Transfer(c.Request().Context(), &wallet_pb.TransferRequest{
+		FromUserId: userID,
+		ToUserId:   req.ToUserID,
+		Amount:     req.Amount.ToProto(),
+		Note:       req.Note,
+	})
+	if err != nil {
+		return grpcErrorResponse(c, "Transfer failed", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.TransferResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Transfer success",
+		Data: dto.TransferResultResponse{
+			Transfer: dto.ToTransferResponse(grpcResp.Transfer),
+			Balance:  money.FromMinor(grpcResp.NewBalance.GetMinorUnits()),
+		},
+	})
+}
+
+// ListTransfers menangani GET /api/wallet/transfers
+// ListTransfers godoc
+// @Summary      Ambil riwayat transfer wallet user
+// @Description  Mengambil transfer masuk dan keluar milik user dari token JWT, terbaru lebih dulu
+// @Tags         Gateway - Wallet
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page        query  int     false  "Nomor halaman, mulai dari 1"
+// @Param        page_size   query  int     false  "Jumlah transfer per halaman (default 20, maksimal 100)"
+// @Success      200  {object}  dto.TransferListResponseApi
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /wallet/transfers [get]
+func (h *WalletHandler) ListTransfers(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	grpcReq := &wallet_pb.ListTransfersRequest{UserId: userID}
+	for name, target := range map[string]*int32{
+		"page":      &grpcReq.Page,
+		"page_size": &grpcReq.PageSize,
+	} {
+		value := c.QueryParam(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || n < 1 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message: "Invalid " + name,
+			})
+		}
+		*target = int32(n)
+	}
+
+	grpcResp, err := h.walletClient.ListTransfers(c.Request().Context(), grpcReq)
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get transfers", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.TransferListResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get data success",
+		Data: dto.ToTransferListResponse(grpcResp),
+	})
+}
+
+// GetSpendingLimits menangani GET /api/wallet/limits
+// GetSpendingLimits godoc
+// @Summary      Ambil batas pengeluaran wallet
+// @Description  Mengambil batas pengeluaran harian, bulanan, dan per transaksi milik user dari token JWT beserta pemakaiannya hari ini dan bulan ini. Nilai 0 berarti tidak dibatasi.
+// @Tags         Gateway - Wallet
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dto.SpendingLimitsResponseApi
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /wallet/limits [get]
+func (h *WalletHandler) GetSpendingLimits(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	return h.getSpendingLimits(c, userID)
+}
+
+// SetSpendingLimits menangani PUT /api/wallet/limits
+// SetSpendingLimits godoc
+// @Summary      Atur batas pengeluaran wallet
+// @Description  Mengganti batas pengeluaran milik user dari token JWT. Batas berlaku untuk pembelian dan transfer keluar; batas yang tidak diisi atau 0 berarti tidak dibatasi.
+// @Description  User hanya boleh memperketat batasnya sendiri. Menaikkan atau menghapus batas yang sudah ada ditolak dengan 403 dan hanya bisa dilakukan admin lewat /admin/wallets/{user_id}/limits.
+// @Tags         Gateway - Wallet
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body  dto.SpendingLimitsRequest  true  "Batas pengeluaran"
+// @Success      200  {object}  dto.SpendingLimitsResponseApi
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /wallet/limits [put]
+func (h *WalletHandler) SetSpendingLimits(c echo.Context) error {
+	userID, ok := c.Get("user_id").(string)
+	if !ok || userID == "" {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+	}
+
+	return h.setSpendingLimits(c, userID, false)
+}
+
+// GetUserSpendingLimits menangani GET /api/admin/wallets/:user_id/limits
+// GetUserSpendingLimits godoc
+// @Summary      Ambil batas pengeluaran wallet user tertentu (admin)
+// @Description  Mengambil batas pengeluaran seorang user beserta pemakaiannya hari ini dan bulan ini
+// @Tags         Gateway - Wallet
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id  path  string  true  "User ID"
+// @Success      200  {object}  dto.SpendingLimitsResponseApi
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /admin/wallets/{user_id}/limits [get]
+func (h *WalletHandler) GetUserSpendingLimits(c echo.Context) error {
+	return h.getSpendingLimits(c, c.Param("user_id"))
+}
+
+// SetUserSpendingLimits menangani PUT /api/admin/wallets/:user_id/limits
+// SetUserSpendingLimits godoc
+// @Summary      Atur batas pengeluaran wallet user tertentu (admin)
+// @Description  Mengganti batas pengeluaran seorang user, mis. akun anak atau pelajar. Batas yang tidak diisi atau 0 berarti tidak dibatasi.
+// @Tags         Gateway - Wallet
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        user_id  path  string                     true  "User ID"
+// @Param        body     body  dto.SpendingLimitsRequest  true  "Batas pengeluaran"
+// @Success      200  {object}  dto.SpendingLimitsResponseApi
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /admin/wallets/{user_id}/limits [put]
+func (h *WalletHandler) SetUserSpendingLimits(c echo.Context) error {
+	return h.setSpendingLimits(c, c.Param("user_id"), true)
+}
+
+// getSpendingLimits memanggil wallet-service untuk membaca batas pengeluaran userID.
+func (h *WalletHandler) getSpendingLimits(c echo.Context, userID string) error {
+	grpcResp, err := h.walletClient.GetSpendingLimits(c.Request().Context(), &wallet_pb.GetSpendingLimitsRequest{UserId: userID})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to get spending limits", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.SpendingLimitsResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Get data success",
+		Data: dto.ToSpendingLimitsResponse(grpcResp),
+	})
+}
+
+// setSpendingLimits membaca body request lalu mengganti batas pengeluaran userID.
+// Jika bukan callerIsAdmin, wallet-service menolak permintaan yang menaikkan atau menghapus
+// batas yang berlaku dengan PermissionDenied (403).
+func (h *WalletHandler) setSpendingLimits(c echo.Context, userID string, callerIsAdmin bool) error {
+	var req dto.SpendingLimitsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error: err.Error(),
+		})
+	}
+
+	grpcResp, err := h.walletClient.SetSpendingLimits(c.Request().Context(), &wallet_pb.SetSpendingLimitsRequest{
+		UserId:              userID,
+		DailyLimit:          req.DailyLimit.ToProto(),
+		MonthlyLimit:        req.MonthlyLimit.ToProto(),
+		PerTransactionLimit: req.PerTransactionLimit.ToProto(),
+		CallerIsAdmin:       callerIsAdmin,
+	})
+	if err != nil {
+		return grpcErrorResponse(c, "Failed to update spending limits", err)
+	}
+
+	return c.JSON(http.StatusOK, dto.SpendingLimitsResponseApi{
+		StatusCode: http.StatusOK,
+		Message: "Spending limits updated",
+		Data: dto.ToSpendingLimitsResponse(grpcResp),
 	})
 }
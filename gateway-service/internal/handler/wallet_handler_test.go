@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"shared/money"
 	"testing"
 
 	"gateway-service/internal/dto"
@@ -15,6 +16,8 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Skenario 1: Tes GetBalance jika service berhasil merespons
@@ -30,7 +33,7 @@ func TestGetBalance_Success(t *testing.T) {
 	// 2. Buat mock gRPC client
 	mockClient := new(mock_proto.MockWalletServiceClient)
 	// 3. Program mock untuk mengembalikan response sukses
-	mockResponse := &pb.GetBalanceResponse{UserId: "user-123", Balance: 50000}
+	mockResponse := &pb.GetBalanceResponse{UserId: "user-123", Balance: money.FromRupiah(50000).ToProto(), AvailableBalance: money.FromRupiah(30000).ToProto()}
 	mockClient.On("GetBalance", mock.Anything, &pb.GetBalanceRequest{UserId: "user-123"}).Return(mockResponse, nil)
 
 	// 4. Buat handler dengan mock client
@@ -45,9 +48,11 @@ func TestGetBalance_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
 
-	var resp dto.BalanceResponse
+	var resp dto.BalanceResponseApi
 	json.Unmarshal(rec.Body.Bytes(), &resp)
-	assert.Equal(t, float64(50000), resp.Balance)
+	assert.Equal(t, money.FromRupiah(50000), resp.Data.Balance)
+	assert.Equal(t, money.FromRupiah(30000), resp.Data.AvailableBalance)
+	assert.Contains(t, rec.Body.String(), `"balance":50000`) // Tetap angka untuk klien lama
 	mockClient.AssertExpectations(t)
 }
 
@@ -79,7 +84,7 @@ func TestGetBalance_ServiceError(t *testing.T) {
 func TestTopUp_Success(t *testing.T) {
 	// --- Arrange ---
 	// 1. Siapkan request body
-	requestBody := dto.TopUpRequest{Amount: 100000, Method: "Transfer"}
+	requestBody := dto.TopUpRequest{Amount: money.FromRupiah(100000), Method: "Transfer"}
 	jsonBody, _ := json.Marshal(requestBody)
 
 	// 2. Siapkan Echo context
@@ -92,8 +97,10 @@ func TestTopUp_Success(t *testing.T) {
 
 	// 3. Buat dan program mock
 	mockClient := new(mock_proto.MockWalletServiceClient)
-	mockResponse := &pb.TopUpResponse{TopUpId: "topup-456", Status: "success"}
-	mockClient.On("TopUp", mock.Anything, mock.AnythingOfType("*proto.TopUpRequest")).Return(mockResponse, nil)
+	mockResponse := &pb.TopUpResponse{TopUpId: "topup-456", Status: "success", Amount: money.FromRupiah(100000).ToProto()}
+	mockClient.On("TopUp", mock.Anything, mock.MatchedBy(func(r *pb.TopUpRequest) bool {
+		return r.UserId == "user-123" && r.Amount.GetMinorUnits() == money.FromRupiah(100000).Minor()
+	})).Return(mockResponse, nil)
 
 	h := NewWalletHandler(mockClient)
 
@@ -104,16 +111,17 @@ func TestTopUp_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
 
-	var resp pb.TopUpResponse
+	var resp dto.TopUpResponseApi
 	json.Unmarshal(rec.Body.Bytes(), &resp)
-	assert.Equal(t, "topup-456", resp.TopUpId)
+	assert.Equal(t, "topup-456", resp.Data.TopUpID)
+	assert.Equal(t, money.FromRupiah(100000), resp.Data.Amount)
 	mockClient.AssertExpectations(t)
 }
 
 // Skenario 4: Tes TopUp jika service mengembalikan error
 func TestTopUp_ServiceError(t *testing.T) {
 	// --- Arrange ---
-	requestBody := dto.TopUpRequest{Amount: 100000, Method: "Transfer"}
+	requestBody := dto.TopUpRequest{Amount: money.FromRupiah(100000), Method: "Transfer"}
 	jsonBody, _ := json.Marshal(requestBody)
 
 	e := echo.New()
@@ -137,3 +145,425 @@ func TestTopUp_ServiceError(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "failed to process top-up")
 	mockClient.AssertExpectations(t)
 }
+
+
+// Skenario 5: Tes GetLedger meneruskan filter dari query string
+func TestGetLedger_Success(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/wallet/ledger?start_time=2025-07-01T00:00:00Z&page=2&page_size=10", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "user-123")
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	mockResponse := &pb.GetLedgerResponse{
+		Entries: []*pb.LedgerEntry{
+			{Id: "7", EntryType: "debit", Amount: money.FromRupiah(-25000).ToProto(), BalanceAfter: money.FromRupiah(75000).ToProto(), ReferenceType: "transaction", ReferenceId: "42"},
+		},
+		Total:    11,
+		Page:     2,
+		PageSize: 10,
+	}
+	mockClient.On("GetLedger", mock.Anything, mock.MatchedBy(func(r *pb.GetLedgerRequest) bool {
+		return r.UserId == "user-123" && r.Page == 2 && r.PageSize == 10 &&
+			r.StartTime != nil && r.StartTime.AsTime().Format("2006-01-02") == "2025-07-01" && r.EndTime == nil
+	})).Return(mockResponse, nil)
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.GetLedger(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.LedgerResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Equal(t, int64(11), resp.Data.Total)
+	assert.Len(t, resp.Data.Entries, 1)
+	assert.Equal(t, "42", resp.Data.Entries[0].ReferenceID)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario 6: Tes GetLedger dengan format waktu yang salah
+func TestGetLedger_InvalidTime(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/wallet/ledger?end_time=kemarin", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "user-123")
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.GetLedger(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	mockClient.AssertNotCalled(t, "GetLedger", mock.Anything, mock.Anything)
+}
+
+// Skenario: Tes Transfer jika service berhasil merespons
+func TestTransfer_Success(t *testing.T) {
+	// --- Arrange ---
+	requestBody := dto.TransferRequest{ToUserID: "2", Amount: money.FromRupiah(25000), Note: "Uang jajan"}
+	jsonBody, _ := json.Marshal(requestBody)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/wallet/transfer", bytes.NewReader(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	mockResponse := &pb.TransferResponse{
+		Transfer:   &pb.Transfer{Id: "7", FromUserId: "1", ToUserId: "2", Amount: money.FromRupiah(25000).ToProto(), Note: "Uang jajan"},
+		NewBalance: money.FromRupiah(75000).ToProto(),
+	}
+	mockClient.On("Transfer", mock.Anything, mock.MatchedBy(func(r *pb.TransferRequest) bool {
+		return r.FromUserId == "1" && r.ToUserId == "2" && r.Note == "Uang jajan" &&
+			r.Amount.GetMinorUnits() == money.FromRupiah(25000).Minor()
+	})).Return(mockResponse, nil)
+
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.Transfer(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.TransferResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Equal(t, "7", resp.Data.Transfer.ID)
+	assert.Equal(t, money.FromRupiah(75000), resp.Data.Balance)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario: Tes Transfer jika saldo pengirim tidak cukup
+func TestTransfer_InsufficientFunds(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/wallet/transfer", bytes.NewReader([]byte(`{"to_user_id":"2","amount":500000}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	mockClient.On("Transfer", mock.Anything, mock.Anything).Return(nil, status.Error(codes.FailedPrecondition, "insufficient funds"))
+
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.Transfer(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "insufficient funds")
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario: Tes ListTransfers dengan pagination
+func TestListTransfers_Success(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/wallet/transfers?page=2&page_size=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	mockResponse := &pb.ListTransfersResponse{
+		Transfers: []*pb.Transfer{{Id: "7", FromUserId: "1", ToUserId: "2", Amount: money.FromRupiah(25000).ToProto()}},
+		Total:     6,
+		Page:      2,
+		PageSize:  5,
+	}
+	mockClient.On("ListTransfers", mock.Anything, &pb.ListTransfersRequest{UserId: "1", Page: 2, PageSize: 5}).Return(mockResponse, nil)
+
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.ListTransfers(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.TransferListResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Len(t, resp.Data.Transfers, 1)
+	assert.Equal(t, money.FromRupiah(25000), resp.Data.Transfers[0].Amount)
+	assert.Equal(t, int64(6), resp.Data.Total)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario: Tes PaymentWebhook meneruskan body mentah dan tanda tangan ke wallet-service
+func TestPaymentWebhook_Success(t *testing.T) {
+	// --- Arrange ---
+	payload := `{"reference":"fake_12_abc","status":"paid"}`
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/payments/webhook", bytes.NewReader([]byte(payload)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Payment-Signature", "sig-123")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	mockClient.On("HandlePaymentWebhook", mock.Anything, mock.MatchedBy(func(r *pb.PaymentWebhookRequest) bool {
+		return string(r.Payload) == payload && r.Signature == "sig-123"
+	})).Return(&pb.PaymentWebhookResponse{TopUpId: "12", Status: "success"}, nil)
+
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.PaymentWebhook(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.PaymentWebhookResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Equal(t, "12", resp.Data.TopUpID)
+	assert.Equal(t, "success", resp.Data.Status)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario: Tes PaymentWebhook dengan tanda tangan yang ditolak wallet-service
+func TestPaymentWebhook_InvalidSignature(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/payments/webhook", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("X-Payment-Signature", "forged")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	mockClient.On("HandlePaymentWebhook", mock.Anything, mock.Anything).Return(nil, status.Error(codes.Unauthenticated, "invalid webhook signature"))
+
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.PaymentWebhook(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario: Tes PaymentWebhook tanpa header tanda tangan tidak diteruskan
+func TestPaymentWebhook_MissingSignature(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/payments/webhook", bytes.NewReader([]byte(`{}`)))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.PaymentWebhook(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockClient.AssertNotCalled(t, "HandlePaymentWebhook", mock.Anything, mock.Anything)
+}
+
+// Skenario: Tes ListTopUps meneruskan filter dan pagination
+func TestListTopUps_Success(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/wallet/topups?status=success&method=Transfer&page=1&page_size=10", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	mockResponse := &pb.ListTopUpsResponse{
+		TopUps: []*pb.TopUp{{
+			Id: "12", UserId: "1", Amount: money.FromRupiah(50000).ToProto(), Method: "Transfer", Status: "success",
+			Transitions: []*pb.TopUpStatusChange{{Status: "pending"}, {Status: "success"}},
+		}},
+		Total:    1,
+		Page:     1,
+		PageSize: 10,
+	}
+	mockClient.On("ListTopUps", mock.Anything, &pb.ListTopUpsRequest{UserId: "1", Status: "success", Method: "Transfer", Page: 1, PageSize: 10}).
+		Return(mockResponse, nil)
+
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.ListTopUps(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.TopUpListResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Len(t, resp.Data.TopUps, 1)
+	assert.Equal(t, money.FromRupiah(50000), resp.Data.TopUps[0].Amount)
+	assert.Len(t, resp.Data.TopUps[0].Transitions, 2)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario: Tes GetTopUp jika top up tidak ditemukan
+func TestGetTopUp_NotFound(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/wallet/topups/99", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "1")
+	c.SetParamNames("id")
+	c.SetParamValues("99")
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	mockClient.On("GetTopUp", mock.Anything, &pb.GetTopUpRequest{UserId: "1", TopUpId: "99"}).
+		Return(nil, status.Error(codes.NotFound, "top-up not found"))
+
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.GetTopUp(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario: Tes admin mengatur batas pengeluaran user tertentu
+func TestSetUserSpendingLimits_Success(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	body := `{"daily_limit":50000,"monthly_limit":500000,"per_transaction_limit":25000}`
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/wallets/3/limits", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("user_id")
+	c.SetParamValues("3")
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	mockResponse := &pb.SpendingLimitsResponse{
+		UserId:              "3",
+		DailyLimit:          money.FromRupiah(50000).ToProto(),
+		MonthlyLimit:        money.FromRupiah(500000).ToProto(),
+		PerTransactionLimit: money.FromRupiah(25000).ToProto(),
+		SpentToday:          money.FromRupiah(10000).ToProto(),
+		SpentThisMonth:      money.FromRupiah(120000).ToProto(),
+	}
+	mockClient.On("SetSpendingLimits", mock.Anything, mock.MatchedBy(func(r *pb.SetSpendingLimitsRequest) bool {
+		return r.UserId == "3" && r.DailyLimit.GetMinorUnits() == money.FromRupiah(50000).Minor() &&
+			r.PerTransactionLimit.GetMinorUnits() == money.FromRupiah(25000).Minor() && r.CallerIsAdmin
+	})).Return(mockResponse, nil)
+
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.SetUserSpendingLimits(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp dto.SpendingLimitsResponseApi
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.Equal(t, money.FromRupiah(500000), resp.Data.MonthlyLimit)
+	assert.Equal(t, money.FromRupiah(10000), resp.Data.SpentToday)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario: Tes batas pengeluaran yang tidak valid ditolak dengan 400
+func TestSetSpendingLimits_Invalid(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/wallet/limits", bytes.NewReader([]byte(`{"daily_limit":600000,"monthly_limit":500000}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "3")
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	mockClient.On("SetSpendingLimits", mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.InvalidArgument, "invalid spending limit: daily_limit must not exceed monthly_limit"))
+
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.SetSpendingLimits(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "daily_limit must not exceed monthly_limit")
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario: Tes user boleh memperketat batas pengeluarannya sendiri
+func TestSetSpendingLimits_Stricter(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	body := `{"daily_limit":30000,"monthly_limit":500000,"per_transaction_limit":25000}`
+	req := httptest.NewRequest(http.MethodPut, "/api/wallet/limits", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "3")
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	mockClient.On("SetSpendingLimits", mock.Anything, mock.MatchedBy(func(r *pb.SetSpendingLimitsRequest) bool {
+		return r.UserId == "3" && r.DailyLimit.GetMinorUnits() == money.FromRupiah(30000).Minor() && !r.CallerIsAdmin
+	})).Return(&pb.SpendingLimitsResponse{UserId: "3"}, nil)
+
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.SetSpendingLimits(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockClient.AssertExpectations(t)
+}
+
+// Skenario: Tes penolakan wallet-service saat user melonggarkan batasnya sendiri diteruskan sebagai 403
+func TestSetSpendingLimits_RaiseRejected(t *testing.T) {
+	// --- Arrange ---
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/wallet/limits", bytes.NewReader([]byte(`{"daily_limit":100000,"monthly_limit":500000}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", "3")
+
+	mockClient := new(mock_proto.MockWalletServiceClient)
+	mockClient.On("SetSpendingLimits", mock.Anything, mock.MatchedBy(func(r *pb.SetSpendingLimitsRequest) bool {
+		return r.UserId == "3" && !r.CallerIsAdmin
+	})).Return(nil, status.Error(codes.PermissionDenied, "only an admin can raise or remove spending limits: daily_limit would be raised or removed"))
+
+	h := NewWalletHandler(mockClient)
+
+	// --- Act ---
+	err := h.SetSpendingLimits(c)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "daily_limit")
+	mockClient.AssertExpectations(t)
+}
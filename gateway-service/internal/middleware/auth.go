@@ -43,6 +43,7 @@ func JwtAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
         // Simpan informasi user di context agar bisa digunakan oleh handler
 		c.Set("user_id", claims["user_id"])
 		c.Set("role", claims["role"])
+		c.Set("email", claims["email"])
 
 		return next(c)
 	}
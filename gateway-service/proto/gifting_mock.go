@@ -22,4 +22,13 @@ func (m *MockGiftingServiceClient) SendGift(ctx context.Context, in *pb.SendGift
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*pb.SendGiftResponse), args.Error(1)
-}
\ No newline at end of file
+}
+
+// AcceptGift adalah implementasi mock untuk menerima hadiah.
+func (m *MockGiftingServiceClient) AcceptGift(ctx context.Context, in *pb.AcceptGiftRequest, opts ...grpc.CallOption) (*pb.AcceptGiftResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.AcceptGiftResponse), args.Error(1)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
+	"io"
 	pb "transaction-service/proto"
 )
 
@@ -30,4 +31,223 @@ func (m *MockTransactionServiceClient) GetUserTransactions(ctx context.Context,
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*pb.GetUserTransactionsResponse), args.Error(1)
-}
\ No newline at end of file
+}
+
+// GetTransaction adalah implementasi mock
+func (m *MockTransactionServiceClient) GetTransaction(ctx context.Context, in *pb.GetTransactionRequest, opts ...grpc.CallOption) (*pb.TransactionResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.TransactionResponse), args.Error(1)
+}
+
+// WatchTransaction adalah implementasi mock
+func (m *MockTransactionServiceClient) WatchTransaction(ctx context.Context, in *pb.WatchTransactionRequest, opts ...grpc.CallOption) (pb.TransactionService_WatchTransactionClient, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(pb.TransactionService_WatchTransactionClient), args.Error(1)
+}
+
+// MockWatchTransactionStream adalah stream WatchTransaction palsu yang mengirim Events
+// secara berurutan lalu mengembalikan Err (io.EOF jika kosong).
+type MockWatchTransactionStream struct {
+	grpc.ClientStream
+	Events []*pb.TransactionStatusEvent
+	Err    error
+}
+
+// Recv adalah implementasi mock
+func (s *MockWatchTransactionStream) Recv() (*pb.TransactionStatusEvent, error) {
+	if len(s.Events) == 0 {
+		if s.Err == nil {
+			return nil, io.EOF
+		}
+		return nil, s.Err
+	}
+	event := s.Events[0]
+	s.Events = s.Events[1:]
+	return event, nil
+}
+
+// CancelTransaction adalah implementasi mock
+func (m *MockTransactionServiceClient) CancelTransaction(ctx context.Context, in *pb.CancelTransactionRequest, opts ...grpc.CallOption) (*pb.TransactionResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.TransactionResponse), args.Error(1)
+}
+
+// QuoteOrder adalah implementasi mock
+func (m *MockTransactionServiceClient) QuoteOrder(ctx context.Context, in *pb.QuoteOrderRequest, opts ...grpc.CallOption) (*pb.QuoteResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.QuoteResponse), args.Error(1)
+}
+
+// GetCart adalah implementasi mock
+func (m *MockTransactionServiceClient) GetCart(ctx context.Context, in *pb.GetCartRequest, opts ...grpc.CallOption) (*pb.CartResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.CartResponse), args.Error(1)
+}
+
+// AddCartItem adalah implementasi mock
+func (m *MockTransactionServiceClient) AddCartItem(ctx context.Context, in *pb.CartItemRequest, opts ...grpc.CallOption) (*pb.CartResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.CartResponse), args.Error(1)
+}
+
+// UpdateCartItem adalah implementasi mock
+func (m *MockTransactionServiceClient) UpdateCartItem(ctx context.Context, in *pb.CartItemRequest, opts ...grpc.CallOption) (*pb.CartResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.CartResponse), args.Error(1)
+}
+
+// RemoveCartItem adalah implementasi mock
+func (m *MockTransactionServiceClient) RemoveCartItem(ctx context.Context, in *pb.RemoveCartItemRequest, opts ...grpc.CallOption) (*pb.CartResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.CartResponse), args.Error(1)
+}
+
+// CheckoutCart adalah implementasi mock
+func (m *MockTransactionServiceClient) CheckoutCart(ctx context.Context, in *pb.CheckoutCartRequest, opts ...grpc.CallOption) (*pb.TransactionResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.TransactionResponse), args.Error(1)
+}
+
+// CreateCoupon adalah implementasi mock
+func (m *MockTransactionServiceClient) CreateCoupon(ctx context.Context, in *pb.CreateCouponRequest, opts ...grpc.CallOption) (*pb.Coupon, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Coupon), args.Error(1)
+}
+
+// UpdateCoupon adalah implementasi mock
+func (m *MockTransactionServiceClient) UpdateCoupon(ctx context.Context, in *pb.UpdateCouponRequest, opts ...grpc.CallOption) (*pb.Coupon, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Coupon), args.Error(1)
+}
+
+// DeleteCoupon adalah implementasi mock
+func (m *MockTransactionServiceClient) DeleteCoupon(ctx context.Context, in *pb.DeleteCouponRequest, opts ...grpc.CallOption) (*pb.DeleteCouponResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.DeleteCouponResponse), args.Error(1)
+}
+
+// GetCoupon adalah implementasi mock
+func (m *MockTransactionServiceClient) GetCoupon(ctx context.Context, in *pb.GetCouponRequest, opts ...grpc.CallOption) (*pb.Coupon, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Coupon), args.Error(1)
+}
+
+// ListCoupons adalah implementasi mock
+func (m *MockTransactionServiceClient) ListCoupons(ctx context.Context, in *pb.ListCouponsRequest, opts ...grpc.CallOption) (*pb.ListCouponsResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ListCouponsResponse), args.Error(1)
+}
+
+func (m *MockTransactionServiceClient) GetRevenueReport(ctx context.Context, in *pb.RevenueReportRequest, opts ...grpc.CallOption) (*pb.RevenueReportResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.RevenueReportResponse), args.Error(1)
+}
+
+func (m *MockTransactionServiceClient) GetTopBooksReport(ctx context.Context, in *pb.TopBooksReportRequest, opts ...grpc.CallOption) (*pb.TopBooksReportResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.TopBooksReportResponse), args.Error(1)
+}
+
+func (m *MockTransactionServiceClient) GetCategoryReport(ctx context.Context, in *pb.CategoryReportRequest, opts ...grpc.CallOption) (*pb.CategoryReportResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.CategoryReportResponse), args.Error(1)
+}
+
+func (m *MockTransactionServiceClient) RunReconciliation(ctx context.Context, in *pb.RunReconciliationRequest, opts ...grpc.CallOption) (*pb.ReconciliationRun, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ReconciliationRun), args.Error(1)
+}
+
+func (m *MockTransactionServiceClient) ListReconciliationRuns(ctx context.Context, in *pb.ListReconciliationRunsRequest, opts ...grpc.CallOption) (*pb.ListReconciliationRunsResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ListReconciliationRunsResponse), args.Error(1)
+}
+
+func (m *MockTransactionServiceClient) GetReconciliationRun(ctx context.Context, in *pb.GetReconciliationRunRequest, opts ...grpc.CallOption) (*pb.ReconciliationRun, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ReconciliationRun), args.Error(1)
+}
+
+func (m *MockTransactionServiceClient) ListLibrary(ctx context.Context, in *pb.ListLibraryRequest, opts ...grpc.CallOption) (*pb.ListLibraryResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ListLibraryResponse), args.Error(1)
+}
+
+func (m *MockTransactionServiceClient) CheckOwnership(ctx context.Context, in *pb.CheckOwnershipRequest, opts ...grpc.CallOption) (*pb.CheckOwnershipResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.CheckOwnershipResponse), args.Error(1)
+}
+
+func (m *MockTransactionServiceClient) GrantEntitlement(ctx context.Context, in *pb.GrantEntitlementRequest, opts ...grpc.CallOption) (*pb.Entitlement, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.Entitlement), args.Error(1)
+}
@@ -15,6 +15,11 @@ func SetupRoutes(
 	transactionHandler *handler.TransactionHandler,
 	walletHandler *handler.WalletHandler,
 	giftingHandler *handler.GiftingHandler,
+	cartHandler *handler.CartHandler,
+	couponHandler *handler.CouponHandler,
+	reportHandler *handler.ReportHandler,
+	reconciliationHandler *handler.ReconciliationHandler,
+	libraryHandler *handler.LibraryHandler,
 ) {
 	api := e.Group("/api")
 	{
@@ -27,6 +32,9 @@ func SetupRoutes(
 		api.GET("/books", bookHandler.GetBooks)
 		api.GET("/books/:id", bookHandler.GetBookByID)
 
+		// Webhook payment provider diverifikasi lewat tanda tangan, bukan token JWT
+		api.POST("/payments/webhook", walletHandler.PaymentWebhook)
+
 		// === ROUTE TERLINDUNGI (BUTUH LOGIN/TOKEN JWT) ===
 		// Buat grup baru dan terapkan middleware otentikasi
 		protected := api.Group("")
@@ -34,10 +42,28 @@ func SetupRoutes(
 		{
 			// Pindahkan route transaksi ke dalam grup yang dilindungi
 			protected.POST("/transactions", transactionHandler.CreateTransaction)
+			protected.POST("/transactions/quote", transactionHandler.QuoteOrder)
 			protected.GET("/transactions", transactionHandler.GetTransactions)
+			protected.GET("/transactions/:id", transactionHandler.GetTransaction)
+			protected.GET("/transactions/:id/events", transactionHandler.WatchTransaction)
+			protected.POST("/transactions/:id/cancel", transactionHandler.CancelTransaction)
+			protected.GET("/cart", cartHandler.GetCart)
+			protected.POST("/cart/items", cartHandler.AddCartItem)
+			protected.PUT("/cart/items/:book_id", cartHandler.UpdateCartItem)
+			protected.DELETE("/cart/items/:book_id", cartHandler.RemoveCartItem)
+			protected.POST("/cart/checkout", cartHandler.CheckoutCart)
 			protected.GET("/wallet/balance", walletHandler.GetBalance)
 			protected.POST("/wallet/topup", walletHandler.TopUp)
+			protected.GET("/wallet/topups", walletHandler.ListTopUps)
+			protected.GET("/wallet/topups/:id", walletHandler.GetTopUp)
+			protected.GET("/wallet/ledger", walletHandler.GetLedger)
+			protected.POST("/wallet/transfer", walletHandler.Transfer)
+			protected.GET("/wallet/transfers", walletHandler.ListTransfers)
+			protected.GET("/wallet/limits", walletHandler.GetSpendingLimits)
+			protected.PUT("/wallet/limits", walletHandler.SetSpendingLimits)
 			protected.POST("/gifts", giftingHandler.SendGift)
+			protected.POST("/gifts/:id/accept", giftingHandler.AcceptGift)
+			protected.GET("/library", libraryHandler.GetLibrary)
 			
 			// --- ROUTE KHUSUS ADMIN ---
 			// Anda bisa membuat middleware baru untuk memeriksa role 'admin'
@@ -47,6 +73,20 @@ func SetupRoutes(
 				admin.POST("/books", bookHandler.CreateBook)
 				admin.PUT("/books/:id", bookHandler.UpdateBook)
 				admin.DELETE("/books/:id", bookHandler.DeleteBook)
+				admin.GET("/wallets/:user_id/ledger", walletHandler.GetUserLedger)
+				admin.GET("/wallets/:user_id/limits", walletHandler.GetUserSpendingLimits)
+				admin.PUT("/wallets/:user_id/limits", walletHandler.SetUserSpendingLimits)
+				admin.POST("/coupons", couponHandler.CreateCoupon)
+				admin.GET("/coupons", couponHandler.ListCoupons)
+				admin.GET("/coupons/:id", couponHandler.GetCoupon)
+				admin.PUT("/coupons/:id", couponHandler.UpdateCoupon)
+				admin.DELETE("/coupons/:id", couponHandler.DeleteCoupon)
+				admin.GET("/reports/revenue", reportHandler.GetRevenueReport)
+				admin.GET("/reports/top-books", reportHandler.GetTopBooksReport)
+				admin.GET("/reports/categories", reportHandler.GetCategoryReport)
+				admin.POST("/reconciliation/runs", reconciliationHandler.RunReconciliation)
+				admin.GET("/reconciliation/runs", reconciliationHandler.ListReconciliationRuns)
+				admin.GET("/reconciliation/runs/:id", reconciliationHandler.GetReconciliationRun)
 			}
 		}
 	}
@@ -11,10 +11,12 @@ import (
 	"log"
 	"net"
 	"os"
+	transaction_pb "transaction-service/proto"
 
 	"github.com/joho/godotenv"
 	"github.com/robfig/cron/v3"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -25,10 +27,14 @@ func main() {
 	dbURL := os.Getenv("DATABASE_URL")
 	grpcPort := os.Getenv("GRPC_PORT")
 	bookServiceURL := os.Getenv("BOOK_SERVICE_URL")
+	transactionServiceURL := os.Getenv("TRANSACTION_SERVICE_URL")
 
 	if grpcPort == "" {
 		grpcPort = "50054"
 	}
+	if transactionServiceURL == "" {
+		log.Fatal("TRANSACTION_SERVICE_URL is not set")
+	}
 
 	// Koneksi Database
 	db, err := gorm.Open(postgres.Open(dbURL), &gorm.Config{})
@@ -37,10 +43,18 @@ func main() {
 	}
 	db.AutoMigrate(&model.EbookGiftLog{})
 
+	// Koneksi KLIEN ke transaction-service untuk mencatat hadiah yang diterima ke library
+	transactionConn, err := grpc.Dial(transactionServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Did not connect to transaction-service: %v", err)
+	}
+	defer transactionConn.Close()
+
 	// Inisialisasi Dependensi
 	bookClient := client.NewBookServiceClient(bookServiceURL)
+	libraryClient := transaction_pb.NewTransactionServiceClient(transactionConn)
 	repo := repository.NewGormRepository(db)
-	svc := service.NewGiftingService(repo, bookClient)
+	svc := service.NewGiftingService(repo, bookClient, libraryClient)
 	grpcServer := server.NewGrpcServer(svc)
 
 	// Setup dan Jalankan Scheduller
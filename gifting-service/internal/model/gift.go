@@ -1,19 +1,27 @@
 package model
 
 import (
-	"time"
 	"gorm.io/gorm"
+	"time"
+)
+
+// Status yang mungkin dimiliki sebuah hadiah.
+const (
+	GiftStatusPending  = "pending"
+	GiftStatusAccepted = "accepted"
+	GiftStatusExpired  = "expired"
 )
 
 type EbookGiftLog struct {
-	GiftID          uint           `gorm:"primaryKey"`
-	DonorID         uint           `gorm:"not null"`
-	RecipientEmail  string         `gorm:"type:varchar(100);not null"`
-	BookID          uint           `gorm:"not null"`
-	Message         string         `gorm:"type:text"`
-	Status          string         `gorm:"type:varchar(50);default:'pending'"`
-	RecipientUserID *uint          // Pointer ke uint agar bisa NULL
+	GiftID          uint   `gorm:"primaryKey"`
+	DonorID         uint   `gorm:"not null"`
+	RecipientEmail  string `gorm:"type:varchar(100);not null"`
+	BookID          string `gorm:"type:varchar(255);not null"` // ID buku di book-service (ObjectID)
+	Message         string `gorm:"type:text"`
+	Status          string `gorm:"type:varchar(50);default:'pending'"`
+	RecipientUserID *uint  // Pointer ke uint agar bisa NULL
+	AcceptedAt      *time.Time
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	DeletedAt       gorm.DeletedAt `gorm:"index"`
-}
\ No newline at end of file
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"gifting-service/internal/model"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 type GiftingRepository interface {
 	CreateGift(ctx context.Context, gift *model.EbookGiftLog) (*model.EbookGiftLog, error)
 	ExpiredOldGifts(ctx context.Context, days int) (int64, error)
+	GetGift(ctx context.Context, id uint) (*model.EbookGiftLog, error)
+	AcceptGift(ctx context.Context, id uint, recipientUserID uint, acceptedAt time.Time) (bool, error)
 }
 
 type gormRepository struct {
@@ -27,7 +30,33 @@ func (r *gormRepository) CreateGift(ctx context.Context, gift *model.EbookGiftLo
 }
 
 func (r *gormRepository) ExpiredOldGifts(ctx context.Context, days int) (int64, error) {
-	result := r.db.WithContext(ctx).Model(&model.EbookGiftLog{}).Where("status=?", "pending").Where("created_at < ?", time.Now().AddDate(0, 0, -days)).Update("status", "expired")
+	result := r.db.WithContext(ctx).Model(&model.EbookGiftLog{}).Where("status=?", model.GiftStatusPending).Where("created_at < ?", time.Now().AddDate(0, 0, -days)).Update("status", model.GiftStatusExpired)
 
 	return result.RowsAffected, result.Error
-}
\ No newline at end of file
+}
+
+// GetGift mengambil satu hadiah. Mengembalikan (nil, nil) jika hadiah tidak ditemukan.
+func (r *gormRepository) GetGift(ctx context.Context, id uint) (*model.EbookGiftLog, error) {
+	var gift model.EbookGiftLog
+	err := r.db.WithContext(ctx).First(&gift, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &gift, nil
+}
+
+// AcceptGift menandai hadiah pending sebagai accepted oleh recipientUserID.
+// Nilai bool bernilai false jika hadiah sudah tidak pending (mis. baru saja kedaluwarsa).
+func (r *gormRepository) AcceptGift(ctx context.Context, id uint, recipientUserID uint, acceptedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.EbookGiftLog{}).
+		Where("gift_id = ? AND status = ?", id, model.GiftStatusPending).
+		Updates(map[string]interface{}{
+			"status":            model.GiftStatusAccepted,
+			"recipient_user_id": recipientUserID,
+			"accepted_at":       acceptedAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"gifting-service/internal/model"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -22,4 +23,17 @@ func (m *MockGiftingRepository) CreateGift(ctx context.Context, gift *model.Eboo
 func (m *MockGiftingRepository) ExpiredOldGifts(ctx context.Context, days int) (int64, error) {
 	args := m.Called(ctx, days)
 	return args.Get(0).(int64), args.Error(1)
-}
\ No newline at end of file
+}
+
+func (m *MockGiftingRepository) GetGift(ctx context.Context, id uint) (*model.EbookGiftLog, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.EbookGiftLog), args.Error(1)
+}
+
+func (m *MockGiftingRepository) AcceptGift(ctx context.Context, id uint, recipientUserID uint, acceptedAt time.Time) (bool, error) {
+	args := m.Called(ctx, id, recipientUserID, acceptedAt)
+	return args.Bool(0), args.Error(1)
+}
@@ -2,9 +2,12 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	pb "gifting-service/proto"
 	"gifting-service/internal/service"
+	pb "gifting-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -27,8 +30,41 @@ func (s *GrpcServer) SendGift(ctx context.Context, req *pb.SendGiftRequest) (*pb
 		GiftId:         fmt.Sprintf("%d", gift.GiftID),
 		DonorId:        fmt.Sprintf("%d", gift.DonorID),
 		RecipientEmail: gift.RecipientEmail,
-		BookId:         fmt.Sprintf("%d", gift.BookID),
+		BookId:         gift.BookID,
 		Status:         gift.Status,
 		GiftDate:       timestamppb.New(gift.CreatedAt),
 	}, nil
-}
\ No newline at end of file
+}
+
+// AcceptGift menerima hadiah atas nama penerima dan mencatat bukunya ke library.
+func (s *GrpcServer) AcceptGift(ctx context.Context, req *pb.AcceptGiftRequest) (*pb.AcceptGiftResponse, error) {
+	gift, err := s.giftingService.AcceptGift(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+
+	response := &pb.AcceptGiftResponse{
+		GiftId:          fmt.Sprintf("%d", gift.GiftID),
+		BookId:          gift.BookID,
+		RecipientUserId: req.UserId,
+		Status:          gift.Status,
+	}
+	if gift.AcceptedAt != nil {
+		response.AcceptedAt = timestamppb.New(*gift.AcceptedAt)
+	}
+	return response, nil
+}
+
+// toGrpcError menerjemahkan error bisnis dari service ke kode gRPC.
+func toGrpcError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrGiftNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotGiftRecipient):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrGiftNotAcceptable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
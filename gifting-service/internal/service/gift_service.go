@@ -3,26 +3,39 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"gifting-service/internal/model"
 	"gifting-service/internal/repository"
 	"gifting-service/pkg/client" // HTTP client ke book-service
 	pb "gifting-service/proto"
 	"log"
 	"strconv"
+	"strings"
+	"time"
+	transaction_pb "transaction-service/proto"
+)
+
+// Error yang dikembalikan saat menerima hadiah.
+var (
+	ErrGiftNotFound      = errors.New("gift not found")
+	ErrNotGiftRecipient  = errors.New("gift is addressed to another email")
+	ErrGiftNotAcceptable = errors.New("gift is no longer pending")
 )
 
 type GiftingService interface {
 	SendGift(ctx context.Context, req *pb.SendGiftRequest) (*model.EbookGiftLog, error)
+	AcceptGift(ctx context.Context, req *pb.AcceptGiftRequest) (*model.EbookGiftLog, error)
 	ExpiredOldGifts(ctx context.Context)
 }
 
 type giftingService struct {
-	repo       repository.GiftingRepository
-	bookClient client.BookServiceClient
+	repo          repository.GiftingRepository
+	bookClient    client.BookServiceClient
+	libraryClient client.LibraryClient
 }
 
-func NewGiftingService(repo repository.GiftingRepository, bookClient client.BookServiceClient) GiftingService {
-	return &giftingService{repo: repo, bookClient: bookClient}
+func NewGiftingService(repo repository.GiftingRepository, bookClient client.BookServiceClient, libraryClient client.LibraryClient) GiftingService {
+	return &giftingService{repo: repo, bookClient: bookClient, libraryClient: libraryClient}
 }
 
 func (s *giftingService) SendGift(ctx context.Context, req *pb.SendGiftRequest) (*model.EbookGiftLog, error) {
@@ -37,21 +50,78 @@ func (s *giftingService) SendGift(ctx context.Context, req *pb.SendGiftRequest)
 	}
 
 	donorID, _ := strconv.ParseUint(req.DonorId, 10, 32)
-	bookID, _ := strconv.ParseUint(req.BookId, 10, 32)
 
 	// 2. Buat entitas hadiah
 	gift := &model.EbookGiftLog{
 		DonorID:        uint(donorID),
 		RecipientEmail: req.RecipientEmail,
-		BookID:         uint(bookID),
+		BookID:         req.BookId,
 		Message:        req.Message,
-		Status:         "pending",
+		Status:         model.GiftStatusPending,
 	}
 
 	// 3. Simpan ke database
 	return s.repo.CreateGift(ctx, gift)
 }
 
+// AcceptGift dipanggil penerima untuk menerima hadiah yang dikirim ke email-nya. Hadiah
+// ditandai accepted lebih dulu, lalu bukunya dicatat ke library penerima. Jika pencatatan
+// gagal, penerima yang sama bisa memanggil ulang karena pencatatan di library idempoten.
+func (s *giftingService) AcceptGift(ctx context.Context, req *pb.AcceptGiftRequest) (*model.EbookGiftLog, error) {
+	giftID, err := strconv.ParseUint(req.GiftId, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid gift id format")
+	}
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid user id format")
+	}
+
+	gift, err := s.repo.GetGift(ctx, uint(giftID))
+	if err != nil {
+		return nil, err
+	}
+	if gift == nil {
+		return nil, ErrGiftNotFound
+	}
+	if !strings.EqualFold(gift.RecipientEmail, req.UserEmail) {
+		return nil, ErrNotGiftRecipient
+	}
+
+	switch {
+	case gift.Status == model.GiftStatusPending:
+		now := time.Now()
+		accepted, err := s.repo.AcceptGift(ctx, gift.GiftID, uint(userID), now)
+		if err != nil {
+			return nil, err
+		}
+		if !accepted {
+			// Hadiah kedaluwarsa atau diterima di antara pembacaan dan update
+			return nil, ErrGiftNotAcceptable
+		}
+		recipientUserID := uint(userID)
+		gift.Status = model.GiftStatusAccepted
+		gift.RecipientUserID = &recipientUserID
+		gift.AcceptedAt = &now
+	case gift.Status == model.GiftStatusAccepted && gift.RecipientUserID != nil && *gift.RecipientUserID == uint(userID):
+		// Penerimaan ulang, pastikan buku sudah tercatat di library
+	default:
+		return nil, ErrGiftNotAcceptable
+	}
+
+	_, err = s.libraryClient.GrantEntitlement(ctx, &transaction_pb.GrantEntitlementRequest{
+		UserId:      req.UserId,
+		BookId:      gift.BookID,
+		Source:      "gift",
+		ReferenceId: fmt.Sprintf("gift-%d", gift.GiftID),
+	})
+	if err != nil {
+		log.Printf("Failed to add gift %d to library of user %d: %v", gift.GiftID, userID, err)
+		return nil, fmt.Errorf("failed to add gift to library: %w", err)
+	}
+	return gift, nil
+}
+
 // Scheduller (CronJob)
 func (s *giftingService) ExpiredOldGifts(ctx context.Context) {
 	log.Println("Scheduler running: Expiring old gifts...")
@@ -8,6 +8,7 @@ import (
 	"gifting-service/pkg/client"
 	pb "gifting-service/proto"
 	"testing"
+	transaction_pb "transaction-service/proto"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -37,7 +38,7 @@ func TestSendGift_Success(t *testing.T) {
 	mockRepo.On("CreateGift", mock.Anything, mock.AnythingOfType("*model.EbookGiftLog")).Return(mockGift, nil)
 
 	// 4. Buat instance service dengan mock
-	giftingService := NewGiftingService(mockRepo, mockBookClient)
+	giftingService := NewGiftingService(mockRepo, mockBookClient, nil)
 
 	// --- Act ---
 	result, err := giftingService.SendGift(context.Background(), req)
@@ -62,7 +63,7 @@ func TestSendGift_BookNotForDonation(t *testing.T) {
 	mockBook := &client.BookDTO{ID: "102", IsDonationOnly: true}
 	mockBookClient.On("GetBookByID", mock.Anything, req.BookId).Return(mockBook, nil)
 
-	giftingService := NewGiftingService(mockRepo, mockBookClient)
+	giftingService := NewGiftingService(mockRepo, mockBookClient, nil)
 
 	// --- Act ---
 	result, err := giftingService.SendGift(context.Background(), req)
@@ -86,7 +87,7 @@ func TestSendGift_BookNotFound(t *testing.T) {
 	// Program mock agar GetBookByID mengembalikan error
 	mockBookClient.On("GetBookByID", mock.Anything, req.BookId).Return(nil, errors.New("not found"))
 
-	giftingService := NewGiftingService(mockRepo, mockBookClient)
+	giftingService := NewGiftingService(mockRepo, mockBookClient, nil)
 
 	// --- Act ---
 	result, err := giftingService.SendGift(context.Background(), req)
@@ -97,3 +98,88 @@ func TestSendGift_BookNotFound(t *testing.T) {
 	assert.Equal(t, "book not found", err.Error())
 	mockBookClient.AssertExpectations(t)
 }
+
+// Skenario 4: Tes penerima menerima hadiah pending dan bukunya dicatat ke library
+func TestAcceptGift_Success(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockGiftingRepository)
+	mockLibraryClient := new(client.MockLibraryClient)
+
+	gift := &model.EbookGiftLog{GiftID: 7, RecipientEmail: "Penerima@example.com", BookID: "64f0c2a1b2", Status: "pending"}
+	mockRepo.On("GetGift", mock.Anything, uint(7)).Return(gift, nil)
+	mockRepo.On("AcceptGift", mock.Anything, uint(7), uint(5), mock.AnythingOfType("time.Time")).Return(true, nil)
+	mockLibraryClient.On("GrantEntitlement", mock.Anything, mock.MatchedBy(func(req *transaction_pb.GrantEntitlementRequest) bool {
+		return req.UserId == "5" && req.BookId == "64f0c2a1b2" && req.Source == "gift" && req.ReferenceId == "gift-7"
+	})).Return(&transaction_pb.Entitlement{UserId: "5", BookId: "64f0c2a1b2"}, nil)
+
+	giftingService := NewGiftingService(mockRepo, nil, mockLibraryClient)
+
+	// --- Act ---
+	result, err := giftingService.AcceptGift(context.Background(), &pb.AcceptGiftRequest{
+		GiftId: "7", UserId: "5", UserEmail: "penerima@example.com",
+	})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, "accepted", result.Status)
+	assert.Equal(t, uint(5), *result.RecipientUserID)
+	assert.NotNil(t, result.AcceptedAt)
+	mockRepo.AssertExpectations(t)
+	mockLibraryClient.AssertExpectations(t)
+}
+
+// Skenario 5: Tes hadiah tidak bisa diterima oleh email lain
+func TestAcceptGift_NotRecipient(t *testing.T) {
+	mockRepo := new(repository.MockGiftingRepository)
+	mockLibraryClient := new(client.MockLibraryClient)
+	mockRepo.On("GetGift", mock.Anything, uint(7)).Return(&model.EbookGiftLog{GiftID: 7, RecipientEmail: "penerima@example.com", Status: "pending"}, nil)
+
+	giftingService := NewGiftingService(mockRepo, nil, mockLibraryClient)
+
+	_, err := giftingService.AcceptGift(context.Background(), &pb.AcceptGiftRequest{GiftId: "7", UserId: "6", UserEmail: "lain@example.com"})
+
+	assert.ErrorIs(t, err, ErrNotGiftRecipient)
+	mockRepo.AssertNotCalled(t, "AcceptGift", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockLibraryClient.AssertNotCalled(t, "GrantEntitlement", mock.Anything, mock.Anything)
+}
+
+// Skenario 6: Tes hadiah yang sudah kedaluwarsa tidak bisa diterima
+func TestAcceptGift_Expired(t *testing.T) {
+	mockRepo := new(repository.MockGiftingRepository)
+	mockRepo.On("GetGift", mock.Anything, uint(7)).Return(&model.EbookGiftLog{GiftID: 7, RecipientEmail: "penerima@example.com", Status: "expired"}, nil)
+
+	giftingService := NewGiftingService(mockRepo, nil, nil)
+
+	_, err := giftingService.AcceptGift(context.Background(), &pb.AcceptGiftRequest{GiftId: "7", UserId: "5", UserEmail: "penerima@example.com"})
+
+	assert.ErrorIs(t, err, ErrGiftNotAcceptable)
+}
+
+// Skenario 7: Tes penerimaan ulang oleh penerima yang sama mencatat ulang ke library,
+// sehingga kegagalan pencatatan sebelumnya bisa dipulihkan
+func TestAcceptGift_RetryAfterLibraryFailure(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockGiftingRepository)
+	mockLibraryClient := new(client.MockLibraryClient)
+
+	recipientUserID := uint(5)
+	mockRepo.On("GetGift", mock.Anything, uint(7)).Return(&model.EbookGiftLog{
+		GiftID: 7, RecipientEmail: "penerima@example.com", BookID: "64f0c2a1b2", Status: "accepted", RecipientUserID: &recipientUserID,
+	}, nil)
+	mockLibraryClient.On("GrantEntitlement", mock.Anything, mock.Anything).Return(nil, errors.New("transaction-service unavailable")).Once()
+	mockLibraryClient.On("GrantEntitlement", mock.Anything, mock.Anything).Return(&transaction_pb.Entitlement{UserId: "5"}, nil).Once()
+
+	giftingService := NewGiftingService(mockRepo, nil, mockLibraryClient)
+	req := &pb.AcceptGiftRequest{GiftId: "7", UserId: "5", UserEmail: "penerima@example.com"}
+
+	// --- Act ---
+	_, firstErr := giftingService.AcceptGift(context.Background(), req)
+	result, err := giftingService.AcceptGift(context.Background(), req)
+
+	// --- Assert ---
+	assert.Error(t, firstErr)
+	assert.NoError(t, err)
+	assert.Equal(t, "accepted", result.Status)
+	mockRepo.AssertNotCalled(t, "AcceptGift", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockLibraryClient.AssertExpectations(t)
+}
@@ -5,17 +5,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"shared/money"
 	"time"
 )
 
 // BookDTO adalah representasi data buku dari REST API book-service.
 // Pastikan field IsDonationOnly ada di sini.
 type BookDTO struct {
-	ID             string  `json:"id"`
-	Title          string  `json:"title"`
-	Price          float64 `json:"price"`
-	Status         string  `json:"status"`
-	IsDonationOnly bool    `json:"is_donation_only"`
+	ID             string      `json:"id"`
+	Title          string      `json:"title"`
+	Price          money.Money `json:"price"`
+	Status         string      `json:"status"`
+	IsDonationOnly bool        `json:"is_donation_only"`
 }
 
 // BookServiceClient adalah interface untuk klien HTTP ke book-service.
@@ -0,0 +1,14 @@
+package client
+
+import (
+	"context"
+	transaction_pb "transaction-service/proto"
+
+	"google.golang.org/grpc"
+)
+
+// LibraryClient adalah bagian dari TransactionServiceClient yang dipakai gifting-service
+// untuk mencatat buku hadiah ke library penerima di transaction-service.
+type LibraryClient interface {
+	GrantEntitlement(ctx context.Context, in *transaction_pb.GrantEntitlementRequest, opts ...grpc.CallOption) (*transaction_pb.Entitlement, error)
+}
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"context"
+	transaction_pb "transaction-service/proto"
+
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+)
+
+// MockLibraryClient adalah implementasi mock dari LibraryClient.
+type MockLibraryClient struct {
+	mock.Mock
+}
+
+func (m *MockLibraryClient) GrantEntitlement(ctx context.Context, in *transaction_pb.GrantEntitlementRequest, opts ...grpc.CallOption) (*transaction_pb.Entitlement, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*transaction_pb.Entitlement), args.Error(1)
+}
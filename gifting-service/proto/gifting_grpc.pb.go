@@ -24,6 +24,8 @@ const _ = grpc.SupportPackageIsVersion7
 type GiftingServiceClient interface {
 	// Mengirim hadiah buku dari donor ke penerima
 	SendGift(ctx context.Context, in *SendGiftRequest, opts ...grpc.CallOption) (*SendGiftResponse, error)
+	// Penerima menerima hadiah; buku dicatat ke library penerima di transaction-service
+	AcceptGift(ctx context.Context, in *AcceptGiftRequest, opts ...grpc.CallOption) (*AcceptGiftResponse, error)
 }
 
 type giftingServiceClient struct {
@@ -43,12 +45,23 @@ func (c *giftingServiceClient) SendGift(ctx context.Context, in *SendGiftRequest
 	return out, nil
 }
 
+func (c *giftingServiceClient) AcceptGift(ctx context.Context, in *AcceptGiftRequest, opts ...grpc.CallOption) (*AcceptGiftResponse, error) {
+	out := new(AcceptGiftResponse)
+	err := c.cc.Invoke(ctx, "/gifting.GiftingService/AcceptGift", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GiftingServiceServer is the server API for GiftingService service.
 // All implementations must embed UnimplementedGiftingServiceServer
 // for forward compatibility
 type GiftingServiceServer interface {
 	// Mengirim hadiah buku dari donor ke penerima
 	SendGift(context.Context, *SendGiftRequest) (*SendGiftResponse, error)
+	// Penerima menerima hadiah; buku dicatat ke library penerima di transaction-service
+	AcceptGift(context.Context, *AcceptGiftRequest) (*AcceptGiftResponse, error)
 	mustEmbedUnimplementedGiftingServiceServer()
 }
 
@@ -59,6 +72,9 @@ type UnimplementedGiftingServiceServer struct {
 func (UnimplementedGiftingServiceServer) SendGift(context.Context, *SendGiftRequest) (*SendGiftResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendGift not implemented")
 }
+func (UnimplementedGiftingServiceServer) AcceptGift(context.Context, *AcceptGiftRequest) (*AcceptGiftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptGift not implemented")
+}
 func (UnimplementedGiftingServiceServer) mustEmbedUnimplementedGiftingServiceServer() {}
 
 // UnsafeGiftingServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -90,6 +106,24 @@ func _GiftingService_SendGift_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GiftingService_AcceptGift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptGiftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiftingServiceServer).AcceptGift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gifting.GiftingService/AcceptGift",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiftingServiceServer).AcceptGift(ctx, req.(*AcceptGiftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GiftingService_ServiceDesc is the grpc.ServiceDesc for GiftingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -101,6 +135,10 @@ var GiftingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendGift",
 			Handler:    _GiftingService_SendGift_Handler,
 		},
+		{
+			MethodName: "AcceptGift",
+			Handler:    _GiftingService_AcceptGift_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "gifting-service/proto/gifting.proto",
@@ -0,0 +1,179 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"shared/money"
+	sharedpb "shared/proto"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Jenis event yang dikirim antar service.
+const (
+	TypeTransactionCreated = "transaction.created"
+	TypePaymentSucceeded   = "payment.succeeded"
+	TypePaymentFailed      = "payment.failed"
+)
+
+// SchemaVersion adalah versi skema payload yang dikirim oleh producer saat ini.
+const SchemaVersion = 1
+
+// supportedVersions berisi versi skema yang bisa dibaca consumer untuk setiap jenis event.
+var supportedVersions = map[string][]int32{
+	TypeTransactionCreated: {1},
+	TypePaymentSucceeded:   {1},
+	TypePaymentFailed:      {1},
+}
+
+// Header Kafka yang menyertai setiap event. Header hanya untuk routing dan tracing;
+// consumer tetap membaca jenis event dari envelope.
+const (
+	HeaderEventType     = "event-type"
+	HeaderCorrelationID = "correlation-id"
+	HeaderContentType   = "content-type"
+
+	// ContentType adalah content type envelope yang di-encode oleh Marshal.
+	ContentType = "application/json"
+)
+
+// Error yang dikembalikan Unmarshal. Event dengan error ini tidak akan berhasil
+// walaupun dicoba ulang, sehingga consumer sebaiknya menolaknya secara permanen.
+var (
+	ErrMalformedEvent     = errors.New("malformed event")
+	ErrUnknownEventType   = errors.New("unknown event type")
+	ErrUnsupportedVersion = errors.New("unsupported event schema version")
+)
+
+// NewTransactionCreated membungkus TransactionCreated dalam envelope baru.
+func NewTransactionCreated(producer string, payload *TransactionCreated) (*Envelope, error) {
+	return newEnvelope(producer, TypeTransactionCreated, &Envelope{Payload: &Envelope_TransactionCreated{TransactionCreated: payload}})
+}
+
+// NewPaymentSucceeded membungkus PaymentSucceeded dalam envelope baru.
+func NewPaymentSucceeded(producer string, payload *PaymentSucceeded) (*Envelope, error) {
+	return newEnvelope(producer, TypePaymentSucceeded, &Envelope{Payload: &Envelope_PaymentSucceeded{PaymentSucceeded: payload}})
+}
+
+// NewPaymentFailed membungkus PaymentFailed dalam envelope baru.
+func NewPaymentFailed(producer string, payload *PaymentFailed) (*Envelope, error) {
+	return newEnvelope(producer, TypePaymentFailed, &Envelope{Payload: &Envelope_PaymentFailed{PaymentFailed: payload}})
+}
+
+// Marshal mengubah envelope menjadi JSON protobuf. JSON dipakai (bukan biner) agar pesan
+// tetap terbaca di outbox, log, dan dead-letter.
+func Marshal(envelope *Envelope) ([]byte, error) {
+	if err := validate(envelope); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(envelope)
+}
+
+// Unmarshal membaca dan memvalidasi envelope. Jenis event yang tidak dikenal, versi skema
+// yang tidak didukung, atau payload yang tidak lengkap ditolak. Field yang tidak dikenal
+// diabaikan agar producer bisa menambah field opsional tanpa menaikkan versi skema;
+// kompatibilitas ditentukan oleh schema_version.
+func Unmarshal(data []byte) (*Envelope, error) {
+	var envelope Envelope
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedEvent, err)
+	}
+	if err := validate(&envelope); err != nil {
+		return nil, err
+	}
+	return &envelope, nil
+}
+
+// Headers mengembalikan header Kafka untuk envelope. correlationID menghubungkan semua
+// event dalam satu alur (misalnya ID transaksi) dan dihilangkan jika kosong.
+func Headers(envelope *Envelope, correlationID string) map[string]string {
+	headers := map[string]string{
+		HeaderEventType:   envelope.EventType,
+		HeaderContentType: ContentType,
+	}
+	if correlationID != "" {
+		headers[HeaderCorrelationID] = correlationID
+	}
+	return headers
+}
+
+func newEnvelope(producer, eventType string, envelope *Envelope) (*Envelope, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	envelope.EventId = hex.EncodeToString(id)
+	envelope.EventType = eventType
+	envelope.SchemaVersion = SchemaVersion
+	envelope.OccurredAt = timestamppb.Now()
+	envelope.Producer = producer
+	return envelope, nil
+}
+
+// validate memastikan metadata envelope lengkap, versinya didukung, dan payload sesuai event_type.
+func validate(envelope *Envelope) error {
+	if envelope.EventId == "" || envelope.Producer == "" || envelope.OccurredAt == nil {
+		return fmt.Errorf("%w: event_id, producer, and occurred_at are required", ErrMalformedEvent)
+	}
+
+	versions, ok := supportedVersions[envelope.EventType]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownEventType, envelope.EventType)
+	}
+	supported := false
+	for _, version := range versions {
+		supported = supported || version == envelope.SchemaVersion
+	}
+	if !supported {
+		return fmt.Errorf("%w: %s v%d", ErrUnsupportedVersion, envelope.EventType, envelope.SchemaVersion)
+	}
+
+	switch envelope.EventType {
+	case TypeTransactionCreated:
+		payload := envelope.GetTransactionCreated()
+		if payload == nil {
+			return payloadMismatch(envelope.EventType)
+		}
+		return requireFields(envelope.EventType, payload.TotalAmount,
+			"transaction_id", payload.TransactionId, "user_id", payload.UserId)
+	case TypePaymentSucceeded:
+		payload := envelope.GetPaymentSucceeded()
+		if payload == nil {
+			return payloadMismatch(envelope.EventType)
+		}
+		return requireFields(envelope.EventType, payload.Amount,
+			"transaction_id", payload.TransactionId, "user_id", payload.UserId)
+	default:
+		payload := envelope.GetPaymentFailed()
+		if payload == nil {
+			return payloadMismatch(envelope.EventType)
+		}
+		return requireFields(envelope.EventType, payload.Amount,
+			"transaction_id", payload.TransactionId, "user_id", payload.UserId, "failure_code", payload.FailureCode)
+	}
+}
+
+func payloadMismatch(eventType string) error {
+	return fmt.Errorf("%w: payload does not match event type %s", ErrMalformedEvent, eventType)
+}
+
+// requireFields memastikan nominal uang valid dan tidak negatif, serta setiap field teks
+// (pasangan nama dan nilai) tidak kosong.
+func requireFields(eventType string, amount *sharedpb.Money, fields ...string) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i+1] == "" {
+			return fmt.Errorf("%w: %s is missing %s", ErrMalformedEvent, eventType, fields[i])
+		}
+	}
+	if amount == nil {
+		return fmt.Errorf("%w: %s is missing amount", ErrMalformedEvent, eventType)
+	}
+	parsed, err := money.FromProto(amount)
+	if err != nil || parsed.IsNegative() {
+		return fmt.Errorf("%w: %s has an invalid amount", ErrMalformedEvent, eventType)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package events
+
+import (
+	"encoding/json"
+	"shared/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestMarshalUnmarshal_RoundTrip(t *testing.T) {
+	envelope, err := NewTransactionCreated("transaction-service", &TransactionCreated{
+		TransactionId: "99",
+		UserId:        "1",
+		TotalAmount:   money.FromRupiah(50000).ToProto(),
+		HoldId:        "7",
+	})
+	require.NoError(t, err)
+
+	data, err := Marshal(envelope)
+	require.NoError(t, err)
+
+	decoded, err := Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, TypeTransactionCreated, decoded.EventType)
+	assert.Equal(t, int32(SchemaVersion), decoded.SchemaVersion)
+	assert.Equal(t, "transaction-service", decoded.Producer)
+	assert.Equal(t, envelope.EventId, decoded.EventId)
+	assert.Equal(t, "7", decoded.GetTransactionCreated().HoldId)
+	assert.Equal(t, money.FromRupiah(50000).Minor(), decoded.GetTransactionCreated().TotalAmount.GetMinorUnits())
+}
+
+func TestUnmarshal_Rejects(t *testing.T) {
+	// encode menulis envelope tanpa validasi, seperti producer yang salah atau versi baru
+	encode := func(mutate func(*Envelope)) []byte {
+		envelope, _ := NewPaymentFailed("wallet-service", &PaymentFailed{
+			TransactionId: "99",
+			UserId:        "1",
+			Amount:        money.FromRupiah(50000).ToProto(),
+			Reason:        "insufficient funds",
+			FailureCode:   "insufficient_funds",
+		})
+		mutate(envelope)
+		data, _ := protojson.Marshal(envelope)
+		return data
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"not json", []byte(`{not json`), ErrMalformedEvent},
+		{"legacy payload without envelope", []byte(`{"transaction_id":"99","user_id":"1","total_amount":50000}`), ErrMalformedEvent},
+		{"unknown type", encode(func(e *Envelope) { e.EventType = "payment.refunded" }), ErrUnknownEventType},
+		{"unsupported version", encode(func(e *Envelope) { e.SchemaVersion = 2 }), ErrUnsupportedVersion},
+		{"payload does not match type", encode(func(e *Envelope) { e.EventType = TypePaymentSucceeded }), ErrMalformedEvent},
+		{"missing user", encode(func(e *Envelope) { e.GetPaymentFailed().UserId = "" }), ErrMalformedEvent},
+		{"missing event id", encode(func(e *Envelope) { e.EventId = "" }), ErrMalformedEvent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Unmarshal(tt.data)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestUnmarshal_UnknownFields(t *testing.T) {
+	// withExtraFields menambah field yang belum dikenal consumer ini di envelope dan payload,
+	// seperti producer yang sudah memakai versi proto yang lebih baru
+	withExtraFields := func(version int32) []byte {
+		envelope, err := NewPaymentSucceeded("wallet-service", &PaymentSucceeded{
+			TransactionId: "99",
+			UserId:        "1",
+			Amount:        money.FromRupiah(50000).ToProto(),
+		})
+		require.NoError(t, err)
+		envelope.SchemaVersion = version
+		data, err := protojson.Marshal(envelope)
+		require.NoError(t, err)
+
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &fields))
+		fields["traceId"] = "abc123"
+		fields["paymentSucceeded"].(map[string]interface{})["walletBalanceAfter"] = "150000"
+		data, err = json.Marshal(fields)
+		require.NoError(t, err)
+		return data
+	}
+
+	decoded, err := Unmarshal(withExtraFields(SchemaVersion))
+	require.NoError(t, err)
+	assert.Equal(t, "99", decoded.GetPaymentSucceeded().TransactionId)
+
+	_, err = Unmarshal(withExtraFields(2))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestHeaders(t *testing.T) {
+	envelope, err := NewPaymentFailed("wallet-service", &PaymentFailed{
+		TransactionId: "99",
+		UserId:        "1",
+		Amount:        money.FromRupiah(50000).ToProto(),
+		FailureCode:   "insufficient_funds",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		HeaderEventType:     TypePaymentFailed,
+		HeaderContentType:   ContentType,
+		HeaderCorrelationID: "99",
+	}, Headers(envelope, "99"))
+	assert.NotContains(t, Headers(envelope, ""), HeaderCorrelationID)
+}
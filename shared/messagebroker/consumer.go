@@ -0,0 +1,41 @@
+package messagebroker
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Consumer membaca pesan dari satu topic sebagai anggota consumer group. Pesan yang sudah
+// diambil dengan FetchMessage baru dianggap selesai setelah di-commit, sehingga pesan yang
+// belum di-commit akan dibaca ulang saat consumer dijalankan kembali. *kafka.Reader memenuhi
+// interface ini.
+type Consumer interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
+// Broker menyediakan producer dan consumer dari broker yang sama.
+type Broker interface {
+	Producer
+	Consumer(topic, groupID string) Consumer
+}
+
+type kafkaBroker struct {
+	Producer
+	brokerAddress string
+}
+
+// NewKafkaBroker adalah constructor untuk Broker yang terhubung ke cluster Kafka.
+func NewKafkaBroker(brokerAddress string) Broker {
+	return &kafkaBroker{Producer: NewKafkaProducer(brokerAddress), brokerAddress: brokerAddress}
+}
+
+func (b *kafkaBroker) Consumer(topic, groupID string) Consumer {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers: []string{b.brokerAddress},
+		Topic:   topic,
+		GroupID: groupID,
+	})
+}
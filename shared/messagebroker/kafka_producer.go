@@ -0,0 +1,70 @@
+// Package messagebroker membungkus pengiriman dan pembacaan pesan antar service.
+// Implementasi Kafka dipakai di production, sedangkan MemoryBroker menjalankan alur
+// yang sama di dalam satu proses untuk development lokal (MESSAGE_BROKER=memory) dan tes.
+package messagebroker
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/segmentio/kafka-go"
+	"time"
+)
+
+// Message adalah pesan yang dikirim ke Kafka. Pesan dengan Key yang sama selalu masuk
+// ke partisi yang sama sehingga urutannya terjaga; Key kosong dibagi rata ke semua partisi.
+type Message struct {
+	Topic   string
+	Key     string
+	Value   interface{} // Di-encode sebagai JSON; json.RawMessage dikirim apa adanya
+	Headers map[string]string
+}
+
+type Producer interface {
+	Publish(ctx context.Context, message Message) error
+}
+
+type kafkaProducer struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaProducer(brokerAddress string) Producer {
+	return &kafkaProducer{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokerAddress),
+			Balancer: &kafka.Hash{},
+		},
+	}
+}
+
+func (p *kafkaProducer) Publish(ctx context.Context, message Message) error {
+	kafkaMessage, err := toKafkaMessage(message)
+	if err != nil {
+		return err
+	}
+
+	// Menggunakan WriteMessages agar lebih tangguh
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return p.writer.WriteMessages(ctx, kafkaMessage)
+}
+
+// toKafkaMessage meng-encode Value sebagai JSON dan menyalin key serta header ke kafka.Message.
+func toKafkaMessage(message Message) (kafka.Message, error) {
+	jsonBody, err := json.Marshal(message.Value)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+
+	kafkaMessage := kafka.Message{
+		Topic: message.Topic,
+		Value: jsonBody,
+	}
+	if message.Key != "" {
+		kafkaMessage.Key = []byte(message.Key)
+	}
+	for key, value := range message.Headers {
+		kafkaMessage.Headers = append(kafkaMessage.Headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+	return kafkaMessage, nil
+}
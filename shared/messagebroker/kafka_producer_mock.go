@@ -9,7 +9,7 @@ type MockProducer struct {
 	mock.Mock
 }
 
-func (m *MockProducer) Publish(ctx context.Context, topic string, message interface{}) error {
-	args := m.Called(ctx, topic, message)
+func (m *MockProducer) Publish(ctx context.Context, message Message) error {
+	args := m.Called(ctx, message)
 	return args.Error(0)
 }
\ No newline at end of file
@@ -0,0 +1,128 @@
+package messagebroker
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// MemoryBroker adalah Broker di dalam memori untuk development lokal dan tes, sehingga
+// producer dan consumer dalam satu proses bisa berjalan tanpa Kafka dan Zookeeper. Setiap topic hanya
+// punya satu partisi (urutan semua pesan terjaga) dan pesan tidak pernah dihapus.
+//
+// Seperti Kafka, setiap consumer group menyimpan offset yang sudah di-commit; consumer baru
+// dari group yang sama melanjutkan dari offset tersebut. Satu group sebaiknya hanya punya
+// satu consumer aktif karena pesan tidak dibagi antar consumer.
+type MemoryBroker struct {
+	mu      sync.Mutex
+	topics  map[string][]kafka.Message
+	offsets map[string]int64 // Offset berikutnya yang belum di-commit, per topic dan group
+	notify  chan struct{}    // Ditutup dan diganti setiap ada pesan baru
+}
+
+// NewMemoryBroker adalah constructor untuk MemoryBroker kosong.
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		topics:  make(map[string][]kafka.Message),
+		offsets: make(map[string]int64),
+		notify:  make(chan struct{}),
+	}
+}
+
+// Publish menyimpan pesan di akhir topic dan membangunkan consumer yang sedang menunggu.
+func (b *MemoryBroker) Publish(ctx context.Context, message Message) error {
+	kafkaMessage, err := toKafkaMessage(message)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	kafkaMessage.Offset = int64(len(b.topics[message.Topic]))
+	kafkaMessage.Time = time.Now()
+	b.topics[message.Topic] = append(b.topics[message.Topic], kafkaMessage)
+	close(b.notify)
+	b.notify = make(chan struct{})
+	return nil
+}
+
+// Consumer membuat consumer untuk topic yang dimulai dari offset terakhir yang di-commit group.
+func (b *MemoryBroker) Consumer(topic, groupID string) Consumer {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &memoryConsumer{
+		broker:  b,
+		topic:   topic,
+		groupID: groupID,
+		next:    b.offsets[groupKey(topic, groupID)],
+		done:    make(chan struct{}),
+	}
+}
+
+func groupKey(topic, groupID string) string {
+	return groupID + "/" + topic
+}
+
+type memoryConsumer struct {
+	broker  *MemoryBroker
+	topic   string
+	groupID string
+
+	mu        sync.Mutex
+	next      int64 // Offset yang akan diambil FetchMessage berikutnya
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// FetchMessage menunggu sampai ada pesan berikutnya, ctx dibatalkan, atau consumer ditutup.
+// Seperti kafka.Reader, io.EOF dikembalikan setelah consumer ditutup.
+func (c *memoryConsumer) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for {
+		select {
+		case <-c.done:
+			return kafka.Message{}, io.EOF
+		default:
+		}
+
+		c.broker.mu.Lock()
+		messages, notify := c.broker.topics[c.topic], c.broker.notify
+		c.broker.mu.Unlock()
+
+		if c.next < int64(len(messages)) {
+			c.next++
+			return messages[c.next-1], nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return kafka.Message{}, ctx.Err()
+		case <-c.done:
+			return kafka.Message{}, io.EOF
+		case <-notify:
+		}
+	}
+}
+
+// CommitMessages menyimpan offset setelah pesan terakhir sebagai posisi group.
+func (c *memoryConsumer) CommitMessages(ctx context.Context, messages ...kafka.Message) error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	key := groupKey(c.topic, c.groupID)
+	for _, message := range messages {
+		if message.Offset+1 > c.broker.offsets[key] {
+			c.broker.offsets[key] = message.Offset + 1
+		}
+	}
+	return nil
+}
+
+func (c *memoryConsumer) Close() error {
+	c.closeOnce.Do(func() { close(c.done) })
+	return nil
+}
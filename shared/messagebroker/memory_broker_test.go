@@ -0,0 +1,101 @@
+package messagebroker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fetch(t *testing.T, consumer Consumer) (string, string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	m, err := consumer.FetchMessage(ctx)
+	require.NoError(t, err)
+	return string(m.Key), string(m.Value)
+}
+
+func TestMemoryBroker_DeliversInOrderWithKeyAndHeaders(t *testing.T) {
+	broker := NewMemoryBroker()
+	consumer := broker.Consumer("transaction_created", "wallet")
+
+	require.NoError(t, broker.Publish(context.Background(), Message{
+		Topic:   "transaction_created",
+		Key:     "1",
+		Value:   json.RawMessage(`{"n":1}`),
+		Headers: map[string]string{"event-type": "transaction.created"},
+	}))
+	require.NoError(t, broker.Publish(context.Background(), Message{Topic: "transaction_created", Key: "2", Value: map[string]int{"n": 2}}))
+	require.NoError(t, broker.Publish(context.Background(), Message{Topic: "other", Value: "ignored"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	first, err := consumer.FetchMessage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(first.Key))
+	assert.Equal(t, `{"n":1}`, string(first.Value))
+	assert.Equal(t, int64(0), first.Offset)
+	require.Len(t, first.Headers, 1)
+	assert.Equal(t, "event-type", first.Headers[0].Key)
+
+	key, value := fetch(t, consumer)
+	assert.Equal(t, "2", key)
+	assert.Equal(t, `{"n":2}`, value)
+}
+
+func TestMemoryBroker_WaitsForNewMessages(t *testing.T) {
+	broker := NewMemoryBroker()
+	consumer := broker.Consumer("payment_success", "transaction")
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		broker.Publish(context.Background(), Message{Topic: "payment_success", Key: "1", Value: "paid"})
+	}()
+
+	key, value := fetch(t, consumer)
+	assert.Equal(t, "1", key)
+	assert.Equal(t, `"paid"`, value)
+}
+
+// Consumer baru dari group yang sama melanjutkan dari offset yang sudah di-commit,
+// sedangkan group lain membaca topic dari awal.
+func TestMemoryBroker_ResumesFromCommittedOffset(t *testing.T) {
+	broker := NewMemoryBroker()
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, broker.Publish(context.Background(), Message{Topic: "events", Key: key, Value: key}))
+	}
+
+	consumer := broker.Consumer("events", "group")
+	m, err := consumer.FetchMessage(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, consumer.CommitMessages(context.Background(), m))
+	fetch(t, consumer) // Diambil tapi tidak di-commit
+	require.NoError(t, consumer.Close())
+
+	key, _ := fetch(t, broker.Consumer("events", "group"))
+	assert.Equal(t, "b", key)
+
+	key, _ = fetch(t, broker.Consumer("events", "other-group"))
+	assert.Equal(t, "a", key)
+}
+
+func TestMemoryBroker_CloseAndCancelStopFetch(t *testing.T) {
+	broker := NewMemoryBroker()
+
+	consumer := broker.Consumer("events", "group")
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		consumer.Close()
+	}()
+	_, err := consumer.FetchMessage(context.Background())
+	assert.ErrorIs(t, err, io.EOF)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = broker.Consumer("events", "group").FetchMessage(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
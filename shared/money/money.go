@@ -0,0 +1,232 @@
+// Package money menyediakan tipe uang eksak yang dipakai bersama oleh semua service.
+//
+// Nominal disimpan sebagai bilangan bulat dalam satuan terkecil mata uang (sen untuk IDR),
+// sehingga penjumlahan dan perkalian tidak pernah mengalami pembulatan floating point.
+// Untuk kompatibilitas dengan klien lama, JSON tetap berupa angka desimal (mis. 15000.5).
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	sharedpb "shared/proto"
+)
+
+// Currency adalah kode ISO 4217 mata uang yang dipakai Booktopia.
+const Currency = "IDR"
+
+// Scale adalah jumlah digit desimal satuan terkecil IDR (1 rupiah = 100 sen).
+const Scale = 2
+
+const minorPerUnit = 100
+
+// ErrTooManyDecimals dikembalikan jika nominal memiliki lebih dari dua digit desimal.
+var ErrTooManyDecimals = errors.New("money: amount has more than 2 decimal places")
+
+// Money adalah nominal IDR dalam satuan terkecil (sen).
+type Money int64
+
+// FromMinor membuat Money dari nominal dalam satuan terkecil.
+func FromMinor(minor int64) Money {
+	return Money(minor)
+}
+
+// FromRupiah membuat Money dari nominal rupiah utuh.
+func FromRupiah(rupiah int64) Money {
+	return Money(rupiah * minorPerUnit)
+}
+
+// FromFloat mengonversi nominal float lama ke Money, dibulatkan ke sen terdekat
+// (setengah menjauhi nol). Hanya untuk jalur kompatibilitas data lama.
+func FromFloat(f float64) Money {
+	return Money(math.Round(f * minorPerUnit))
+}
+
+// isDigits melaporkan apakah s hanya berisi digit ASCII (string kosong dianggap valid).
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse membaca nominal desimal seperti "15000", "15000.5" atau "-250.75".
+// Nominal dengan lebih dari dua digit desimal ditolak agar tidak ada pembulatan diam-diam.
+func Parse(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("money: empty amount")
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("money: invalid amount %q", s)
+	}
+	// Tanda hanya boleh di depan; ParseInt sendiri menerima "+1" sehingga "5.+1" lolos
+	if !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("money: invalid amount %q", s)
+	}
+	if len(frac) > Scale {
+		// Angka nol di belakang tidak mengubah nilai
+		trimmed := strings.TrimRight(frac, "0")
+		if len(trimmed) > Scale {
+			return 0, ErrTooManyDecimals
+		}
+		frac = trimmed
+	}
+	frac += strings.Repeat("0", Scale-len(frac))
+	if whole == "" {
+		whole = "0"
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units < 0 {
+		return 0, fmt.Errorf("money: invalid amount %q", s)
+	}
+	cents, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil || cents < 0 {
+		return 0, fmt.Errorf("money: invalid amount %q", s)
+	}
+	if units > (math.MaxInt64-cents)/minorPerUnit {
+		return 0, fmt.Errorf("money: amount %q out of range", s)
+	}
+
+	minor := units*minorPerUnit + cents
+	if negative {
+		minor = -minor
+	}
+	return Money(minor), nil
+}
+
+// Minor mengembalikan nominal dalam satuan terkecil.
+func (m Money) Minor() int64 {
+	return int64(m)
+}
+
+// Float64 mengembalikan nominal sebagai float. Hanya untuk tampilan atau integrasi lama.
+func (m Money) Float64() float64 {
+	return float64(m) / minorPerUnit
+}
+
+// Mul mengalikan nominal dengan jumlah barang.
+func (m Money) Mul(quantity int64) Money {
+	return m * Money(quantity)
+}
+
+// IsPositive bernilai true jika nominal lebih dari nol.
+func (m Money) IsPositive() bool {
+	return m > 0
+}
+
+// IsNegative bernilai true jika nominal kurang dari nol.
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+// String mengembalikan nominal desimal dengan dua digit sen, mis. "15000.50".
+func (m Money) String() string {
+	minor := int64(m)
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+	}
+	abs := uint64(minor)
+	if minor < 0 {
+		abs = uint64(-minor)
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, abs/minorPerUnit, abs%minorPerUnit)
+}
+
+// MarshalJSON menulis nominal sebagai angka JSON tanpa nol berlebih (mis. 15000 atau 15000.5),
+// sama seperti bentuk float64 yang dipakai klien lama.
+func (m Money) MarshalJSON() ([]byte, error) {
+	s := m.String()
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	return []byte(s), nil
+}
+
+// UnmarshalJSON menerima angka JSON (15000.5) maupun string ("15000.50").
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value menyimpan nominal ke kolom decimal sebagai string desimal yang eksak.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan membaca nominal dari kolom decimal.
+func (m *Money) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case []byte:
+		parsed, err := Parse(string(v))
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case string:
+		parsed, err := Parse(v)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case int64:
+		*m = FromRupiah(v)
+		return nil
+	case float64:
+		*m = FromFloat(v)
+		return nil
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+}
+
+// ToProto mengubah Money menjadi pesan protobuf shared.Money.
+func (m Money) ToProto() *sharedpb.Money {
+	return &sharedpb.Money{CurrencyCode: Currency, MinorUnits: int64(m)}
+}
+
+// FromProto mengubah pesan protobuf menjadi Money. Pesan kosong dianggap nol,
+// kode mata uang kosong dianggap IDR, dan mata uang lain ditolak.
+func FromProto(p *sharedpb.Money) (Money, error) {
+	if p == nil {
+		return 0, nil
+	}
+	if p.CurrencyCode != "" && p.CurrencyCode != Currency {
+		return 0, fmt.Errorf("money: unsupported currency %q", p.CurrencyCode)
+	}
+	return Money(p.MinorUnits), nil
+}
@@ -0,0 +1,94 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	sharedpb "shared/proto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tes untuk Parse dengan berbagai bentuk nominal
+func TestParse(t *testing.T) {
+	cases := map[string]Money{
+		"15000":    1500000,
+		"15000.5":  1500050,
+		"15000.50": 1500050,
+		"0.1":      10,
+		"-250.75":  -25075,
+		"100.500":  10050,
+		".25":      25,
+	}
+	for input, expected := range cases {
+		got, err := Parse(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, got, input)
+	}
+
+	for _, input := range []string{"", "-", "abc", "1e5", "10.001", "1.2.3", "5.+1", "5.-1", "-+5", "+-5", "1_000"} {
+		_, err := Parse(input)
+		assert.Error(t, err, input)
+	}
+}
+
+// Tes bahwa penjumlahan tidak mengalami drift seperti float64
+func TestMoney_NoDrift(t *testing.T) {
+	var total Money
+	for i := 0; i < 10; i++ {
+		total += FromMinor(10) // 0.10
+	}
+	assert.Equal(t, "1.00", total.String())
+	assert.Equal(t, FromRupiah(45000), FromRupiah(15000).Mul(3))
+}
+
+// Tes JSON tetap berupa angka agar kompatibel dengan klien lama
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(map[string]Money{"a": 1500050, "b": 1500000, "c": -5})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":15000.5,"b":15000,"c":-0.05}`, string(data))
+
+	var req struct {
+		Amount Money `json:"amount"`
+		Price  Money `json:"price"`
+	}
+	err = json.Unmarshal([]byte(`{"amount": 50000.25, "price": "12000.10"}`), &req)
+	assert.NoError(t, err)
+	assert.Equal(t, Money(5000025), req.Amount)
+	assert.Equal(t, Money(1200010), req.Price)
+
+	err = json.Unmarshal([]byte(`{"amount": 0.30000000000000004}`), &req)
+	assert.ErrorIs(t, err, ErrTooManyDecimals)
+}
+
+// Tes untuk Value dan Scan pada kolom decimal
+func TestMoney_SQL(t *testing.T) {
+	value, err := Money(1500050).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "15000.50", value)
+
+	var m Money
+	assert.NoError(t, m.Scan([]byte("98765.43")))
+	assert.Equal(t, Money(9876543), m)
+	assert.NoError(t, m.Scan(float64(19.99)))
+	assert.Equal(t, Money(1999), m)
+	assert.Error(t, m.Scan(true))
+}
+
+// Tes konversi dari dan ke protobuf
+func TestMoney_Proto(t *testing.T) {
+	p := Money(1500050).ToProto()
+	assert.Equal(t, "IDR", p.CurrencyCode)
+	assert.Equal(t, int64(1500050), p.MinorUnits)
+
+	m, err := FromProto(p)
+	assert.NoError(t, err)
+	assert.Equal(t, Money(1500050), m)
+
+	m, err = FromProto(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Money(0), m)
+
+	_, err = FromProto(&sharedpb.Money{CurrencyCode: "USD", MinorUnits: 100})
+	assert.Error(t, err)
+}
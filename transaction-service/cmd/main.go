@@ -1,10 +1,12 @@
 package main
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"net"
 	"os"
+	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -13,12 +15,14 @@ import (
 	"gorm.io/driver/postgres" // 1. Ganti driver
 	"gorm.io/gorm"
 
+	"shared/messagebroker"
 	"transaction-service/internal/model" // 2. Import model untuk AutoMigrate
 	"transaction-service/internal/repository"
 	"transaction-service/internal/server"
 	"transaction-service/internal/service"
+	"transaction-service/internal/worker"
 	"transaction-service/pkg/client"
-	"transaction-service/pkg/messagebroker"
+	"transaction-service/pkg/quote"
 	pb "transaction-service/proto"
 	wallet_pb "wallet-service/proto"
 )
@@ -30,8 +34,7 @@ func main() {
 	grpcPort := os.Getenv("GRPC_PORT")
 	bookServiceURL := os.Getenv("BOOK_SERVICE_URL")
 	walletServiceURL := os.Getenv("WALLET_SERVICE_URL")
-	kafkaURL := os.Getenv("KAFKA_URL")
-	fmt.Println(kafkaURL)
+	quoteSecret := os.Getenv("QUOTE_SECRET")
 
 	if dbURL == "" {
 		log.Fatal("DATABASE_URL is not set")
@@ -42,22 +45,57 @@ func main() {
 	if walletServiceURL == "" {
 		log.Fatal("WALLET_SERVICE_URL is not set")
 	}
-	if kafkaURL == "" {
-		log.Fatal("KAFKA_URL is not set")
-	}
 	if grpcPort == "" {
 		grpcPort = "50052"
 	}
+	if quoteSecret == "" {
+		log.Fatal("QUOTE_SECRET is not set")
+	}
+	reconciliationHour := 2
+	if v := os.Getenv("RECONCILIATION_HOUR"); v != "" {
+		hour, err := strconv.Atoi(v)
+		if err != nil || hour < 0 || hour > 23 {
+			log.Fatalf("Invalid RECONCILIATION_HOUR: %q", v)
+		}
+		reconciliationHour = hour
+	}
+	quoteTTL := 15 * time.Minute
+	if v := os.Getenv("QUOTE_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil {
+			log.Fatalf("Invalid QUOTE_TTL: %v", err)
+		}
+		quoteTTL = ttl
+	}
+	// Wallet-service menolak hold yang berlaku lebih dari 24 jam
+	holdTTL := service.DefaultHoldTTL
+	if v := os.Getenv("PAYMENT_HOLD_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl < time.Second || ttl > 24*time.Hour {
+			log.Fatalf("Invalid PAYMENT_HOLD_TTL: %q (must be between 1s and 24h)", v)
+		}
+		holdTTL = ttl
+	}
 
 	// 3. Koneksi ke PostgreSQL menggunakan GORM
-	db, err := gorm.Open(postgres.Open(dbURL), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dbURL), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatalf("Unable to connect to database: %v", err)
 	}
 
 	// 4. Jalankan AutoMigrate
 	log.Println("Running migrations for transaction service...")
-	db.AutoMigrate(&model.Transaction{}, &model.TransactionDetail{})
+	db.AutoMigrate(&model.Transaction{}, &model.TransactionDetail{}, &model.OutboxEvent{}, &model.CartItem{},
+		&model.Coupon{}, &model.CouponRedemption{}, &model.ReconciliationRun{}, &model.ReconciliationMismatch{}, &model.Entitlement{}, &model.DataMigration{},
+		&model.DeadLetter{})
+
+	// Kode kupon dulu unik untuk semua baris termasuk yang sudah dihapus, sehingga kodenya tidak
+	// bisa dipakai ulang. Index itu diganti idx_coupons_code_active yang mengabaikan kupon terhapus.
+	if db.Migrator().HasIndex(&model.Coupon{}, "idx_coupons_code") {
+		if err := db.Migrator().DropIndex(&model.Coupon{}, "idx_coupons_code"); err != nil {
+			log.Printf("Failed to drop legacy coupon code index: %v", err)
+		}
+	}
 
 	// Koneksi KLIEN ke wallet-service
 	walletConn, err := grpc.Dial(walletServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -69,12 +107,42 @@ func main() {
 
 	// Inisialisasi dependensi
 	bookClient := client.NewBookServiceClient(bookServiceURL)
-	kafkaProducer := messagebroker.NewKafkaProducer(kafkaURL)
+	broker := newMessageBroker()
 
 	// 5. Gunakan GORM repository
 	repo := repository.NewGormRepository(db)
-	svc := service.NewTransactionService(repo, bookClient, walletClient, kafkaProducer)
-	grpcServer := server.NewGrpcServer(svc)
+	outboxRepo := repository.NewGormOutboxRepository(db)
+	quoteSigner := quote.NewSigner([]byte(quoteSecret), quoteTTL)
+	couponRepo := repository.NewGormCouponRepository(db)
+	svc := service.NewTransactionService(repo, bookClient, walletClient, quoteSigner, couponRepo, holdTTL)
+	cartSvc := service.NewCartService(repository.NewGormCartRepository(db), bookClient, svc)
+	couponSvc := service.NewCouponService(couponRepo)
+	reportSvc := service.NewReportService(repository.NewGormReportRepository(db))
+	reconciliationSvc := service.NewReconciliationService(repository.NewGormReconciliationRepository(db), walletClient)
+	libraryRepo := repository.NewGormLibraryRepository(db)
+	librarySvc := service.NewLibraryService(libraryRepo, bookClient)
+	grpcServer := server.NewGrpcServer(svc, cartSvc, couponSvc, reportSvc, reconciliationSvc, librarySvc)
+
+	// Catat ke library pembelian yang selesai sebelum library ada (sekali saja, lihat data_migrations)
+	if granted, applied, err := libraryRepo.BackfillPurchaseEntitlements(context.Background()); err != nil {
+		log.Printf("Failed to backfill library entitlements: %v", err)
+	} else if applied {
+		log.Printf("Backfilled %d library entitlements from completed transactions", granted)
+	}
+
+	// Jalankan consumer hasil pembayaran dari wallet-service
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deadLetterRepo := repository.NewGormDeadLetterRepository(db)
+	worker.StartPaymentConsumer(ctx, broker, worker.TopicPaymentSuccess, svc, deadLetterRepo)
+	worker.StartPaymentConsumer(ctx, broker, worker.TopicPaymentFailed, svc, deadLetterRepo)
+
+	// Jalankan outbox relay yang mengirim event transaksi ke broker
+	worker.StartOutboxRelay(ctx, outboxRepo, broker, time.Second)
+
+	// Jalankan rekonsiliasi transaksi dengan wallet setiap malam
+	worker.StartReconciliationScheduler(ctx, reconciliationSvc, reconciliationHour)
 
 	// Setup dan jalankan server gRPC
 	lis, err := net.Listen("tcp", ":"+grpcPort)
@@ -90,3 +158,23 @@ func main() {
 		log.Fatalf("Failed to serve gRPC: %v", err)
 	}
 }
+
+// newMessageBroker memilih broker dari MESSAGE_BROKER: "kafka" (default, butuh KAFKA_URL) atau
+// "memory" untuk menjalankan service tanpa Kafka dan Zookeeper. Broker memory hanya hidup di
+// dalam proses ini, sehingga event tidak sampai ke wallet-service yang berjalan di proses lain.
+func newMessageBroker() messagebroker.Broker {
+	switch name := os.Getenv("MESSAGE_BROKER"); name {
+	case "", "kafka":
+		kafkaURL := os.Getenv("KAFKA_URL")
+		if kafkaURL == "" {
+			log.Fatal("KAFKA_URL is not set")
+		}
+		return messagebroker.NewKafkaBroker(kafkaURL)
+	case "memory":
+		log.Println("Warning: using in-memory message broker, events are not shared with other processes")
+		return messagebroker.NewMemoryBroker()
+	default:
+		log.Fatalf("Unknown MESSAGE_BROKER %q", name)
+		return nil
+	}
+}
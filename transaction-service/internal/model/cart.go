@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"shared/money"
+)
+
+// CartItem merepresentasikan tabel 'cart_items'. Keranjang seorang user adalah semua
+// baris dengan user_id miliknya; satu buku hanya punya satu baris per user.
+type CartItem struct {
+	ID         uint        `gorm:"primaryKey"`
+	UserID     uint        `gorm:"not null;uniqueIndex:idx_cart_items_user_book,priority:1"`
+	BookID     string      `gorm:"type:varchar(255);not null;uniqueIndex:idx_cart_items_user_book,priority:2"`
+	Quantity   int         `gorm:"not null"`
+	PriceAtAdd money.Money `gorm:"type:decimal(10,2);not null"` // Harga buku saat terakhir ditambahkan
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
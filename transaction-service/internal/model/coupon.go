@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"shared/money"
+)
+
+// Tipe kupon diskon.
+const (
+	CouponTypePercentage = "percentage"
+	CouponTypeFixed      = "fixed"
+)
+
+// Coupon merepresentasikan tabel 'coupons'. Batasan buku dan kategori bersifat
+// gabungan: jika salah satu diisi, diskon hanya berlaku untuk buku yang cocok.
+type Coupon struct {
+	ID             uint        `gorm:"primaryKey"`
+	Code           string      `gorm:"type:varchar(50);not null;uniqueIndex:idx_coupons_code_active,where:deleted_at IS NULL"` // Unik di antara kupon yang belum dihapus
+	Type           string      `gorm:"type:varchar(20);not null"`
+	PercentOff     int         `gorm:"not null;default:0"`
+	AmountOff      money.Money `gorm:"type:decimal(12,2);not null;default:0"`
+	MinOrderAmount money.Money `gorm:"type:decimal(12,2);not null;default:0"`
+	BookIDs        []string    `gorm:"serializer:json"`
+	Categories     []string    `gorm:"serializer:json"`
+	UsageLimit     int         `gorm:"not null;default:0"` // 0 berarti tanpa batas
+	PerUserLimit   int         `gorm:"not null;default:0"` // 0 berarti tanpa batas
+	UsedCount      int         `gorm:"not null;default:0"`
+	StartsAt       *time.Time
+	EndsAt         *time.Time
+	Active         bool `gorm:"not null"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+// CouponRedemption merepresentasikan tabel 'coupon_redemptions', satu baris
+// untuk setiap transaksi yang memakai kupon.
+type CouponRedemption struct {
+	ID             uint        `gorm:"primaryKey"`
+	CouponID       uint        `gorm:"not null;index:idx_coupon_redemptions_user,priority:1"`
+	UserID         uint        `gorm:"not null;index:idx_coupon_redemptions_user,priority:2"`
+	TransactionID  uint        `gorm:"not null;uniqueIndex"`
+	DiscountAmount money.Money `gorm:"type:decimal(12,2);not null"`
+	CreatedAt      time.Time
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// DeadLetter merepresentasikan tabel 'dead_letters'.
+// Setiap baris menyimpan event hasil pembayaran yang tetap gagal diproses setelah percobaan
+// ulang dihabiskan, lengkap dengan payload asli dan error terakhir, agar bisa diperiksa manual.
+type DeadLetter struct {
+	ID        uint   `gorm:"primaryKey"`
+	Topic     string `gorm:"type:varchar(100);not null"` // Topic asal pesan
+	Partition int    `gorm:"not null"`
+	Offset    int64  `gorm:"not null"`
+	Key       string `gorm:"type:varchar(255)"`
+	Payload   []byte `gorm:"type:bytea;not null"`
+	Error     string `gorm:"type:text;not null"`
+	Attempts  int    `gorm:"not null"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Sumber entitlement.
+const (
+	EntitlementSourcePurchase = "purchase"
+	EntitlementSourceGift     = "gift"
+)
+
+// Entitlement merepresentasikan tabel 'entitlements', bukti bahwa user memiliki sebuah ebook.
+// User bisa punya beberapa entitlement untuk buku yang sama (mis. dibeli lalu diterima sebagai
+// hadiah); buku tetap dimiliki selama ada minimal satu entitlement. Pasangan reference_id dan
+// book_id unik sehingga pencatatan ulang dari sumber yang sama tidak membuat baris ganda.
+type Entitlement struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;index:idx_entitlements_user_book,priority:1"`
+	BookID      string `gorm:"type:varchar(255);not null;index:idx_entitlements_user_book,priority:2;uniqueIndex:idx_entitlements_reference_book,priority:2"`
+	Source      string `gorm:"type:varchar(20);not null"`
+	ReferenceID string `gorm:"type:varchar(100);not null;uniqueIndex:idx_entitlements_reference_book,priority:1"` // ID transaksi untuk purchase, "gift-<id>" untuk gift
+	CreatedAt   time.Time
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// DataMigration merepresentasikan tabel 'data_migrations', catatan migrasi data satu kali
+// (mis. backfill) yang sudah dijalankan. Migrasi yang namanya sudah tercatat tidak diulang.
+type DataMigration struct {
+	Name      string `gorm:"type:varchar(100);primaryKey"`
+	AppliedAt time.Time
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Status untuk baris outbox.
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+)
+
+// OutboxEvent merepresentasikan tabel 'outbox_events'.
+// Baris ini ditulis dalam transaction database yang sama dengan Transaction,
+// lalu dikirim ke Kafka oleh outbox relay sehingga tidak ada event yang hilang.
+type OutboxEvent struct {
+	ID            uint              `gorm:"primaryKey"`
+	Topic         string            `gorm:"type:varchar(100);not null"`
+	Key           string            `gorm:"type:varchar(100);index:idx_outbox_key"` // Partition key, mis. user_id
+	Headers       map[string]string `gorm:"type:jsonb;serializer:json"`
+	Payload       []byte            `gorm:"not null"`
+	Status        string            `gorm:"type:varchar(20);default:'pending';index:idx_outbox_pending,priority:1"`
+	Attempts      int               `gorm:"not null;default:0"`
+	LastError     string            `gorm:"type:text"`
+	NextAttemptAt time.Time         `gorm:"index:idx_outbox_pending,priority:2"`
+	SentAt        *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+
+	"shared/money"
+)
+
+// Status dan pemicu sebuah run rekonsiliasi.
+const (
+	ReconciliationStatusRunning   = "running"
+	ReconciliationStatusCompleted = "completed"
+	ReconciliationStatusFailed    = "failed"
+
+	ReconciliationTriggerScheduled = "scheduled"
+	ReconciliationTriggerManual    = "manual"
+)
+
+// Jenis ketidakcocokan antara transaksi dan pergerakan wallet.
+const (
+	MismatchMissingDebit     = "missing_debit"     // Transaksi completed/refunded tanpa debit
+	MismatchDoubleDebit      = "double_debit"      // Lebih dari satu debit untuk satu transaksi
+	MismatchAmountMismatch   = "amount_mismatch"   // Nominal debit/refund berbeda dari total transaksi
+	MismatchUnexpectedDebit  = "unexpected_debit"  // Transaksi cancelled sudah didebit tetapi belum di-refund
+	MismatchMissingRefund    = "missing_refund"    // Transaksi refunded tanpa refund di wallet
+	MismatchDoubleRefund     = "double_refund"     // Lebih dari satu refund untuk satu transaksi
+	MismatchUnexpectedRefund = "unexpected_refund" // Refund untuk transaksi yang statusnya tidak refunded
+	MismatchUserMismatch     = "user_mismatch"     // Pergerakan wallet milik user lain
+	MismatchStuckPending     = "stuck_pending"     // Transaksi masih pending jauh setelah dibuat
+)
+
+// ReconciliationRun merepresentasikan tabel 'reconciliation_runs'.
+// Satu run mencocokkan transaksi yang selesai dalam [WindowStart, WindowEnd) dengan
+// debit dan refund di wallet-service.
+type ReconciliationRun struct {
+	ID            uint      `gorm:"primaryKey"`
+	Trigger       string    `gorm:"type:varchar(20);not null"`
+	Status        string    `gorm:"type:varchar(20);not null;index"`
+	WindowStart   time.Time `gorm:"not null"`
+	WindowEnd     time.Time `gorm:"not null"`
+	CheckedCount  int       `gorm:"not null;default:0"`
+	MismatchCount int       `gorm:"not null;default:0"`
+	Error         string    `gorm:"type:text"`
+	StartedAt     time.Time `gorm:"not null"`
+	FinishedAt    *time.Time
+	CreatedAt     time.Time
+
+	Mismatches []ReconciliationMismatch `gorm:"foreignKey:RunID"`
+}
+
+// ReconciliationMismatch merepresentasikan tabel 'reconciliation_mismatches'.
+type ReconciliationMismatch struct {
+	ID                uint        `gorm:"primaryKey"`
+	RunID             uint        `gorm:"not null;index"`
+	TransactionID     uint        `gorm:"not null;index"`
+	UserID            uint        `gorm:"not null"`
+	TransactionStatus string      `gorm:"type:varchar(50);not null"`
+	Type              string      `gorm:"type:varchar(30);not null"`
+	ExpectedAmount    money.Money `gorm:"type:decimal(12,2);not null;default:0"`
+	ActualAmount      money.Money `gorm:"type:decimal(12,2);not null;default:0"`
+	Detail            string      `gorm:"type:text"`
+	CreatedAt         time.Time
+}
@@ -2,31 +2,49 @@ package model
 
 import (
 	"time"
+
 	"gorm.io/gorm"
+	"shared/money"
+)
+
+// Status yang mungkin dimiliki sebuah transaksi.
+const (
+	StatusPending   = "pending"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+	StatusRefunded  = "refunded" // Transaksi completed yang dibatalkan dan dananya sudah dikembalikan
 )
 
 // Transaction merepresentasikan tabel 'transactions' dengan GORM tags.
 type Transaction struct {
-	ID        uint           `gorm:"primaryKey"`
-	UserID    uint           `gorm:"not null"`
-	TotalAmount float64      `gorm:"type:decimal(12,2);not null"`
-	Status      string         `gorm:"type:varchar(50);default:'completed'"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
-	
+	ID             uint        `gorm:"primaryKey"`
+	UserID         uint        `gorm:"not null;index:idx_transactions_user_created,priority:1"`
+	TotalAmount    money.Money `gorm:"type:decimal(12,2);not null"`
+	Status         string      `gorm:"type:varchar(50);default:'completed'"`
+	FailureReason  string      `gorm:"type:text"`        // Alasan pembayaran gagal atau pembatalan, diisi saat status 'cancelled'/'refunded'
+	FailureCode    string      `gorm:"type:varchar(50)"` // Kode kegagalan pembayaran dari wallet-service, mis. 'daily_limit_exceeded'
+	ReservationID  string      `gorm:"type:varchar(64)"` // ID reservasi stok di book-service
+	HoldID         string      `gorm:"type:varchar(20)"` // ID hold dana di wallet-service, di-capture saat pembayaran diproses
+	CouponID       *uint       // Kupon yang dipakai, nil jika tanpa kupon
+	CouponCode     string      `gorm:"type:varchar(50)"`
+	DiscountAmount money.Money `gorm:"type:decimal(12,2);not null;default:0"` // Sudah dikurangkan dari TotalAmount
+	CreatedAt      time.Time   `gorm:"index:idx_transactions_user_created,priority:2"`
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+
 	// Mendefinisikan relasi: satu transaksi memiliki banyak detail
-	Details     []TransactionDetail `gorm:"foreignKey:TransactionID"`
+	Details []TransactionDetail `gorm:"foreignKey:TransactionID"`
 }
 
 // TransactionDetail merepresentasikan tabel 'transaction_details' dengan GORM tags.
 type TransactionDetail struct {
-	ID            uint           `gorm:"primaryKey"`
-	TransactionID uint           `gorm:"not null"`
-	BookID        string         `gorm:"type:varchar(255);not null"`
-	Quantity      int            `gorm:"not null"`
-	PricePerUnit  float64        `gorm:"type:decimal(10,2);not null"`
+	ID            uint        `gorm:"primaryKey"`
+	TransactionID uint        `gorm:"not null"`
+	BookID        string      `gorm:"type:varchar(255);not null"`
+	Category      string      `gorm:"type:varchar(100)"` // Kategori buku saat dibeli, untuk laporan penjualan
+	Quantity      int         `gorm:"not null"`
+	PricePerUnit  money.Money `gorm:"type:decimal(10,2);not null"`
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	DeletedAt     gorm.DeletedAt `gorm:"index"`
-}
\ No newline at end of file
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"transaction-service/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CartRepository adalah interface untuk operasi database keranjang belanja.
+type CartRepository interface {
+	GetItems(ctx context.Context, userID uint) ([]model.CartItem, error)
+	AddItem(ctx context.Context, item *model.CartItem) error
+	SetQuantity(ctx context.Context, userID uint, bookID string, quantity int) (bool, error)
+	RemoveItem(ctx context.Context, userID uint, bookID string) (bool, error)
+	Clear(ctx context.Context, userID uint) error
+}
+
+type gormCartRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCartRepository adalah constructor untuk GORM cart repository.
+func NewGormCartRepository(db *gorm.DB) CartRepository {
+	return &gormCartRepository{db: db}
+}
+
+// GetItems mengambil semua item di keranjang user sesuai urutan penambahan.
+func (r *gormCartRepository) GetItems(ctx context.Context, userID uint) ([]model.CartItem, error) {
+	var items []model.CartItem
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at ASC, id ASC").Find(&items).Error
+	return items, err
+}
+
+// AddItem menambahkan buku ke keranjang. Jika buku sudah ada, quantity dijumlahkan
+// dan harga diperbarui dalam satu query upsert sehingga tidak ada baris ganda.
+func (r *gormCartRepository) AddItem(ctx context.Context, item *model.CartItem) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "book_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"quantity":     gorm.Expr("cart_items.quantity + EXCLUDED.quantity"),
+			"price_at_add": gorm.Expr("EXCLUDED.price_at_add"),
+			"updated_at":   time.Now(),
+		}),
+	}).Create(item).Error
+}
+
+// SetQuantity mengganti quantity satu buku di keranjang.
+// Nilai bool false berarti buku tersebut tidak ada di keranjang.
+func (r *gormCartRepository) SetQuantity(ctx context.Context, userID uint, bookID string, quantity int) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.CartItem{}).
+		Where("user_id = ? AND book_id = ?", userID, bookID).
+		Update("quantity", quantity)
+	return result.RowsAffected > 0, result.Error
+}
+
+// RemoveItem menghapus satu buku dari keranjang.
+// Nilai bool false berarti buku tersebut tidak ada di keranjang.
+func (r *gormCartRepository) RemoveItem(ctx context.Context, userID uint, bookID string) (bool, error) {
+	result := r.db.WithContext(ctx).Where("user_id = ? AND book_id = ?", userID, bookID).Delete(&model.CartItem{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Clear mengosongkan keranjang user, dipanggil setelah checkout berhasil.
+func (r *gormCartRepository) Clear(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&model.CartItem{}).Error
+}
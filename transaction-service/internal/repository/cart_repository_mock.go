@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"transaction-service/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCartRepository struct {
+	mock.Mock
+}
+
+func (m *MockCartRepository) GetItems(ctx context.Context, userID uint) ([]model.CartItem, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.CartItem), args.Error(1)
+}
+
+func (m *MockCartRepository) AddItem(ctx context.Context, item *model.CartItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockCartRepository) SetQuantity(ctx context.Context, userID uint, bookID string, quantity int) (bool, error) {
+	args := m.Called(ctx, userID, bookID, quantity)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCartRepository) RemoveItem(ctx context.Context, userID uint, bookID string) (bool, error) {
+	args := m.Called(ctx, userID, bookID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCartRepository) Clear(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
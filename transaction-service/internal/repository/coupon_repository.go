@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"transaction-service/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// Error yang dikembalikan saat kupon ditukarkan di dalam CreateTransaction.
+var (
+	ErrCouponUsageLimitReached = errors.New("coupon usage limit reached")
+	ErrCouponUserLimitReached  = errors.New("coupon usage limit per user reached")
+	ErrCouponCodeExists        = errors.New("coupon code already exists")
+)
+
+// CouponRepository adalah interface untuk operasi database kupon.
+type CouponRepository interface {
+	Create(ctx context.Context, coupon *model.Coupon) error
+	Update(ctx context.Context, coupon *model.Coupon) error
+	Delete(ctx context.Context, id uint) (bool, error)
+	FindByID(ctx context.Context, id uint) (*model.Coupon, error)
+	FindByCode(ctx context.Context, code string) (*model.Coupon, error)
+	List(ctx context.Context) ([]model.Coupon, error)
+	ReleaseRedemption(ctx context.Context, transactionID uint) error
+}
+
+type gormCouponRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCouponRepository adalah constructor untuk GORM coupon repository.
+func NewGormCouponRepository(db *gorm.DB) CouponRepository {
+	return &gormCouponRepository{db: db}
+}
+
+// Create menyimpan kupon baru. Kode kupon harus unik di antara kupon yang belum dihapus,
+// sehingga kode kupon yang sudah dihapus bisa dipakai lagi.
+func (r *gormCouponRepository) Create(ctx context.Context, coupon *model.Coupon) error {
+	err := r.db.WithContext(ctx).Create(coupon).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrCouponCodeExists
+	}
+	return err
+}
+
+// Update menyimpan perubahan definisi kupon tanpa menyentuh used_count,
+// karena used_count hanya diubah saat kupon ditukarkan atau dilepas.
+func (r *gormCouponRepository) Update(ctx context.Context, coupon *model.Coupon) error {
+	err := r.db.WithContext(ctx).Model(coupon).Select("*").Omit("used_count", "created_at").Updates(coupon).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrCouponCodeExists
+	}
+	return err
+}
+
+// Delete menghapus kupon (soft delete). Nilai bool false berarti kupon tidak ditemukan.
+func (r *gormCouponRepository) Delete(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(&model.Coupon{}, id)
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindByID mengambil kupon berdasarkan ID. Mengembalikan (nil, nil) jika tidak ditemukan.
+func (r *gormCouponRepository) FindByID(ctx context.Context, id uint) (*model.Coupon, error) {
+	var coupon model.Coupon
+	err := r.db.WithContext(ctx).First(&coupon, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+// FindByCode mengambil kupon berdasarkan kodenya. Mengembalikan (nil, nil) jika tidak ditemukan.
+func (r *gormCouponRepository) FindByCode(ctx context.Context, code string) (*model.Coupon, error) {
+	var coupon model.Coupon
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&coupon).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+// List mengambil semua kupon, terbaru lebih dulu.
+func (r *gormCouponRepository) List(ctx context.Context) ([]model.Coupon, error) {
+	var coupons []model.Coupon
+	err := r.db.WithContext(ctx).Order("id DESC").Find(&coupons).Error
+	return coupons, err
+}
+
+// ReleaseRedemption membatalkan penukaran kupon milik transaksi yang batal atau di-refund,
+// sehingga kuota kupon bisa dipakai lagi. Aman dipanggil berulang kali.
+func (r *gormCouponRepository) ReleaseRedemption(ctx context.Context, transactionID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var redemption model.CouponRedemption
+		err := tx.Where("transaction_id = ?", transactionID).First(&redemption).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&redemption).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Coupon{}).Where("id = ? AND used_count > 0", redemption.CouponID).
+			Update("used_count", gorm.Expr("used_count - 1")).Error
+	})
+}
+
+// redeemCoupon mencatat pemakaian kupon untuk transaksi yang baru disimpan, di dalam
+// transaction database yang sama. UPDATE pada baris kupon sekaligus mengunci baris
+// tersebut sehingga pengecekan batas per user tidak balapan dengan penukaran lain.
+func redeemCoupon(tx *gorm.DB, transaction *model.Transaction) error {
+	result := tx.Model(&model.Coupon{}).
+		Where("id = ? AND (usage_limit = 0 OR used_count < usage_limit)", *transaction.CouponID).
+		Update("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCouponUsageLimitReached
+	}
+
+	var coupon model.Coupon
+	if err := tx.First(&coupon, *transaction.CouponID).Error; err != nil {
+		return err
+	}
+	if coupon.PerUserLimit > 0 {
+		var used int64
+		err := tx.Model(&model.CouponRedemption{}).
+			Where("coupon_id = ? AND user_id = ?", coupon.ID, transaction.UserID).
+			Count(&used).Error
+		if err != nil {
+			return err
+		}
+		if used >= int64(coupon.PerUserLimit) {
+			return ErrCouponUserLimitReached
+		}
+	}
+
+	return tx.Create(&model.CouponRedemption{
+		CouponID:       coupon.ID,
+		UserID:         transaction.UserID,
+		TransactionID:  transaction.ID,
+		DiscountAmount: transaction.DiscountAmount,
+	}).Error
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"transaction-service/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCouponRepository struct {
+	mock.Mock
+}
+
+func (m *MockCouponRepository) Create(ctx context.Context, coupon *model.Coupon) error {
+	args := m.Called(ctx, coupon)
+	return args.Error(0)
+}
+
+func (m *MockCouponRepository) Update(ctx context.Context, coupon *model.Coupon) error {
+	args := m.Called(ctx, coupon)
+	return args.Error(0)
+}
+
+func (m *MockCouponRepository) Delete(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCouponRepository) FindByID(ctx context.Context, id uint) (*model.Coupon, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Coupon), args.Error(1)
+}
+
+func (m *MockCouponRepository) FindByCode(ctx context.Context, code string) (*model.Coupon, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Coupon), args.Error(1)
+}
+
+func (m *MockCouponRepository) List(ctx context.Context) ([]model.Coupon, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Coupon), args.Error(1)
+}
+
+func (m *MockCouponRepository) ReleaseRedemption(ctx context.Context, transactionID uint) error {
+	args := m.Called(ctx, transactionID)
+	return args.Error(0)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"transaction-service/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// DeadLetterRepository adalah interface untuk menyimpan event yang gagal diproses permanen.
+type DeadLetterRepository interface {
+	CreateDeadLetter(ctx context.Context, deadLetter *model.DeadLetter) error
+}
+
+type gormDeadLetterRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDeadLetterRepository adalah constructor untuk GORM dead-letter repository.
+func NewGormDeadLetterRepository(db *gorm.DB) DeadLetterRepository {
+	return &gormDeadLetterRepository{db: db}
+}
+
+func (r *gormDeadLetterRepository) CreateDeadLetter(ctx context.Context, deadLetter *model.DeadLetter) error {
+	return r.db.WithContext(ctx).Create(deadLetter).Error
+}
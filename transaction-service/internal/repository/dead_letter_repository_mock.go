@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"transaction-service/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDeadLetterRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeadLetterRepository) CreateDeadLetter(ctx context.Context, deadLetter *model.DeadLetter) error {
+	args := m.Called(ctx, deadLetter)
+	return args.Error(0)
+}
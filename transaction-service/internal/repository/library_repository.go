@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"transaction-service/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LibraryCursor menandai posisi buku terakhir pada halaman library sebelumnya.
+type LibraryCursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+// LibraryFilter berisi user dan cursor pagination untuk library.
+type LibraryFilter struct {
+	UserID uint
+	After  *LibraryCursor // Ambil buku yang dimiliki lebih awal dari cursor ini
+	Limit  int
+}
+
+// LibraryRepository adalah interface untuk operasi database library (entitlement ebook).
+type LibraryRepository interface {
+	GrantEntitlement(ctx context.Context, entitlement *model.Entitlement) (*model.Entitlement, error)
+	ListOwnedBooks(ctx context.Context, filter LibraryFilter) ([]model.Entitlement, error)
+	GetEntitlement(ctx context.Context, userID uint, bookID string) (*model.Entitlement, error)
+	BackfillPurchaseEntitlements(ctx context.Context) (granted int64, applied bool, err error)
+}
+
+// backfillPurchaseEntitlementsMigration adalah nama backfill entitlement pembelian di data_migrations.
+const backfillPurchaseEntitlementsMigration = "backfill_purchase_entitlements"
+
+type gormLibraryRepository struct {
+	db *gorm.DB
+}
+
+// NewGormLibraryRepository adalah constructor untuk GORM library repository.
+func NewGormLibraryRepository(db *gorm.DB) LibraryRepository {
+	return &gormLibraryRepository{db: db}
+}
+
+// GrantEntitlement menyimpan entitlement jika belum ada entitlement dengan reference_id dan
+// book_id yang sama, lalu mengembalikan baris yang tersimpan (baru atau yang sudah ada).
+func (r *gormLibraryRepository) GrantEntitlement(ctx context.Context, entitlement *model.Entitlement) (*model.Entitlement, error) {
+	var stored model.Entitlement
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := grantEntitlements(tx, []model.Entitlement{*entitlement}); err != nil {
+			return err
+		}
+		return tx.Where("reference_id = ? AND book_id = ?", entitlement.ReferenceID, entitlement.BookID).First(&stored).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// ListOwnedBooks mengambil entitlement pertama untuk setiap buku milik user, yang terbaru
+// dimiliki lebih dulu. Buku yang dimiliki lewat beberapa entitlement hanya muncul sekali.
+func (r *gormLibraryRepository) ListOwnedBooks(ctx context.Context, filter LibraryFilter) ([]model.Entitlement, error) {
+	firstPerBook := r.db.Model(&model.Entitlement{}).
+		Select("DISTINCT ON (book_id) *").
+		Where("user_id = ?", filter.UserID).
+		Order("book_id, created_at ASC, id ASC")
+
+	query := r.db.WithContext(ctx).Table("(?) AS entitlements", firstPerBook)
+	if filter.After != nil {
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))",
+			filter.After.CreatedAt, filter.After.CreatedAt, filter.After.ID)
+	}
+
+	var entitlements []model.Entitlement
+	err := query.Order("created_at DESC, id DESC").Limit(filter.Limit).Find(&entitlements).Error
+	return entitlements, err
+}
+
+// GetEntitlement mengambil entitlement paling awal user untuk sebuah buku.
+// Mengembalikan (nil, nil) jika user tidak memiliki buku tersebut.
+func (r *gormLibraryRepository) GetEntitlement(ctx context.Context, userID uint, bookID string) (*model.Entitlement, error) {
+	var entitlement model.Entitlement
+	err := r.db.WithContext(ctx).Where("user_id = ? AND book_id = ?", userID, bookID).
+		Order("created_at ASC, id ASC").First(&entitlement).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entitlement, nil
+}
+
+// BackfillPurchaseEntitlements mencatat entitlement untuk transaksi completed yang selesai
+// sebelum library ada. Backfill hanya dijalankan sekali: penandanya disimpan di data_migrations
+// dalam transaction database yang sama, sehingga instance lain yang start bersamaan menunggu lalu
+// melewatinya. applied bernilai false jika backfill sudah pernah dijalankan.
+func (r *gormLibraryRepository) BackfillPurchaseEntitlements(ctx context.Context) (granted int64, applied bool, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		marker := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.DataMigration{Name: backfillPurchaseEntitlementsMigration, AppliedAt: time.Now()})
+		if marker.Error != nil {
+			return marker.Error
+		}
+		if marker.RowsAffected == 0 {
+			return nil
+		}
+		applied = true
+
+		result := tx.Exec(`
+			INSERT INTO entitlements (user_id, book_id, source, reference_id, created_at)
+			SELECT DISTINCT t.user_id, d.book_id, ?, CAST(t.id AS TEXT), t.updated_at
+			FROM transactions t
+			JOIN transaction_details d ON d.transaction_id = t.id AND d.deleted_at IS NULL
+			WHERE t.status = ? AND t.deleted_at IS NULL
+			ON CONFLICT (reference_id, book_id) DO NOTHING`,
+			model.EntitlementSourcePurchase, model.StatusCompleted)
+		granted = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return granted, applied, nil
+}
+
+// grantEntitlements menyimpan entitlement di dalam transaction database tx. Entitlement yang
+// sudah tercatat untuk reference_id dan book_id yang sama dilewati.
+func grantEntitlements(tx *gorm.DB, entitlements []model.Entitlement) error {
+	if len(entitlements) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "reference_id"}, {Name: "book_id"}},
+		DoNothing: true,
+	}).Create(&entitlements).Error
+}
+
+// purchaseEntitlements membangun satu entitlement untuk setiap buku di transaksi.
+func purchaseEntitlements(transaction *model.Transaction) []model.Entitlement {
+	var entitlements []model.Entitlement
+	seen := make(map[string]bool)
+	for _, detail := range transaction.Details {
+		if seen[detail.BookID] {
+			continue
+		}
+		seen[detail.BookID] = true
+		entitlements = append(entitlements, model.Entitlement{
+			UserID:      transaction.UserID,
+			BookID:      detail.BookID,
+			Source:      model.EntitlementSourcePurchase,
+			ReferenceID: fmt.Sprintf("%d", transaction.ID),
+		})
+	}
+	return entitlements
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"transaction-service/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLibraryRepository struct {
+	mock.Mock
+}
+
+func (m *MockLibraryRepository) GrantEntitlement(ctx context.Context, entitlement *model.Entitlement) (*model.Entitlement, error) {
+	args := m.Called(ctx, entitlement)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Entitlement), args.Error(1)
+}
+
+func (m *MockLibraryRepository) ListOwnedBooks(ctx context.Context, filter LibraryFilter) ([]model.Entitlement, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Entitlement), args.Error(1)
+}
+
+func (m *MockLibraryRepository) GetEntitlement(ctx context.Context, userID uint, bookID string) (*model.Entitlement, error) {
+	args := m.Called(ctx, userID, bookID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Entitlement), args.Error(1)
+}
+
+func (m *MockLibraryRepository) BackfillPurchaseEntitlements(ctx context.Context) (int64, bool, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Bool(1), args.Error(2)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"transaction-service/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// OutboxRepository adalah interface untuk membaca dan memperbarui baris outbox.
+type OutboxRepository interface {
+	GetPendingOutboxEvents(ctx context.Context, limit int) ([]model.OutboxEvent, error)
+	MarkOutboxEventSent(ctx context.Context, id uint) error
+	MarkOutboxEventFailed(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastError string) error
+}
+
+type gormOutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewGormOutboxRepository adalah constructor untuk GORM outbox repository.
+func NewGormOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &gormOutboxRepository{db: db}
+}
+
+// GetPendingOutboxEvents mengambil event yang belum terkirim dan sudah waktunya dicoba lagi,
+// diurutkan sesuai urutan penulisan. Event dengan key yang masih punya event lebih awal yang
+// belum terkirim dan belum waktunya dicoba lagi ditahan, agar urutan per key tetap terjaga.
+// Event tanpa key tidak punya jaminan urutan sehingga tidak ditahan.
+func (r *gormOutboxRepository) GetPendingOutboxEvents(ctx context.Context, limit int) ([]model.OutboxEvent, error) {
+	var events []model.OutboxEvent
+	now := time.Now()
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", model.OutboxStatusPending, now).
+		Where("outbox_events.key = '' OR outbox_events.key IS NULL OR NOT EXISTS (?)",
+			r.db.Table("outbox_events AS earlier").Select("1").
+				Where("earlier.key = outbox_events.key AND earlier.id < outbox_events.id").
+				Where("earlier.status = ? AND earlier.next_attempt_at > ?", model.OutboxStatusPending, now)).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *gormOutboxRepository) MarkOutboxEventSent(ctx context.Context, id uint) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).Model(&model.OutboxEvent{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"status": model.OutboxStatusSent, "sent_at": now, "last_error": ""}).Error
+}
+
+func (r *gormOutboxRepository) MarkOutboxEventFailed(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastError string) error {
+	return r.db.WithContext(ctx).Model(&model.OutboxEvent{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"attempts": attempts, "next_attempt_at": nextAttemptAt, "last_error": lastError}).Error
+}
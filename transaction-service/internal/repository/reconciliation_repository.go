@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"transaction-service/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ReconciliationRepository adalah interface untuk operasi database rekonsiliasi.
+type ReconciliationRepository interface {
+	FindTransactionsToReconcile(ctx context.Context, from, to time.Time, afterID uint, limit int) ([]model.Transaction, error)
+	LastCompletedRun(ctx context.Context, trigger string) (*model.ReconciliationRun, error)
+	CreateRun(ctx context.Context, run *model.ReconciliationRun) error
+	UpdateRun(ctx context.Context, run *model.ReconciliationRun) error
+	AddMismatches(ctx context.Context, mismatches []model.ReconciliationMismatch) error
+	ListRuns(ctx context.Context, offset, limit int) ([]model.ReconciliationRun, int64, error)
+	GetRun(ctx context.Context, id uint) (*model.ReconciliationRun, error)
+}
+
+type gormReconciliationRepository struct {
+	db *gorm.DB
+}
+
+// NewGormReconciliationRepository adalah constructor untuk GORM reconciliation repository.
+func NewGormReconciliationRepository(db *gorm.DB) ReconciliationRepository {
+	return &gormReconciliationRepository{db: db}
+}
+
+// FindTransactionsToReconcile mengambil satu batch transaksi (urut ID, setelah afterID) yang perlu dicek:
+// transaksi yang mencapai status akhir dalam [from, to), dan transaksi yang dibuat dalam
+// [from, to) tetapi masih pending.
+func (r *gormReconciliationRepository) FindTransactionsToReconcile(ctx context.Context, from, to time.Time, afterID uint, limit int) ([]model.Transaction, error) {
+	var transactions []model.Transaction
+	err := r.db.WithContext(ctx).
+		Where("id > ?", afterID).
+		Where(r.db.Where("status IN ? AND updated_at >= ? AND updated_at < ?",
+			[]string{model.StatusCompleted, model.StatusCancelled, model.StatusRefunded}, from, to).
+			Or("status = ? AND created_at >= ? AND created_at < ?", model.StatusPending, from, to)).
+		Order("id").
+		Limit(limit).
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// LastCompletedRun mengambil run terakhir yang selesai dengan pemicu tertentu.
+// Mengembalikan (nil, nil) jika belum ada.
+func (r *gormReconciliationRepository) LastCompletedRun(ctx context.Context, trigger string) (*model.ReconciliationRun, error) {
+	var run model.ReconciliationRun
+	err := r.db.WithContext(ctx).
+		Where("trigger = ? AND status = ?", trigger, model.ReconciliationStatusCompleted).
+		Order("window_end DESC").
+		First(&run).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// CreateRun menyimpan run baru.
+func (r *gormReconciliationRepository) CreateRun(ctx context.Context, run *model.ReconciliationRun) error {
+	return r.db.WithContext(ctx).Omit("Mismatches").Create(run).Error
+}
+
+// UpdateRun menyimpan status, jumlah, dan error run.
+func (r *gormReconciliationRepository) UpdateRun(ctx context.Context, run *model.ReconciliationRun) error {
+	return r.db.WithContext(ctx).Model(run).Select("status", "checked_count", "mismatch_count", "error", "finished_at").Updates(run).Error
+}
+
+// AddMismatches menyimpan ketidakcocokan yang ditemukan dalam satu batch.
+func (r *gormReconciliationRepository) AddMismatches(ctx context.Context, mismatches []model.ReconciliationMismatch) error {
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&mismatches).Error
+}
+
+// ListRuns mengambil run rekonsiliasi (terbaru lebih dulu) beserta jumlah totalnya.
+func (r *gormReconciliationRepository) ListRuns(ctx context.Context, offset, limit int) ([]model.ReconciliationRun, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&model.ReconciliationRun{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var runs []model.ReconciliationRun
+	err := r.db.WithContext(ctx).Order("started_at DESC, id DESC").Offset(offset).Limit(limit).Find(&runs).Error
+	return runs, total, err
+}
+
+// GetRun mengambil satu run beserta ketidakcocokannya. Mengembalikan (nil, nil) jika tidak ditemukan.
+func (r *gormReconciliationRepository) GetRun(ctx context.Context, id uint) (*model.ReconciliationRun, error) {
+	var run model.ReconciliationRun
+	err := r.db.WithContext(ctx).
+		Preload("Mismatches", func(db *gorm.DB) *gorm.DB { return db.Order("transaction_id, id") }).
+		First(&run, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
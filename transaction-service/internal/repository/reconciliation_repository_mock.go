@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"transaction-service/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReconciliationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReconciliationRepository) FindTransactionsToReconcile(ctx context.Context, from, to time.Time, afterID uint, limit int) ([]model.Transaction, error) {
+	args := m.Called(ctx, from, to, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Transaction), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) LastCompletedRun(ctx context.Context, trigger string) (*model.ReconciliationRun, error) {
+	args := m.Called(ctx, trigger)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReconciliationRun), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) CreateRun(ctx context.Context, run *model.ReconciliationRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *MockReconciliationRepository) UpdateRun(ctx context.Context, run *model.ReconciliationRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *MockReconciliationRepository) AddMismatches(ctx context.Context, mismatches []model.ReconciliationMismatch) error {
+	args := m.Called(ctx, mismatches)
+	return args.Error(0)
+}
+
+func (m *MockReconciliationRepository) ListRuns(ctx context.Context, offset, limit int) ([]model.ReconciliationRun, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]model.ReconciliationRun), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockReconciliationRepository) GetRun(ctx context.Context, id uint) (*model.ReconciliationRun, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReconciliationRun), args.Error(1)
+}
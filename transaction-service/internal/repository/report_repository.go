@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"shared/money"
+	"time"
+	"transaction-service/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ReportFilter membatasi rentang waktu laporan penjualan.
+type ReportFilter struct {
+	From *time.Time // Inklusif
+	To   *time.Time // Eksklusif
+}
+
+// RevenueRow adalah hasil agregasi pendapatan untuk satu periode.
+type RevenueRow struct {
+	PeriodStart      time.Time
+	Revenue          money.Money
+	TransactionCount int64
+	BuyerCount       int64
+}
+
+// BookSalesRow adalah hasil agregasi penjualan per buku. GrossSales adalah harga x quantity
+// sebelum diskon kupon. NetSales adalah GrossSales dikurangi bagian diskon transaksi yang
+// dibagi proporsional ke setiap baris detail, sehingga sejalan dengan pendapatan di RevenueRow.
+type BookSalesRow struct {
+	BookID     string
+	Quantity   int64
+	GrossSales money.Money
+	NetSales   money.Money
+}
+
+// CategorySalesRow adalah hasil agregasi penjualan per kategori, dengan GrossSales dan
+// NetSales seperti BookSalesRow.
+type CategorySalesRow struct {
+	Category   string
+	Quantity   int64
+	GrossSales money.Money
+	NetSales   money.Money
+}
+
+// ReportRepository adalah interface untuk query laporan penjualan.
+// Semua laporan hanya menghitung transaksi berstatus completed.
+type ReportRepository interface {
+	RevenueByPeriod(ctx context.Context, period string, filter ReportFilter) ([]RevenueRow, error)
+	RevenueTotals(ctx context.Context, filter ReportFilter) (*RevenueRow, error)
+	TopBooks(ctx context.Context, filter ReportFilter, limit int) ([]BookSalesRow, error)
+	SalesByCategory(ctx context.Context, filter ReportFilter) ([]CategorySalesRow, error)
+}
+
+type gormReportRepository struct {
+	db *gorm.DB
+}
+
+// NewGormReportRepository adalah constructor untuk GORM report repository.
+func NewGormReportRepository(db *gorm.DB) ReportRepository {
+	return &gormReportRepository{db: db}
+}
+
+// completedTransactions membuat query dasar atas transaksi completed dalam rentang filter.
+// Soft delete difilter otomatis oleh GORM.
+func (r *gormReportRepository) completedTransactions(ctx context.Context, filter ReportFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&model.Transaction{}).
+		Where("transactions.status = ?", model.StatusCompleted)
+	if filter.From != nil {
+		query = query.Where("transactions.created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("transactions.created_at < ?", *filter.To)
+	}
+	return query
+}
+
+// lineNetSales menghitung penjualan bersih satu baris detail. Diskon transaksi dibagi ke
+// setiap baris sesuai porsi baris itu dari subtotal (total_amount + discount_amount),
+// lalu dibulatkan ke sen agar bisa dibaca sebagai money.Money.
+const lineNetSales = "ROUND(transaction_details.price_per_unit * transaction_details.quantity * " +
+	"transactions.total_amount / NULLIF(transactions.total_amount + transactions.discount_amount, 0), 2)"
+
+// RevenueByPeriod menjumlahkan pendapatan per periode ("day", "week", atau "month").
+// Periode harus sudah divalidasi oleh pemanggil.
+func (r *gormReportRepository) RevenueByPeriod(ctx context.Context, period string, filter ReportFilter) ([]RevenueRow, error) {
+	var rows []RevenueRow
+	err := r.completedTransactions(ctx, filter).
+		Select("date_trunc(?, transactions.created_at) AS period_start, "+
+			"COALESCE(SUM(transactions.total_amount), 0) AS revenue, "+
+			"COUNT(*) AS transaction_count, "+
+			"COUNT(DISTINCT transactions.user_id) AS buyer_count", period).
+		Group("period_start").
+		Order("period_start").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// RevenueTotals menjumlahkan pendapatan di seluruh rentang. BuyerCount adalah jumlah
+// user berbeda, sehingga tidak sama dengan penjumlahan buyer_count per periode.
+func (r *gormReportRepository) RevenueTotals(ctx context.Context, filter ReportFilter) (*RevenueRow, error) {
+	var row RevenueRow
+	err := r.completedTransactions(ctx, filter).
+		Select("COALESCE(SUM(transactions.total_amount), 0) AS revenue, " +
+			"COUNT(*) AS transaction_count, " +
+			"COUNT(DISTINCT transactions.user_id) AS buyer_count").
+		Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// TopBooks mengambil buku dengan jumlah terjual terbanyak.
+func (r *gormReportRepository) TopBooks(ctx context.Context, filter ReportFilter, limit int) ([]BookSalesRow, error) {
+	var rows []BookSalesRow
+	err := r.completedTransactions(ctx, filter).
+		Joins("JOIN transaction_details ON transaction_details.transaction_id = transactions.id AND transaction_details.deleted_at IS NULL").
+		Select("transaction_details.book_id, " +
+			"SUM(transaction_details.quantity) AS quantity, " +
+			"SUM(transaction_details.price_per_unit * transaction_details.quantity) AS gross_sales, " +
+			"COALESCE(SUM(" + lineNetSales + "), 0) AS net_sales").
+		Group("transaction_details.book_id").
+		Order("quantity DESC, gross_sales DESC, transaction_details.book_id").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// SalesByCategory menjumlahkan penjualan per kategori buku, diurutkan dari penjualan bersih
+// terbesar. Detail lama yang belum
+// menyimpan kategori dikelompokkan sebagai "Uncategorized".
+func (r *gormReportRepository) SalesByCategory(ctx context.Context, filter ReportFilter) ([]CategorySalesRow, error) {
+	var rows []CategorySalesRow
+	err := r.completedTransactions(ctx, filter).
+		Joins("JOIN transaction_details ON transaction_details.transaction_id = transactions.id AND transaction_details.deleted_at IS NULL").
+		Select("COALESCE(NULLIF(transaction_details.category, ''), 'Uncategorized') AS category, " +
+			"SUM(transaction_details.quantity) AS quantity, " +
+			"SUM(transaction_details.price_per_unit * transaction_details.quantity) AS gross_sales, " +
+			"COALESCE(SUM(" + lineNetSales + "), 0) AS net_sales").
+		Group("1").
+		Order("net_sales DESC, category").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) RevenueByPeriod(ctx context.Context, period string, filter ReportFilter) ([]RevenueRow, error) {
+	args := m.Called(ctx, period, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]RevenueRow), args.Error(1)
+}
+
+func (m *MockReportRepository) RevenueTotals(ctx context.Context, filter ReportFilter) (*RevenueRow, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*RevenueRow), args.Error(1)
+}
+
+func (m *MockReportRepository) TopBooks(ctx context.Context, filter ReportFilter, limit int) ([]BookSalesRow, error) {
+	args := m.Called(ctx, filter, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]BookSalesRow), args.Error(1)
+}
+
+func (m *MockReportRepository) SalesByCategory(ctx context.Context, filter ReportFilter) ([]CategorySalesRow, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]CategorySalesRow), args.Error(1)
+}
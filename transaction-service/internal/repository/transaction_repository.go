@@ -2,15 +2,42 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 	"transaction-service/internal/model"
 
 	"gorm.io/gorm"
 )
 
+// OutboxEventFunc membangun event outbox dari transaksi yang baru disimpan (ID sudah terisi).
+type OutboxEventFunc func(transaction *model.Transaction) (*model.OutboxEvent, error)
+
+// TransactionCursor menandai posisi transaksi terakhir pada halaman sebelumnya.
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+// TransactionFilter berisi filter dan cursor pagination untuk riwayat transaksi.
+type TransactionFilter struct {
+	UserID uint
+	Status string             // Kosong berarti semua status
+	From   *time.Time         // Inklusif
+	To     *time.Time         // Eksklusif
+	After  *TransactionCursor // Ambil transaksi yang lebih lama dari cursor ini
+	Limit  int
+}
+
 // TransactionRepository adalah interface untuk operasi database.
 type TransactionRepository interface {
-	CreateTransaction(ctx context.Context, transaction *model.Transaction) (*model.Transaction, error)
-	GetTransactionsByUserID(ctx context.Context, userID uint) ([]model.Transaction, error)
+	CreateTransaction(ctx context.Context, transaction *model.Transaction, newEvent OutboxEventFunc) (*model.Transaction, error)
+	GetTransactionsByUserID(ctx context.Context, filter TransactionFilter) ([]model.Transaction, int64, error)
+	GetTransactionByID(ctx context.Context, id uint) (*model.Transaction, error)
+	UpdateTransactionStatus(ctx context.Context, id uint, fromStatus, toStatus, failureReason string) (bool, error)
+	CompletePendingTransaction(ctx context.Context, id uint) (bool, error)
+	RefundTransaction(ctx context.Context, id uint, fromStatus, reason string) (bool, error)
+	FailPendingTransaction(ctx context.Context, id uint, failureCode, failureReason string) (bool, error)
 }
 
 type gormRepository struct {
@@ -22,16 +49,146 @@ func NewGormRepository(db *gorm.DB) TransactionRepository {
 	return &gormRepository{db: db}
 }
 
-// CreateTransaction menyimpan transaksi beserta semua detailnya.
-// GORM akan otomatis menggunakan transaction database karena kita membuat data yang berelasi.
-func (r *gormRepository) CreateTransaction(ctx context.Context, transaction *model.Transaction) (*model.Transaction, error) {
-	err := r.db.WithContext(ctx).Create(transaction).Error
+// CreateTransaction menyimpan transaksi beserta semua detailnya dan event outbox-nya
+// dalam satu transaction database, sehingga keduanya tersimpan atau gagal bersamaan.
+// Jika transaksi memakai kupon, penukaran kupon juga dicatat di transaction yang sama.
+func (r *gormRepository) CreateTransaction(ctx context.Context, transaction *model.Transaction, newEvent OutboxEventFunc) (*model.Transaction, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(transaction).Error; err != nil {
+			return err
+		}
+
+		if transaction.CouponID != nil {
+			if err := redeemCoupon(tx, transaction); err != nil {
+				return err
+			}
+		}
+
+		event, err := newEvent(transaction)
+		if err != nil {
+			return err
+		}
+		event.Status = model.OutboxStatusPending
+		event.NextAttemptAt = time.Now()
+		return tx.Create(event).Error
+	})
 	return transaction, err
 }
 
-func (r *gormRepository) GetTransactionsByUserID(ctx context.Context, userID uint) ([]model.Transaction, error) {
-    var transactions []model.Transaction
-    // Menggunakan Preload untuk mengambil data relasi 'Details' juga
-    err := r.db.WithContext(ctx).Preload("Details").Where("user_id = ?", userID).Order("created_at DESC").Find(&transactions).Error
-    return transactions, err
-}
\ No newline at end of file
+// GetTransactionsByUserID mengambil satu halaman transaksi milik user (terbaru lebih dulu)
+// beserta jumlah seluruh transaksi yang cocok dengan filter, tanpa memperhitungkan cursor.
+func (r *gormRepository) GetTransactionsByUserID(ctx context.Context, filter TransactionFilter) ([]model.Transaction, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.Transaction{}).Where("user_id = ?", filter.UserID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filter.After != nil {
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))",
+			filter.After.CreatedAt, filter.After.CreatedAt, filter.After.ID)
+	}
+
+	var transactions []model.Transaction
+	// Menggunakan Preload untuk mengambil data relasi 'Details' juga, hanya untuk halaman ini
+	err := query.Preload("Details").Order("created_at DESC, id DESC").Limit(filter.Limit).Find(&transactions).Error
+	return transactions, total, err
+}
+
+// GetTransactionByID mengambil satu transaksi beserta detailnya.
+// Mengembalikan (nil, nil) jika transaksi tidak ditemukan.
+func (r *gormRepository) GetTransactionByID(ctx context.Context, id uint) (*model.Transaction, error) {
+	var transaction model.Transaction
+	err := r.db.WithContext(ctx).Preload("Details").First(&transaction, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// UpdateTransactionStatus mengubah status transaksi hanya jika status saat ini masih fromStatus.
+// Nilai bool menandakan apakah ada baris yang benar-benar berubah, sehingga event
+// yang terkirim ulang tidak menimpa status yang sudah final.
+func (r *gormRepository) UpdateTransactionStatus(ctx context.Context, id uint, fromStatus, toStatus, failureReason string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.Transaction{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Updates(map[string]interface{}{"status": toStatus, "failure_reason": failureReason})
+	return result.RowsAffected > 0, result.Error
+}
+
+// CompletePendingTransaction mengubah transaksi pending menjadi completed dan, dalam transaction
+// database yang sama, mencatat entitlement untuk setiap buku di transaksi tersebut sehingga
+// pembayaran yang berhasil selalu tercatat di library user. Nilai bool bernilai false jika
+// transaksi sudah tidak pending.
+func (r *gormRepository) CompletePendingTransaction(ctx context.Context, id uint) (bool, error) {
+	updated := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Transaction{}).
+			Where("id = ? AND status = ?", id, model.StatusPending).
+			Updates(map[string]interface{}{"status": model.StatusCompleted, "failure_reason": ""})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		var transaction model.Transaction
+		if err := tx.Preload("Details").First(&transaction, id).Error; err != nil {
+			return err
+		}
+		updated = true
+		return grantEntitlements(tx, purchaseEntitlements(&transaction))
+	})
+	if err != nil {
+		return false, err
+	}
+	return updated, nil
+}
+
+// RefundTransaction mengubah status transaksi dari fromStatus menjadi refunded dan, dalam
+// transaction database yang sama, mencabut entitlement pembelian dari transaksi tersebut.
+// Entitlement dari sumber lain (mis. hadiah) untuk buku yang sama tidak ikut dicabut.
+func (r *gormRepository) RefundTransaction(ctx context.Context, id uint, fromStatus, reason string) (bool, error) {
+	updated := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Transaction{}).
+			Where("id = ? AND status = ?", id, fromStatus).
+			Updates(map[string]interface{}{"status": model.StatusRefunded, "failure_reason": reason})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		updated = true
+		return tx.Where("source = ? AND reference_id = ?", model.EntitlementSourcePurchase, fmt.Sprintf("%d", id)).
+			Delete(&model.Entitlement{}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return updated, nil
+}
+
+// FailPendingTransaction membatalkan transaksi pending karena pembayaran gagal, sekaligus
+// menyimpan kode dan alasan kegagalannya. Seperti UpdateTransactionStatus, nilai bool
+// bernilai false jika transaksi sudah tidak pending.
+func (r *gormRepository) FailPendingTransaction(ctx context.Context, id uint, failureCode, failureReason string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.Transaction{}).
+		Where("id = ? AND status = ?", id, model.StatusPending).
+		Updates(map[string]interface{}{
+			"status":         model.StatusCancelled,
+			"failure_code":   failureCode,
+			"failure_reason": failureReason,
+		})
+	return result.RowsAffected > 0, result.Error
+}
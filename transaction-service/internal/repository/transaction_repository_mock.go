@@ -11,18 +11,46 @@ type MockTransactionRepository struct {
 	mock.Mock
 }
 
-func (m *MockTransactionRepository) CreateTransaction(ctx context.Context, transaction *model.Transaction) (*model.Transaction, error) {
-	args := m.Called(ctx, transaction)
+func (m *MockTransactionRepository) CreateTransaction(ctx context.Context, transaction *model.Transaction, newEvent OutboxEventFunc) (*model.Transaction, error) {
+	args := m.Called(ctx, transaction, newEvent)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.Transaction), args.Error(1)
 }
 
-func (m *MockTransactionRepository) GetTransactionsByUserID(ctx context.Context, userID uint) ([]model.Transaction, error) {
-	args := m.Called(ctx, userID)
+func (m *MockTransactionRepository) GetTransactionsByUserID(ctx context.Context, filter TransactionFilter) ([]model.Transaction, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]model.Transaction), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTransactionRepository) GetTransactionByID(ctx context.Context, id uint) (*model.Transaction, error) {
+	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]model.Transaction), args.Error(1)
-}
\ No newline at end of file
+	return args.Get(0).(*model.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) UpdateTransactionStatus(ctx context.Context, id uint, fromStatus, toStatus, failureReason string) (bool, error) {
+	args := m.Called(ctx, id, fromStatus, toStatus, failureReason)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CompletePendingTransaction(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) RefundTransaction(ctx context.Context, id uint, fromStatus, reason string) (bool, error) {
+	args := m.Called(ctx, id, fromStatus, reason)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransactionRepository) FailPendingTransaction(ctx context.Context, id uint, failureCode, failureReason string) (bool, error) {
+	args := m.Called(ctx, id, failureCode, failureReason)
+	return args.Bool(0), args.Error(1)
+}
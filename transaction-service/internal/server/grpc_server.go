@@ -2,8 +2,13 @@ package server
 
 import (
 	"context"
+	"errors"
 	"transaction-service/internal/service"
 	pb "transaction-service/proto" // Import kode yang di-generate
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // server mengimplementasikan TransactionServiceServer
@@ -11,10 +16,15 @@ type GrpcServer struct {
 	pb.UnimplementedTransactionServiceServer
 	// Dependensi ke service/repository
 	transactionService service.TransactionService
+	cartService        service.CartService
+	couponService      service.CouponService
+	reportService         service.ReportService
+	reconciliationService service.ReconciliationService
+	libraryService        service.LibraryService
 }
 
-func NewGrpcServer(ts service.TransactionService) *GrpcServer {
-	return &GrpcServer{transactionService: ts}
+func NewGrpcServer(ts service.TransactionService, cs service.CartService, cps service.CouponService, rs service.ReportService, rcs service.ReconciliationService, ls service.LibraryService) *GrpcServer {
+	return &GrpcServer{transactionService: ts, cartService: cs, couponService: cps, reportService: rs, reconciliationService: rcs, libraryService: ls}
 }
 
 // CreateTransaction adalah implementasi dari RPC
@@ -33,13 +43,262 @@ func (s *GrpcServer) CreateTransaction(ctx context.Context, req *pb.CreateTransa
 	if err != nil {
 		// Jika ada error dari service (misal: buku tidak ada, saldo kurang),
 		// gRPC akan meneruskannya ke client.
-		return nil, err
+		return nil, toGrpcError(err)
 	}
 
 	// Kembalikan response yang sudah dalam format protobuf
 	return response, nil
 }
 
+// QuoteOrder mengembalikan quote harga bertanda tangan untuk keranjang user.
+func (s *GrpcServer) QuoteOrder(ctx context.Context, req *pb.QuoteOrderRequest) (*pb.QuoteResponse, error) {
+	response, err := s.transactionService.QuoteOrder(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
 func (s *GrpcServer) GetUserTransactions(ctx context.Context, req *pb.GetUserTransactionsRequest) (*pb.GetUserTransactionsResponse, error) {
-	return s.transactionService.GetUserTransactions(ctx, req)
-}
\ No newline at end of file
+	response, err := s.transactionService.GetUserTransactions(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// GetTransaction mengambil satu transaksi milik user.
+func (s *GrpcServer) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.TransactionResponse, error) {
+	response, err := s.transactionService.GetTransaction(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// CancelTransaction membatalkan transaksi milik user, atau milik siapa saja jika admin.
+func (s *GrpcServer) CancelTransaction(ctx context.Context, req *pb.CancelTransactionRequest) (*pb.TransactionResponse, error) {
+	response, err := s.transactionService.CancelTransaction(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// WatchTransaction mengirim perubahan status transaksi ke client sampai transaksi selesai diproses.
+func (s *GrpcServer) WatchTransaction(req *pb.WatchTransactionRequest, stream pb.TransactionService_WatchTransactionServer) error {
+	if err := s.transactionService.WatchTransaction(stream.Context(), req, stream.Send); err != nil {
+		return toGrpcError(err)
+	}
+	return nil
+}
+
+// GetCart mengambil keranjang user beserta harga dan ketersediaan terbaru.
+func (s *GrpcServer) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.CartResponse, error) {
+	response, err := s.cartService.GetCart(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// AddCartItem menambahkan buku ke keranjang user.
+func (s *GrpcServer) AddCartItem(ctx context.Context, req *pb.CartItemRequest) (*pb.CartResponse, error) {
+	response, err := s.cartService.AddCartItem(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// UpdateCartItem mengganti quantity buku di keranjang user.
+func (s *GrpcServer) UpdateCartItem(ctx context.Context, req *pb.CartItemRequest) (*pb.CartResponse, error) {
+	response, err := s.cartService.UpdateCartItem(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// RemoveCartItem menghapus buku dari keranjang user.
+func (s *GrpcServer) RemoveCartItem(ctx context.Context, req *pb.RemoveCartItemRequest) (*pb.CartResponse, error) {
+	response, err := s.cartService.RemoveCartItem(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// CheckoutCart membuat transaksi dari isi keranjang user.
+func (s *GrpcServer) CheckoutCart(ctx context.Context, req *pb.CheckoutCartRequest) (*pb.TransactionResponse, error) {
+	response, err := s.cartService.CheckoutCart(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// CreateCoupon membuat kupon baru.
+func (s *GrpcServer) CreateCoupon(ctx context.Context, req *pb.CreateCouponRequest) (*pb.Coupon, error) {
+	response, err := s.couponService.CreateCoupon(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// UpdateCoupon mengubah definisi kupon.
+func (s *GrpcServer) UpdateCoupon(ctx context.Context, req *pb.UpdateCouponRequest) (*pb.Coupon, error) {
+	response, err := s.couponService.UpdateCoupon(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// DeleteCoupon menghapus kupon.
+func (s *GrpcServer) DeleteCoupon(ctx context.Context, req *pb.DeleteCouponRequest) (*pb.DeleteCouponResponse, error) {
+	response, err := s.couponService.DeleteCoupon(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// GetCoupon mengambil satu kupon.
+func (s *GrpcServer) GetCoupon(ctx context.Context, req *pb.GetCouponRequest) (*pb.Coupon, error) {
+	response, err := s.couponService.GetCoupon(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// ListCoupons mengambil semua kupon.
+func (s *GrpcServer) ListCoupons(ctx context.Context, req *pb.ListCouponsRequest) (*pb.ListCouponsResponse, error) {
+	response, err := s.couponService.ListCoupons(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// GetRevenueReport mengambil laporan pendapatan per periode (admin).
+func (s *GrpcServer) GetRevenueReport(ctx context.Context, req *pb.RevenueReportRequest) (*pb.RevenueReportResponse, error) {
+	response, err := s.reportService.GetRevenueReport(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// GetTopBooksReport mengambil laporan buku terlaris (admin).
+func (s *GrpcServer) GetTopBooksReport(ctx context.Context, req *pb.TopBooksReportRequest) (*pb.TopBooksReportResponse, error) {
+	response, err := s.reportService.GetTopBooksReport(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// GetCategoryReport mengambil laporan penjualan per kategori (admin).
+func (s *GrpcServer) GetCategoryReport(ctx context.Context, req *pb.CategoryReportRequest) (*pb.CategoryReportResponse, error) {
+	response, err := s.reportService.GetCategoryReport(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// RunReconciliation menjalankan rekonsiliasi manual (admin).
+func (s *GrpcServer) RunReconciliation(ctx context.Context, req *pb.RunReconciliationRequest) (*pb.ReconciliationRun, error) {
+	response, err := s.reconciliationService.RunReconciliation(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// ListReconciliationRuns mengambil daftar run rekonsiliasi (admin).
+func (s *GrpcServer) ListReconciliationRuns(ctx context.Context, req *pb.ListReconciliationRunsRequest) (*pb.ListReconciliationRunsResponse, error) {
+	response, err := s.reconciliationService.ListReconciliationRuns(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// GetReconciliationRun mengambil satu run rekonsiliasi beserta ketidakcocokannya (admin).
+func (s *GrpcServer) GetReconciliationRun(ctx context.Context, req *pb.GetReconciliationRunRequest) (*pb.ReconciliationRun, error) {
+	response, err := s.reconciliationService.GetReconciliationRun(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// ListLibrary mengembalikan buku yang dimiliki user beserta metadatanya.
+func (s *GrpcServer) ListLibrary(ctx context.Context, req *pb.ListLibraryRequest) (*pb.ListLibraryResponse, error) {
+	response, err := s.libraryService.ListLibrary(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// CheckOwnership mengecek apakah user memiliki sebuah buku.
+func (s *GrpcServer) CheckOwnership(ctx context.Context, req *pb.CheckOwnershipRequest) (*pb.CheckOwnershipResponse, error) {
+	response, err := s.libraryService.CheckOwnership(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// GrantEntitlement mencatat entitlement dari service lain, mis. hadiah yang diterima.
+func (s *GrpcServer) GrantEntitlement(ctx context.Context, req *pb.GrantEntitlementRequest) (*pb.Entitlement, error) {
+	response, err := s.libraryService.GrantEntitlement(ctx, req)
+	if err != nil {
+		return nil, toGrpcError(err)
+	}
+	return response, nil
+}
+
+// ErrorDomain adalah domain ErrorInfo yang dilampirkan pada status gRPC dari service ini.
+const ErrorDomain = "transaction-service"
+
+// toGrpcError menerjemahkan error bisnis dari service ke kode gRPC.
+func toGrpcError(err error) error {
+	var notAuthorized *service.PaymentNotAuthorizedError
+	switch {
+	case errors.As(err, &notAuthorized) && notAuthorized.Code != "":
+		// Kode penolakan dari wallet-service diteruskan agar gateway bisa menampilkannya
+		st := status.New(codes.FailedPrecondition, err.Error())
+		if detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: notAuthorized.Code, Domain: ErrorDomain}); detailErr == nil {
+			st = detailed
+		}
+		return st.Err()
+	case errors.Is(err, service.ErrTransactionNotFound), errors.Is(err, service.ErrCartItemNotFound),
+		errors.Is(err, service.ErrCouponNotFound), errors.Is(err, service.ErrReconciliationRunNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotTransactionOwner):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrTransactionNotCancellable), errors.Is(err, service.ErrOutOfStock),
+		errors.Is(err, service.ErrQuoteExpired), errors.Is(err, service.ErrCartEmpty),
+		errors.Is(err, service.ErrCartItemUnavailable), errors.Is(err, service.ErrCouponNotActive),
+		errors.Is(err, service.ErrCouponMinOrderNotMet), errors.Is(err, service.ErrCouponNotApplicable),
+		errors.Is(err, service.ErrCouponUsageLimitReached), errors.Is(err, service.ErrCouponUserLimitReached),
+		errors.Is(err, service.ErrCouponCodeExists), errors.Is(err, service.ErrPaymentNotAuthorized),
+		errors.Is(err, service.ErrEntitlementConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrInvalidPageToken), errors.Is(err, service.ErrInvalidDateRange),
+		errors.Is(err, service.ErrInvalidStatusFilter), errors.Is(err, service.ErrInvalidQuote),
+		errors.Is(err, service.ErrInvalidCartQuantity), errors.Is(err, service.ErrCouponInvalid),
+		errors.Is(err, service.ErrInvalidCouponDefinition), errors.Is(err, service.ErrInvalidReportPeriod),
+		errors.Is(err, service.ErrInvalidEntitlement), errors.Is(err, service.ErrInvalidTransactionID),
+		errors.Is(err, service.ErrInvalidUserID):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return err
+	}
+}
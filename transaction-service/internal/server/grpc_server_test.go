@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"transaction-service/internal/service"
+	pb "transaction-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Tes ID yang bukan angka dikembalikan sebagai InvalidArgument, bukan Unknown
+func TestGetTransaction_InvalidIDIsInvalidArgument(t *testing.T) {
+	grpcServer := NewGrpcServer(service.NewTransactionService(nil, nil, nil, nil, nil, 0), nil, nil, nil, nil, nil)
+
+	_, err := grpcServer.GetTransaction(context.Background(), &pb.GetTransactionRequest{TransactionId: "abc", UserId: "1"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "invalid transaction id format", status.Convert(err).Message())
+}
+
+func TestToGrpcError_InvalidUserID(t *testing.T) {
+	assert.Equal(t, codes.InvalidArgument, status.Code(toGrpcError(service.ErrInvalidUserID)))
+}
+
+// Tes kode penolakan pembayaran dari wallet-service ikut terkirim di detail status
+func TestToGrpcError_PaymentNotAuthorizedKeepsCode(t *testing.T) {
+	err := toGrpcError(&service.PaymentNotAuthorizedError{Code: "daily_limit_exceeded", Reason: "daily spending limit of Rp100.000 exceeded"})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	assert.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "daily_limit_exceeded", info.Reason)
+}
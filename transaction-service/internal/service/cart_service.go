@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"shared/money"
+	"strconv"
+
+	"transaction-service/internal/model"
+	"transaction-service/internal/repository"
+	"transaction-service/pkg/client"
+	pb "transaction-service/proto"
+)
+
+// Error yang dikembalikan CartService.
+var (
+	ErrInvalidCartQuantity = errors.New("quantity must be greater than zero")
+	ErrCartItemNotFound    = errors.New("book is not in the cart")
+	ErrCartEmpty           = errors.New("cart is empty")
+	ErrCartItemUnavailable = errors.New("some books in the cart are no longer available")
+)
+
+// CartService adalah interface untuk logika bisnis keranjang belanja.
+type CartService interface {
+	GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.CartResponse, error)
+	AddCartItem(ctx context.Context, req *pb.CartItemRequest) (*pb.CartResponse, error)
+	UpdateCartItem(ctx context.Context, req *pb.CartItemRequest) (*pb.CartResponse, error)
+	RemoveCartItem(ctx context.Context, req *pb.RemoveCartItemRequest) (*pb.CartResponse, error)
+	CheckoutCart(ctx context.Context, req *pb.CheckoutCartRequest) (*pb.TransactionResponse, error)
+}
+
+type cartService struct {
+	repo         repository.CartRepository
+	bookClient   client.BookServiceClient
+	transactions TransactionService // Dipakai saat checkout agar alur transaksi tetap satu pintu
+}
+
+// NewCartService adalah constructor untuk cart service.
+func NewCartService(repo repository.CartRepository, bookClient client.BookServiceClient, transactions TransactionService) CartService {
+	return &cartService{
+		repo:         repo,
+		bookClient:   bookClient,
+		transactions: transactions,
+	}
+}
+
+// GetCart mengambil keranjang user dan memvalidasi ulang setiap buku ke book-service.
+func (s *cartService) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.CartResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidUserID
+	}
+	return s.loadCart(ctx, uint(userID))
+}
+
+// AddCartItem menambahkan buku ke keranjang. Buku harus ada dan tersedia;
+// jika buku sudah ada di keranjang, quantity-nya dijumlahkan.
+func (s *cartService) AddCartItem(ctx context.Context, req *pb.CartItemRequest) (*pb.CartResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidUserID
+	}
+	if req.Quantity <= 0 {
+		return nil, ErrInvalidCartQuantity
+	}
+
+	book, err := s.bookClient.GetBookByID(ctx, req.BookId)
+	if err != nil || book.Status != "available" {
+		return nil, ErrCartItemUnavailable
+	}
+
+	item := &model.CartItem{
+		UserID:     uint(userID),
+		BookID:     book.ID,
+		Quantity:   int(req.Quantity),
+		PriceAtAdd: book.Price,
+	}
+	if err := s.repo.AddItem(ctx, item); err != nil {
+		log.Printf("Failed to add book %s to cart of user %d: %v", req.BookId, userID, err)
+		return nil, errors.New("failed to update cart")
+	}
+	return s.loadCart(ctx, uint(userID))
+}
+
+// UpdateCartItem mengganti quantity satu buku yang sudah ada di keranjang.
+func (s *cartService) UpdateCartItem(ctx context.Context, req *pb.CartItemRequest) (*pb.CartResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidUserID
+	}
+	if req.Quantity <= 0 {
+		return nil, ErrInvalidCartQuantity
+	}
+
+	found, err := s.repo.SetQuantity(ctx, uint(userID), req.BookId, int(req.Quantity))
+	if err != nil {
+		log.Printf("Failed to update book %s in cart of user %d: %v", req.BookId, userID, err)
+		return nil, errors.New("failed to update cart")
+	}
+	if !found {
+		return nil, ErrCartItemNotFound
+	}
+	return s.loadCart(ctx, uint(userID))
+}
+
+// RemoveCartItem menghapus satu buku dari keranjang.
+func (s *cartService) RemoveCartItem(ctx context.Context, req *pb.RemoveCartItemRequest) (*pb.CartResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidUserID
+	}
+
+	found, err := s.repo.RemoveItem(ctx, uint(userID), req.BookId)
+	if err != nil {
+		log.Printf("Failed to remove book %s from cart of user %d: %v", req.BookId, userID, err)
+		return nil, errors.New("failed to update cart")
+	}
+	if !found {
+		return nil, ErrCartItemNotFound
+	}
+	return s.loadCart(ctx, uint(userID))
+}
+
+// CheckoutCart mengubah isi keranjang menjadi CreateTransactionRequest. Checkout ditolak
+// jika keranjang kosong atau ada buku yang tidak tersedia; keranjang dikosongkan
+// hanya setelah transaksi berhasil dibuat.
+func (s *cartService) CheckoutCart(ctx context.Context, req *pb.CheckoutCartRequest) (*pb.TransactionResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidUserID
+	}
+
+	cart, err := s.loadCart(ctx, uint(userID))
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, ErrCartEmpty
+	}
+
+	items := make([]*pb.BookOrderItem, len(cart.Items))
+	for i, item := range cart.Items {
+		if !item.Available {
+			return nil, ErrCartItemUnavailable
+		}
+		items[i] = &pb.BookOrderItem{BookId: item.BookId, Quantity: item.Quantity}
+	}
+
+	response, err := s.transactions.CreateTransaction(ctx, &pb.CreateTransactionRequest{
+		UserId:     req.UserId,
+		Items:      items,
+		CouponCode: req.CouponCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Transaksi sudah tercatat; gagal mengosongkan keranjang tidak membatalkan checkout
+	if err := s.repo.Clear(ctx, uint(userID)); err != nil {
+		log.Printf("Failed to clear cart of user %d after transaction %s: %v", userID, response.TransactionId, err)
+	}
+	return response, nil
+}
+
+// loadCart membaca keranjang dari database lalu mengisi harga terbaru dan status
+// ketersediaan setiap buku. Total hanya menghitung buku yang masih tersedia.
+func (s *cartService) loadCart(ctx context.Context, userID uint) (*pb.CartResponse, error) {
+	items, err := s.repo.GetItems(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to get cart of user %d: %v", userID, err)
+		return nil, errors.New("failed to get cart")
+	}
+
+	var totalAmount money.Money
+	cartItems := make([]*pb.CartItem, len(items))
+	for i, item := range items {
+		cartItem := &pb.CartItem{
+			BookId:       item.BookID,
+			Quantity:     int32(item.Quantity),
+			PricePerUnit: item.PriceAtAdd.ToProto(),
+			PriceAtAdd:   item.PriceAtAdd.ToProto(),
+			Subtotal:     item.PriceAtAdd.Mul(int64(item.Quantity)).ToProto(),
+		}
+
+		// Buku yang gagal diambil (dihapus atau book-service bermasalah) ditandai tidak tersedia
+		book, err := s.bookClient.GetBookByID(ctx, item.BookID)
+		if err == nil {
+			subtotal := book.Price.Mul(int64(item.Quantity))
+			cartItem.Title = book.Title
+			cartItem.PricePerUnit = book.Price.ToProto()
+			cartItem.Subtotal = subtotal.ToProto()
+			cartItem.Available = book.Status == "available"
+			cartItem.PriceChanged = book.Price != item.PriceAtAdd
+			if cartItem.Available {
+				totalAmount += subtotal
+			}
+		}
+		cartItems[i] = cartItem
+	}
+
+	return &pb.CartResponse{
+		UserId:      strconv.FormatUint(uint64(userID), 10),
+		Items:       cartItems,
+		TotalAmount: totalAmount.ToProto(),
+	}, nil
+}
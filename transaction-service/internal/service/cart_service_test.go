@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"shared/money"
+	"testing"
+	"transaction-service/internal/model"
+	"transaction-service/internal/repository"
+	"transaction-service/pkg/client"
+	pb "transaction-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Skenario 1: Tes GetCart menandai harga yang berubah dan buku yang tidak tersedia
+func TestGetCart_RevalidatesItems(t *testing.T) {
+	// --- Arrange ---
+	mockCartRepo := new(repository.MockCartRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+
+	mockCartRepo.On("GetItems", mock.Anything, uint(1)).Return([]model.CartItem{
+		{UserID: 1, BookID: "101", Quantity: 2, PriceAtAdd: money.FromRupiah(50000)},
+		{UserID: 1, BookID: "102", Quantity: 1, PriceAtAdd: money.FromRupiah(30000)},
+		{UserID: 1, BookID: "103", Quantity: 1, PriceAtAdd: money.FromRupiah(20000)},
+	}, nil)
+	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Title: "Buku A", Status: "available", Price: money.FromRupiah(60000)}, nil)
+	mockBookClient.On("GetBookByID", mock.Anything, "102").Return(&client.BookDTO{ID: "102", Title: "Buku B", Status: "unavailable", Price: money.FromRupiah(30000)}, nil)
+	mockBookClient.On("GetBookByID", mock.Anything, "103").Return(nil, errors.New("book not found"))
+
+	cartService := NewCartService(mockCartRepo, mockBookClient, nil)
+
+	// --- Act ---
+	result, err := cartService.GetCart(context.Background(), &pb.GetCartRequest{UserId: "1"})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 3)
+	assert.True(t, result.Items[0].Available)
+	assert.True(t, result.Items[0].PriceChanged)
+	assert.Equal(t, money.FromRupiah(120000).Minor(), result.Items[0].Subtotal.MinorUnits)
+	assert.False(t, result.Items[1].Available)
+	assert.False(t, result.Items[2].Available)
+	// Hanya buku yang tersedia yang dihitung ke total
+	assert.Equal(t, money.FromRupiah(120000).Minor(), result.TotalAmount.MinorUnits)
+	mockCartRepo.AssertExpectations(t)
+	mockBookClient.AssertExpectations(t)
+}
+
+// Skenario 2: Tes AddCartItem menyimpan harga buku saat ini dan menolak buku yang tidak tersedia
+func TestAddCartItem(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockCartRepo := new(repository.MockCartRepository)
+		mockBookClient := new(client.MockBookServiceClient)
+		book := &client.BookDTO{ID: "101", Title: "Buku A", Status: "available", Price: money.FromRupiah(50000)}
+
+		mockBookClient.On("GetBookByID", mock.Anything, "101").Return(book, nil)
+		mockCartRepo.On("AddItem", mock.Anything, &model.CartItem{UserID: 1, BookID: "101", Quantity: 2, PriceAtAdd: money.FromRupiah(50000)}).Return(nil)
+		mockCartRepo.On("GetItems", mock.Anything, uint(1)).Return([]model.CartItem{
+			{UserID: 1, BookID: "101", Quantity: 3, PriceAtAdd: money.FromRupiah(50000)},
+		}, nil)
+
+		cartService := NewCartService(mockCartRepo, mockBookClient, nil)
+		result, err := cartService.AddCartItem(context.Background(), &pb.CartItemRequest{UserId: "1", BookId: "101", Quantity: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), result.Items[0].Quantity)
+		mockCartRepo.AssertExpectations(t)
+	})
+
+	t.Run("unavailable book", func(t *testing.T) {
+		mockCartRepo := new(repository.MockCartRepository)
+		mockBookClient := new(client.MockBookServiceClient)
+		mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Status: "unavailable"}, nil)
+
+		cartService := NewCartService(mockCartRepo, mockBookClient, nil)
+		result, err := cartService.AddCartItem(context.Background(), &pb.CartItemRequest{UserId: "1", BookId: "101", Quantity: 1})
+
+		assert.ErrorIs(t, err, ErrCartItemUnavailable)
+		assert.Nil(t, result)
+		mockCartRepo.AssertNotCalled(t, "AddItem", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid quantity", func(t *testing.T) {
+		cartService := NewCartService(new(repository.MockCartRepository), new(client.MockBookServiceClient), nil)
+		_, err := cartService.AddCartItem(context.Background(), &pb.CartItemRequest{UserId: "1", BookId: "101", Quantity: 0})
+
+		assert.ErrorIs(t, err, ErrInvalidCartQuantity)
+	})
+}
+
+// Skenario 3: Tes UpdateCartItem untuk buku yang tidak ada di keranjang
+func TestUpdateCartItem_NotFound(t *testing.T) {
+	mockCartRepo := new(repository.MockCartRepository)
+	mockCartRepo.On("SetQuantity", mock.Anything, uint(1), "999", 4).Return(false, nil)
+
+	cartService := NewCartService(mockCartRepo, nil, nil)
+	result, err := cartService.UpdateCartItem(context.Background(), &pb.CartItemRequest{UserId: "1", BookId: "999", Quantity: 4})
+
+	assert.ErrorIs(t, err, ErrCartItemNotFound)
+	assert.Nil(t, result)
+	mockCartRepo.AssertExpectations(t)
+}
+
+// Skenario 4: Tes CheckoutCart membuat transaksi dari isi keranjang lalu mengosongkannya
+func TestCheckoutCart_Success(t *testing.T) {
+	// --- Arrange ---
+	mockCartRepo := new(repository.MockCartRepository)
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+
+	mockCartRepo.On("GetItems", mock.Anything, uint(1)).Return([]model.CartItem{
+		{UserID: 1, BookID: "101", Quantity: 2, PriceAtAdd: money.FromRupiah(50000)},
+	}, nil)
+	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Status: "available", Price: money.FromRupiah(50000)}, nil)
+	mockBookClient.On("ReserveStock", mock.Anything, mock.Anything, []client.StockItem{{BookID: "101", Quantity: 2}}).Return(nil)
+	mockRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*model.Transaction"), mock.Anything).
+		Return(&model.Transaction{ID: 99, UserID: 1, TotalAmount: money.FromRupiah(100000), Status: "pending"}, nil)
+	mockCartRepo.On("Clear", mock.Anything, uint(1)).Return(nil)
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, newAuthorizingWallet(), nil, nil, 0)
+	cartService := NewCartService(mockCartRepo, mockBookClient, transactionService)
+
+	// --- Act ---
+	result, err := cartService.CheckoutCart(context.Background(), &pb.CheckoutCartRequest{UserId: "1"})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, "99", result.TransactionId)
+	mockCartRepo.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+// Skenario 5: Tes CheckoutCart ditolak untuk keranjang kosong atau berisi buku yang tidak tersedia
+func TestCheckoutCart_Rejected(t *testing.T) {
+	t.Run("empty cart", func(t *testing.T) {
+		mockCartRepo := new(repository.MockCartRepository)
+		mockCartRepo.On("GetItems", mock.Anything, uint(1)).Return([]model.CartItem{}, nil)
+
+		cartService := NewCartService(mockCartRepo, nil, nil)
+		result, err := cartService.CheckoutCart(context.Background(), &pb.CheckoutCartRequest{UserId: "1"})
+
+		assert.ErrorIs(t, err, ErrCartEmpty)
+		assert.Nil(t, result)
+	})
+
+	t.Run("unavailable book", func(t *testing.T) {
+		mockCartRepo := new(repository.MockCartRepository)
+		mockBookClient := new(client.MockBookServiceClient)
+		mockCartRepo.On("GetItems", mock.Anything, uint(1)).Return([]model.CartItem{
+			{UserID: 1, BookID: "101", Quantity: 1, PriceAtAdd: money.FromRupiah(50000)},
+		}, nil)
+		mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Status: "unavailable"}, nil)
+
+		cartService := NewCartService(mockCartRepo, mockBookClient, nil)
+		result, err := cartService.CheckoutCart(context.Background(), &pb.CheckoutCartRequest{UserId: "1"})
+
+		assert.ErrorIs(t, err, ErrCartItemUnavailable)
+		assert.Nil(t, result)
+		mockCartRepo.AssertNotCalled(t, "Clear", mock.Anything, mock.Anything)
+	})
+}
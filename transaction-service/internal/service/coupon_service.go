@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"shared/money"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"transaction-service/internal/model"
+	"transaction-service/internal/repository"
+	pb "transaction-service/proto"
+)
+
+// Error yang dikembalikan saat kupon dipakai di CreateTransaction.
+var (
+	ErrCouponInvalid           = errors.New("coupon code is not valid")
+	ErrCouponNotActive         = errors.New("coupon is not valid at this time")
+	ErrCouponMinOrderNotMet    = errors.New("order total is below the coupon minimum")
+	ErrCouponNotApplicable     = errors.New("coupon does not apply to any book in this order")
+	ErrCouponUsageLimitReached = repository.ErrCouponUsageLimitReached
+	ErrCouponUserLimitReached  = repository.ErrCouponUserLimitReached
+)
+
+// Error yang dikembalikan CouponService.
+var (
+	ErrCouponNotFound          = errors.New("coupon not found")
+	ErrCouponCodeExists        = repository.ErrCouponCodeExists
+	ErrInvalidCouponDefinition = errors.New("invalid coupon")
+)
+
+// CouponService adalah interface untuk manajemen kupon oleh admin.
+type CouponService interface {
+	CreateCoupon(ctx context.Context, req *pb.CreateCouponRequest) (*pb.Coupon, error)
+	UpdateCoupon(ctx context.Context, req *pb.UpdateCouponRequest) (*pb.Coupon, error)
+	DeleteCoupon(ctx context.Context, req *pb.DeleteCouponRequest) (*pb.DeleteCouponResponse, error)
+	GetCoupon(ctx context.Context, req *pb.GetCouponRequest) (*pb.Coupon, error)
+	ListCoupons(ctx context.Context, req *pb.ListCouponsRequest) (*pb.ListCouponsResponse, error)
+}
+
+type couponService struct {
+	repo repository.CouponRepository
+}
+
+// NewCouponService adalah constructor untuk coupon service.
+func NewCouponService(repo repository.CouponRepository) CouponService {
+	return &couponService{repo: repo}
+}
+
+// CreateCoupon memvalidasi lalu menyimpan kupon baru.
+func (s *couponService) CreateCoupon(ctx context.Context, req *pb.CreateCouponRequest) (*pb.Coupon, error) {
+	coupon, err := couponFromProto(req.Coupon)
+	if err != nil {
+		return nil, err
+	}
+	coupon.ID = 0
+
+	if err := s.repo.Create(ctx, coupon); err != nil {
+		if errors.Is(err, ErrCouponCodeExists) {
+			return nil, err
+		}
+		log.Printf("Failed to create coupon %s: %v", coupon.Code, err)
+		return nil, errors.New("failed to create coupon")
+	}
+	return toCouponProto(coupon), nil
+}
+
+// UpdateCoupon mengganti definisi kupon. Jumlah pemakaian tidak ikut diubah.
+func (s *couponService) UpdateCoupon(ctx context.Context, req *pb.UpdateCouponRequest) (*pb.Coupon, error) {
+	if req.Coupon == nil {
+		return nil, fmt.Errorf("%w: coupon is required", ErrInvalidCouponDefinition)
+	}
+	existing, err := s.findCoupon(ctx, req.Coupon.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	coupon, err := couponFromProto(req.Coupon)
+	if err != nil {
+		return nil, err
+	}
+	coupon.ID = existing.ID
+	coupon.UsedCount = existing.UsedCount
+	coupon.CreatedAt = existing.CreatedAt
+
+	if err := s.repo.Update(ctx, coupon); err != nil {
+		if errors.Is(err, ErrCouponCodeExists) {
+			return nil, err
+		}
+		log.Printf("Failed to update coupon %d: %v", coupon.ID, err)
+		return nil, errors.New("failed to update coupon")
+	}
+	return toCouponProto(coupon), nil
+}
+
+// DeleteCoupon menghapus kupon. Transaksi yang sudah memakai kupon tidak terpengaruh.
+func (s *couponService) DeleteCoupon(ctx context.Context, req *pb.DeleteCouponRequest) (*pb.DeleteCouponResponse, error) {
+	id, err := strconv.ParseUint(req.CouponId, 10, 32)
+	if err != nil {
+		return nil, ErrCouponNotFound
+	}
+	deleted, err := s.repo.Delete(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return nil, ErrCouponNotFound
+	}
+	return &pb.DeleteCouponResponse{}, nil
+}
+
+// GetCoupon mengambil satu kupon.
+func (s *couponService) GetCoupon(ctx context.Context, req *pb.GetCouponRequest) (*pb.Coupon, error) {
+	coupon, err := s.findCoupon(ctx, req.CouponId)
+	if err != nil {
+		return nil, err
+	}
+	return toCouponProto(coupon), nil
+}
+
+// ListCoupons mengambil semua kupon.
+func (s *couponService) ListCoupons(ctx context.Context, req *pb.ListCouponsRequest) (*pb.ListCouponsResponse, error) {
+	coupons, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListCouponsResponse{Coupons: make([]*pb.Coupon, len(coupons))}
+	for i := range coupons {
+		response.Coupons[i] = toCouponProto(&coupons[i])
+	}
+	return response, nil
+}
+
+func (s *couponService) findCoupon(ctx context.Context, couponID string) (*model.Coupon, error) {
+	id, err := strconv.ParseUint(couponID, 10, 32)
+	if err != nil {
+		return nil, ErrCouponNotFound
+	}
+	coupon, err := s.repo.FindByID(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	if coupon == nil {
+		return nil, ErrCouponNotFound
+	}
+	return coupon, nil
+}
+
+// couponDiscount menghitung potongan harga kupon untuk sebuah pesanan. Minimum order
+// dibandingkan dengan subtotal seluruh pesanan, sedangkan diskon hanya dihitung dari
+// buku yang lolos batasan buku/kategori kupon. Batas pemakaian per user dicek ulang
+// secara atomik oleh repository saat transaksi disimpan.
+func couponDiscount(coupon *model.Coupon, details []model.TransactionDetail, subtotal money.Money, now time.Time) (money.Money, error) {
+	if !coupon.Active {
+		return 0, ErrCouponInvalid
+	}
+	if (coupon.StartsAt != nil && now.Before(*coupon.StartsAt)) || (coupon.EndsAt != nil && !now.Before(*coupon.EndsAt)) {
+		return 0, ErrCouponNotActive
+	}
+	if coupon.UsageLimit > 0 && coupon.UsedCount >= coupon.UsageLimit {
+		return 0, ErrCouponUsageLimitReached
+	}
+	if subtotal < coupon.MinOrderAmount {
+		return 0, ErrCouponMinOrderNotMet
+	}
+
+	var eligible money.Money
+	for _, detail := range details {
+		if couponAppliesTo(coupon, detail.BookID, detail.Category) {
+			eligible += detail.PricePerUnit.Mul(int64(detail.Quantity))
+		}
+	}
+	if eligible == 0 {
+		return 0, ErrCouponNotApplicable
+	}
+
+	var discount money.Money
+	switch coupon.Type {
+	case model.CouponTypePercentage:
+		discount = money.FromMinor(eligible.Minor() * int64(coupon.PercentOff) / 100)
+	case model.CouponTypeFixed:
+		discount = coupon.AmountOff
+	}
+	if discount > eligible {
+		discount = eligible
+	}
+	return discount, nil
+}
+
+// couponAppliesTo bernilai true jika kupon tanpa batasan, atau buku cocok dengan
+// salah satu buku atau kategori yang diizinkan kupon.
+func couponAppliesTo(coupon *model.Coupon, bookID, category string) bool {
+	if len(coupon.BookIDs) == 0 && len(coupon.Categories) == 0 {
+		return true
+	}
+	for _, id := range coupon.BookIDs {
+		if id == bookID {
+			return true
+		}
+	}
+	for _, c := range coupon.Categories {
+		if strings.EqualFold(c, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// couponFromProto mengubah kupon dari request admin menjadi model dan memvalidasinya.
+func couponFromProto(in *pb.Coupon) (*model.Coupon, error) {
+	if in == nil {
+		return nil, fmt.Errorf("%w: coupon is required", ErrInvalidCouponDefinition)
+	}
+
+	coupon := &model.Coupon{
+		Code:         strings.ToUpper(strings.TrimSpace(in.Code)),
+		Type:         in.Type,
+		PercentOff:   int(in.PercentOff),
+		BookIDs:      in.BookIds,
+		Categories:   in.Categories,
+		UsageLimit:   int(in.UsageLimit),
+		PerUserLimit: int(in.PerUserLimit),
+		Active:       in.Active,
+	}
+	var err error
+	if in.AmountOff != nil {
+		if coupon.AmountOff, err = money.FromProto(in.AmountOff); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCouponDefinition, err)
+		}
+	}
+	if in.MinOrderAmount != nil {
+		if coupon.MinOrderAmount, err = money.FromProto(in.MinOrderAmount); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCouponDefinition, err)
+		}
+	}
+	if in.StartsAt != nil {
+		startsAt := in.StartsAt.AsTime()
+		coupon.StartsAt = &startsAt
+	}
+	if in.EndsAt != nil {
+		endsAt := in.EndsAt.AsTime()
+		coupon.EndsAt = &endsAt
+	}
+
+	switch {
+	case coupon.Code == "":
+		return nil, fmt.Errorf("%w: code is required", ErrInvalidCouponDefinition)
+	case coupon.Type == model.CouponTypePercentage && (coupon.PercentOff < 1 || coupon.PercentOff > 100):
+		return nil, fmt.Errorf("%w: percent_off must be between 1 and 100", ErrInvalidCouponDefinition)
+	case coupon.Type == model.CouponTypeFixed && !coupon.AmountOff.IsPositive():
+		return nil, fmt.Errorf("%w: amount_off must be positive", ErrInvalidCouponDefinition)
+	case coupon.Type != model.CouponTypePercentage && coupon.Type != model.CouponTypeFixed:
+		return nil, fmt.Errorf("%w: type must be percentage or fixed", ErrInvalidCouponDefinition)
+	case coupon.MinOrderAmount.IsNegative(), coupon.UsageLimit < 0, coupon.PerUserLimit < 0:
+		return nil, fmt.Errorf("%w: limits must not be negative", ErrInvalidCouponDefinition)
+	case coupon.StartsAt != nil && coupon.EndsAt != nil && !coupon.StartsAt.Before(*coupon.EndsAt):
+		return nil, fmt.Errorf("%w: starts_at must be before ends_at", ErrInvalidCouponDefinition)
+	}
+	return coupon, nil
+}
+
+// toCouponProto mengubah model kupon menjadi pesan gRPC.
+func toCouponProto(coupon *model.Coupon) *pb.Coupon {
+	out := &pb.Coupon{
+		Id:             strconv.FormatUint(uint64(coupon.ID), 10),
+		Code:           coupon.Code,
+		Type:           coupon.Type,
+		PercentOff:     int32(coupon.PercentOff),
+		AmountOff:      coupon.AmountOff.ToProto(),
+		MinOrderAmount: coupon.MinOrderAmount.ToProto(),
+		BookIds:        coupon.BookIDs,
+		Categories:     coupon.Categories,
+		UsageLimit:     int32(coupon.UsageLimit),
+		PerUserLimit:   int32(coupon.PerUserLimit),
+		UsedCount:      int32(coupon.UsedCount),
+		Active:         coupon.Active,
+	}
+	if coupon.StartsAt != nil {
+		out.StartsAt = timestamppb.New(*coupon.StartsAt)
+	}
+	if coupon.EndsAt != nil {
+		out.EndsAt = timestamppb.New(*coupon.EndsAt)
+	}
+	return out
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"shared/events"
+	"shared/money"
+	"testing"
+	"time"
+	"transaction-service/internal/model"
+	"transaction-service/internal/repository"
+	"transaction-service/pkg/client"
+	pb "transaction-service/proto"
+	walletMocks "transaction-service/proto/mocks"
+	wallet_pb "wallet-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Skenario 1: Tes perhitungan diskon untuk berbagai aturan kupon
+func TestCouponDiscount(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+
+	// Pesanan: buku fiksi 2 x 50.000 dan buku sains 1 x 100.000, subtotal 200.000
+	details := []model.TransactionDetail{
+		{BookID: "101", Category: "Fiction", Quantity: 2, PricePerUnit: money.FromRupiah(50000)},
+		{BookID: "102", Category: "Science", Quantity: 1, PricePerUnit: money.FromRupiah(100000)},
+	}
+	subtotal := money.FromRupiah(200000)
+
+	tests := []struct {
+		name    string
+		coupon  model.Coupon
+		want    money.Money
+		wantErr error
+	}{
+		{"percentage", model.Coupon{Type: "percentage", PercentOff: 10, Active: true}, money.FromRupiah(20000), nil},
+		{"fixed", model.Coupon{Type: "fixed", AmountOff: money.FromRupiah(15000), Active: true}, money.FromRupiah(15000), nil},
+		{"fixed capped at eligible amount", model.Coupon{Type: "fixed", AmountOff: money.FromRupiah(500000), Active: true}, subtotal, nil},
+		{"category restriction", model.Coupon{Type: "percentage", PercentOff: 50, Categories: []string{"fiction"}, Active: true}, money.FromRupiah(50000), nil},
+		{"book restriction", model.Coupon{Type: "percentage", PercentOff: 50, BookIDs: []string{"102"}, Active: true}, money.FromRupiah(50000), nil},
+		{"not applicable", model.Coupon{Type: "percentage", PercentOff: 50, Categories: []string{"History"}, Active: true}, 0, ErrCouponNotApplicable},
+		{"below minimum order", model.Coupon{Type: "percentage", PercentOff: 10, MinOrderAmount: money.FromRupiah(250000), Active: true}, 0, ErrCouponMinOrderNotMet},
+		{"not started", model.Coupon{Type: "percentage", PercentOff: 10, StartsAt: &tomorrow, Active: true}, 0, ErrCouponNotActive},
+		{"ended", model.Coupon{Type: "percentage", PercentOff: 10, EndsAt: &yesterday, Active: true}, 0, ErrCouponNotActive},
+		{"inactive", model.Coupon{Type: "percentage", PercentOff: 10}, 0, ErrCouponInvalid},
+		{"usage limit reached", model.Coupon{Type: "percentage", PercentOff: 10, UsageLimit: 5, UsedCount: 5, Active: true}, 0, ErrCouponUsageLimitReached},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := couponDiscount(&tt.coupon, details, subtotal, now)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// Skenario 2: Tes CreateTransaction dengan kupon mencatat diskon sebagai baris terpisah
+func TestCreateTransaction_WithCoupon(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+	mockCouponRepo := new(repository.MockCouponRepository)
+
+	req := &pb.CreateTransactionRequest{
+		UserId:     "1",
+		Items:      []*pb.BookOrderItem{{BookId: "101", Quantity: 2}},
+		CouponCode: "hemat10",
+	}
+	coupon := &model.Coupon{ID: 7, Code: "HEMAT10", Type: "percentage", PercentOff: 10, Active: true}
+
+	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Status: "available", Price: money.FromRupiah(50000)}, nil)
+	mockCouponRepo.On("FindByCode", mock.Anything, "HEMAT10").Return(coupon, nil)
+	mockBookClient.On("ReserveStock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateTransaction", mock.Anything, mock.MatchedBy(func(tx *model.Transaction) bool {
+		return tx.TotalAmount == money.FromRupiah(90000) && tx.DiscountAmount == money.FromRupiah(10000) &&
+			tx.CouponID != nil && *tx.CouponID == 7 && tx.CouponCode == "HEMAT10"
+	}), mock.Anything).Return(&model.Transaction{
+		ID: 99, UserID: 1, Status: "pending",
+		TotalAmount: money.FromRupiah(90000), DiscountAmount: money.FromRupiah(10000), CouponCode: "HEMAT10",
+	}, nil)
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, newAuthorizingWallet(), nil, mockCouponRepo, 0)
+
+	// --- Act ---
+	result, err := transactionService.CreateTransaction(context.Background(), req)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromRupiah(90000).Minor(), result.TotalAmount.MinorUnits)
+	assert.Equal(t, money.FromRupiah(100000).Minor(), result.SubtotalAmount.MinorUnits)
+	assert.Equal(t, "HEMAT10", result.Discount.CouponCode)
+	assert.Equal(t, money.FromRupiah(10000).Minor(), result.Discount.Amount.MinorUnits)
+	mockRepo.AssertExpectations(t)
+	mockCouponRepo.AssertExpectations(t)
+}
+
+// Skenario 2b: Tes checkout dengan kupon 100% selesai tanpa hold maupun debit wallet:
+// stok di-commit, buku masuk library, dan penukaran kupon tetap tercatat
+func TestCreateTransaction_FullDiscountCompletes(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+	mockCouponRepo := new(repository.MockCouponRepository)
+	mockWalletClient := new(walletMocks.MockWalletServiceClient)
+
+	req := &pb.CreateTransactionRequest{
+		UserId:     "1",
+		Items:      []*pb.BookOrderItem{{BookId: "101", Quantity: 1}},
+		CouponCode: "GRATIS",
+	}
+	coupon := &model.Coupon{ID: 8, Code: "GRATIS", Type: "percentage", PercentOff: 100, Active: true}
+	couponID := coupon.ID
+	saved := &model.Transaction{ID: 99, UserID: 1, Status: model.StatusPending, DiscountAmount: money.FromRupiah(50000), CouponID: &couponID, CouponCode: "GRATIS"}
+
+	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Status: "available", Price: money.FromRupiah(50000)}, nil)
+	mockCouponRepo.On("FindByCode", mock.Anything, "GRATIS").Return(coupon, nil)
+	mockBookClient.On("ReserveStock", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(nil)
+	mockRepo.On("CreateTransaction", mock.Anything, mock.MatchedBy(func(tx *model.Transaction) bool {
+		return tx.TotalAmount == 0 && tx.DiscountAmount == money.FromRupiah(50000) && tx.HoldID == "" &&
+			tx.CouponID != nil && *tx.CouponID == 8
+	}), mock.MatchedBy(func(newEvent repository.OutboxEventFunc) bool {
+		// Event tetap dikirim sebagai cadangan; wallet-service membalasnya tanpa mendebit
+		event, err := newEvent(saved)
+		if err != nil {
+			return false
+		}
+		envelope, err := events.Unmarshal(event.Payload)
+		return err == nil && envelope.GetTransactionCreated().GetTotalAmount().GetMinorUnits() == 0 &&
+			envelope.GetTransactionCreated().GetHoldId() == ""
+	})).Return(saved, nil)
+	mockRepo.On("CompletePendingTransaction", mock.Anything, uint(99)).Return(true, nil).Once()
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).
+		Return(&model.Transaction{ID: 99, UserID: 1, Status: model.StatusCompleted, ReservationID: "res-99", CouponID: &couponID}, nil)
+	mockBookClient.On("CommitReservation", mock.Anything, "res-99").Return(nil).Once()
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, mockWalletClient, nil, mockCouponRepo, 0)
+
+	// --- Act ---
+	result, err := transactionService.CreateTransaction(context.Background(), req)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusCompleted, result.Status)
+	assert.Equal(t, int64(0), result.TotalAmount.MinorUnits)
+	assert.Equal(t, "GRATIS", result.Discount.CouponCode)
+	mockRepo.AssertExpectations(t)
+	mockBookClient.AssertExpectations(t)
+	mockWalletClient.AssertNotCalled(t, "Authorize", mock.Anything, mock.Anything)
+	mockCouponRepo.AssertNotCalled(t, "ReleaseRedemption", mock.Anything, mock.Anything)
+}
+
+// Skenario 3: Tes kuota kupon habis saat disimpan, stok yang ditahan dikembalikan
+func TestCreateTransaction_CouponUserLimitReached(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+	mockCouponRepo := new(repository.MockCouponRepository)
+
+	req := &pb.CreateTransactionRequest{
+		UserId:     "1",
+		Items:      []*pb.BookOrderItem{{BookId: "101", Quantity: 1}},
+		CouponCode: "HEMAT10",
+	}
+
+	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Status: "available", Price: money.FromRupiah(50000)}, nil)
+	mockCouponRepo.On("FindByCode", mock.Anything, "HEMAT10").Return(&model.Coupon{ID: 7, Code: "HEMAT10", Type: "percentage", PercentOff: 10, PerUserLimit: 1, Active: true}, nil)
+	mockBookClient.On("ReserveStock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateTransaction", mock.Anything, mock.Anything, mock.Anything).Return(nil, repository.ErrCouponUserLimitReached)
+	mockBookClient.On("ReleaseReservation", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, newAuthorizingWallet(), nil, mockCouponRepo, 0)
+
+	// --- Act ---
+	result, err := transactionService.CreateTransaction(context.Background(), req)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, ErrCouponUserLimitReached)
+	assert.Nil(t, result)
+	mockBookClient.AssertExpectations(t)
+}
+
+// Skenario 4: Tes pembayaran gagal mengembalikan kuota kupon
+func TestFailTransaction_ReleasesCoupon(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockCouponRepo := new(repository.MockCouponRepository)
+	couponID := uint(7)
+
+	mockRepo.On("FailPendingTransaction", mock.Anything, uint(99), "insufficient_funds", "insufficient funds").Return(true, nil)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, Status: "cancelled", CouponID: &couponID, CouponCode: "HEMAT10"}, nil)
+	mockCouponRepo.On("ReleaseRedemption", mock.Anything, uint(99)).Return(nil)
+
+	transactionService := NewTransactionService(mockRepo, nil, nil, nil, mockCouponRepo, 0)
+
+	// --- Act ---
+	err := transactionService.FailTransaction(context.Background(), "99", "insufficient_funds", "insufficient funds")
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	mockCouponRepo.AssertExpectations(t)
+}
+
+// Skenario 5: Tes CreateCoupon memvalidasi definisi kupon
+func TestCreateCoupon(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockCouponRepo := new(repository.MockCouponRepository)
+		mockCouponRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *model.Coupon) bool {
+			return c.Code == "HEMAT10" && c.PercentOff == 10 && c.Active
+		})).Return(nil)
+
+		couponService := NewCouponService(mockCouponRepo)
+		result, err := couponService.CreateCoupon(context.Background(), &pb.CreateCouponRequest{
+			Coupon: &pb.Coupon{Code: " hemat10 ", Type: "percentage", PercentOff: 10, Active: true},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "HEMAT10", result.Code)
+		mockCouponRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid definition", func(t *testing.T) {
+		mockCouponRepo := new(repository.MockCouponRepository)
+		couponService := NewCouponService(mockCouponRepo)
+
+		invalid := []*pb.Coupon{
+			{Code: "", Type: "percentage", PercentOff: 10},
+			{Code: "A", Type: "percentage", PercentOff: 150},
+			{Code: "B", Type: "fixed"},
+			{Code: "C", Type: "bogo"},
+		}
+		for _, coupon := range invalid {
+			_, err := couponService.CreateCoupon(context.Background(), &pb.CreateCouponRequest{Coupon: coupon})
+			assert.ErrorIs(t, err, ErrInvalidCouponDefinition, coupon.Code)
+		}
+		mockCouponRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+// Skenario 6: Tes UpdateCoupon mempertahankan jumlah pemakaian
+func TestUpdateCoupon_KeepsUsedCount(t *testing.T) {
+	mockCouponRepo := new(repository.MockCouponRepository)
+	mockCouponRepo.On("FindByID", mock.Anything, uint(7)).Return(&model.Coupon{ID: 7, Code: "HEMAT10", UsedCount: 3}, nil)
+	mockCouponRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *model.Coupon) bool {
+		return c.ID == 7 && c.UsedCount == 3 && c.PercentOff == 20
+	})).Return(nil)
+
+	couponService := NewCouponService(mockCouponRepo)
+	result, err := couponService.UpdateCoupon(context.Background(), &pb.UpdateCouponRequest{
+		Coupon: &pb.Coupon{Id: "7", Code: "HEMAT10", Type: "percentage", PercentOff: 20, Active: true},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), result.UsedCount)
+	mockCouponRepo.AssertExpectations(t)
+}
+
+// Skenario 7: Tes refund transaksi completed mengembalikan kuota kupon
+func TestCancelTransaction_RefundReleasesCoupon(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockWalletClient := new(walletMocks.MockWalletServiceClient)
+	mockCouponRepo := new(repository.MockCouponRepository)
+	couponID := uint(7)
+
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{
+		ID: 99, UserID: 1, Status: model.StatusCompleted, TotalAmount: money.FromRupiah(90000), CouponID: &couponID, CouponCode: "HEMAT10",
+	}, nil)
+	mockWalletClient.On("Credit", mock.Anything, mock.MatchedBy(func(req *wallet_pb.CreditRequest) bool {
+		return req.ReferenceId == "refund-99" && req.Refund
+	})).Return(&wallet_pb.CreditResponse{Success: true}, nil)
+	mockRepo.On("RefundTransaction", mock.Anything, uint(99), model.StatusCompleted, "duplicate order").Return(true, nil)
+	mockCouponRepo.On("ReleaseRedemption", mock.Anything, uint(99)).Return(nil).Once()
+
+	transactionService := NewTransactionService(mockRepo, nil, mockWalletClient, nil, mockCouponRepo, 0)
+
+	// --- Act ---
+	result, err := transactionService.CancelTransaction(context.Background(), &pb.CancelTransactionRequest{
+		TransactionId: "99", UserId: "7", IsAdmin: true, Reason: "duplicate order",
+	})
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusRefunded, result.Status)
+	mockRepo.AssertExpectations(t)
+	mockCouponRepo.AssertExpectations(t)
+}
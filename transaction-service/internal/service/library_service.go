@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"transaction-service/internal/model"
+	"transaction-service/internal/repository"
+	"transaction-service/pkg/client"
+	pb "transaction-service/proto"
+)
+
+// Error yang dikembalikan LibraryService.
+var (
+	ErrInvalidEntitlement  = errors.New("invalid entitlement")
+	ErrEntitlementConflict = errors.New("reference is already granted to another user")
+)
+
+// maxConcurrentBookLookups membatasi jumlah permintaan metadata ke book-service yang berjalan
+// bersamaan untuk satu halaman library.
+const maxConcurrentBookLookups = 8
+
+// grantableSources adalah sumber entitlement yang boleh dicatat lewat GrantEntitlement.
+// Entitlement pembelian hanya dibuat saat transaksi completed.
+var grantableSources = map[string]bool{
+	model.EntitlementSourceGift: true,
+}
+
+// LibraryService adalah interface untuk logika bisnis library ebook user.
+type LibraryService interface {
+	ListLibrary(ctx context.Context, req *pb.ListLibraryRequest) (*pb.ListLibraryResponse, error)
+	CheckOwnership(ctx context.Context, req *pb.CheckOwnershipRequest) (*pb.CheckOwnershipResponse, error)
+	GrantEntitlement(ctx context.Context, req *pb.GrantEntitlementRequest) (*pb.Entitlement, error)
+}
+
+type libraryService struct {
+	repo       repository.LibraryRepository
+	bookClient client.BookServiceClient
+}
+
+// NewLibraryService adalah constructor untuk library service.
+func NewLibraryService(repo repository.LibraryRepository, bookClient client.BookServiceClient) LibraryService {
+	return &libraryService{repo: repo, bookClient: bookClient}
+}
+
+// ListLibrary mengambil buku yang dimiliki user per halaman beserta metadatanya dari book-service.
+// Buku yang dimiliki lewat beberapa entitlement hanya muncul sekali. Jika book-service gagal
+// untuk satu buku, buku tersebut tetap ditampilkan tanpa metadata.
+func (s *libraryService) ListLibrary(ctx context.Context, req *pb.ListLibraryRequest) (*pb.ListLibraryResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidUserID
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	filter := repository.LibraryFilter{
+		UserID: uint(userID),
+		Limit:  pageSize + 1, // Satu baris ekstra untuk mengetahui apakah ada halaman berikutnya
+	}
+	if req.PageToken != "" {
+		cursor, err := decodeCursor(req.PageToken)
+		if err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		filter.After = (*repository.LibraryCursor)(cursor)
+	}
+
+	owned, err := s.repo.ListOwnedBooks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListLibraryResponse{UserId: req.UserId}
+	if len(owned) > pageSize {
+		owned = owned[:pageSize]
+		last := owned[pageSize-1]
+		response.NextPageToken = encodeCursor(repository.TransactionCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	response.Books = make([]*pb.LibraryBook, len(owned))
+	for i := range owned {
+		response.Books[i] = &pb.LibraryBook{Entitlement: toEntitlementResponse(&owned[i])}
+	}
+	s.loadBookMetadata(ctx, uint(userID), response.Books)
+	return response, nil
+}
+
+// loadBookMetadata mengisi metadata setiap buku dari book-service, paling banyak
+// maxConcurrentBookLookups permintaan sekaligus.
+func (s *libraryService) loadBookMetadata(ctx context.Context, userID uint, books []*pb.LibraryBook) {
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxConcurrentBookLookups)
+	for _, entry := range books {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(entry *pb.LibraryBook) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			bookID := entry.Entitlement.BookId
+			book, err := s.bookClient.GetBookByID(ctx, bookID)
+			if err != nil {
+				log.Printf("Failed to load book %s for library of user %d: %v", bookID, userID, err)
+				return
+			}
+			entry.Title = book.Title
+			entry.Author = book.Author
+			entry.Publisher = book.Publisher
+			entry.Category = book.Category
+			entry.Description = book.Description
+		}(entry)
+	}
+	wg.Wait()
+}
+
+// CheckOwnership mengecek apakah user memiliki buku lewat entitlement apa pun.
+func (s *libraryService) CheckOwnership(ctx context.Context, req *pb.CheckOwnershipRequest) (*pb.CheckOwnershipResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidUserID
+	}
+	if req.BookId == "" {
+		return nil, fmt.Errorf("%w: book_id is required", ErrInvalidEntitlement)
+	}
+
+	entitlement, err := s.repo.GetEntitlement(ctx, uint(userID), req.BookId)
+	if err != nil {
+		return nil, err
+	}
+	if entitlement == nil {
+		return &pb.CheckOwnershipResponse{Owned: false}, nil
+	}
+	return &pb.CheckOwnershipResponse{Owned: true, Entitlement: toEntitlementResponse(entitlement)}, nil
+}
+
+// GrantEntitlement mencatat entitlement dari luar alur pembelian, mis. hadiah yang diterima.
+// Pemanggilan ulang dengan reference_id dan book_id yang sama mengembalikan entitlement yang
+// sudah ada, kecuali jika entitlement tersebut milik user lain.
+func (s *libraryService) GrantEntitlement(ctx context.Context, req *pb.GrantEntitlementRequest) (*pb.Entitlement, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidUserID
+	}
+	if req.BookId == "" || req.ReferenceId == "" {
+		return nil, fmt.Errorf("%w: book_id and reference_id are required", ErrInvalidEntitlement)
+	}
+	if !grantableSources[req.Source] {
+		return nil, fmt.Errorf("%w: unsupported source %q", ErrInvalidEntitlement, req.Source)
+	}
+
+	entitlement, err := s.repo.GrantEntitlement(ctx, &model.Entitlement{
+		UserID:      uint(userID),
+		BookID:      req.BookId,
+		Source:      req.Source,
+		ReferenceID: req.ReferenceId,
+	})
+	if err != nil {
+		log.Printf("Failed to grant book %s to user %d (%s): %v", req.BookId, userID, req.ReferenceId, err)
+		return nil, err
+	}
+	if entitlement.UserID != uint(userID) {
+		return nil, ErrEntitlementConflict
+	}
+	return toEntitlementResponse(entitlement), nil
+}
+
+func toEntitlementResponse(entitlement *model.Entitlement) *pb.Entitlement {
+	return &pb.Entitlement{
+		UserId:      fmt.Sprintf("%d", entitlement.UserID),
+		BookId:      entitlement.BookID,
+		Source:      entitlement.Source,
+		ReferenceId: entitlement.ReferenceID,
+		GrantedAt:   timestamppb.New(entitlement.CreatedAt),
+	}
+}
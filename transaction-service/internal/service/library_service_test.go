@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+	"transaction-service/internal/model"
+	"transaction-service/internal/repository"
+	"transaction-service/pkg/client"
+	pb "transaction-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Skenario 1: Tes ListLibrary menampilkan buku sesuai urutan dari repository beserta
+// metadatanya, dan tetap menampilkan buku yang gagal dimuat dari book-service
+func TestListLibrary(t *testing.T) {
+	// --- Arrange ---
+	mockLibraryRepo := new(repository.MockLibraryRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	mockLibraryRepo.On("ListOwnedBooks", mock.Anything, repository.LibraryFilter{UserID: 1, Limit: 21}).Return([]model.Entitlement{
+		{ID: 2, UserID: 1, BookID: "102", Source: "gift", ReferenceID: "gift-3", CreatedAt: day.Add(time.Hour)},
+		{ID: 1, UserID: 1, BookID: "101", Source: "purchase", ReferenceID: "10", CreatedAt: day},
+	}, nil)
+	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Title: "Buku A", Author: "Penulis A"}, nil)
+	mockBookClient.On("GetBookByID", mock.Anything, "102").Return(nil, errors.New("book not found"))
+
+	libraryService := NewLibraryService(mockLibraryRepo, mockBookClient)
+
+	// --- Act ---
+	result, err := libraryService.ListLibrary(context.Background(), &pb.ListLibraryRequest{UserId: "1"})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Len(t, result.Books, 2)
+	assert.Empty(t, result.NextPageToken)
+	assert.Equal(t, "102", result.Books[0].Entitlement.BookId)
+	assert.Empty(t, result.Books[0].Title)
+	assert.Equal(t, "101", result.Books[1].Entitlement.BookId)
+	assert.Equal(t, "purchase", result.Books[1].Entitlement.Source)
+	assert.Equal(t, "Buku A", result.Books[1].Title)
+	assert.Equal(t, "Penulis A", result.Books[1].Author)
+	mockLibraryRepo.AssertExpectations(t)
+	mockBookClient.AssertExpectations(t)
+}
+
+// Skenario 1b: Tes ListLibrary mengembalikan next_page_token yang dipakai sebagai cursor halaman berikutnya
+func TestListLibrary_Pagination(t *testing.T) {
+	// --- Arrange ---
+	mockLibraryRepo := new(repository.MockLibraryRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	var firstPage []model.Entitlement
+	for i := 0; i < 3; i++ {
+		firstPage = append(firstPage, model.Entitlement{ID: uint(10 - i), UserID: 1, BookID: fmt.Sprintf("%d", 110-i), CreatedAt: day.Add(-time.Duration(i) * time.Hour)})
+	}
+	mockLibraryRepo.On("ListOwnedBooks", mock.Anything, repository.LibraryFilter{UserID: 1, Limit: 3}).Return(firstPage, nil)
+	mockLibraryRepo.On("ListOwnedBooks", mock.Anything, repository.LibraryFilter{
+		UserID: 1,
+		Limit:  3,
+		After:  &repository.LibraryCursor{CreatedAt: day.Add(-time.Hour), ID: 9},
+	}).Return(firstPage[2:], nil)
+	mockBookClient.On("GetBookByID", mock.Anything, mock.Anything).Return(&client.BookDTO{Title: "Buku"}, nil)
+
+	libraryService := NewLibraryService(mockLibraryRepo, mockBookClient)
+
+	// --- Act ---
+	page1, err1 := libraryService.ListLibrary(context.Background(), &pb.ListLibraryRequest{UserId: "1", PageSize: 2})
+	page2, err2 := libraryService.ListLibrary(context.Background(), &pb.ListLibraryRequest{UserId: "1", PageSize: 2, PageToken: page1.GetNextPageToken()})
+
+	// --- Assert ---
+	assert.NoError(t, err1)
+	assert.Len(t, page1.Books, 2)
+	assert.NotEmpty(t, page1.NextPageToken)
+	assert.NoError(t, err2)
+	assert.Len(t, page2.Books, 1)
+	assert.Equal(t, "108", page2.Books[0].Entitlement.BookId)
+	assert.Empty(t, page2.NextPageToken)
+	mockBookClient.AssertNumberOfCalls(t, "GetBookByID", 3)
+}
+
+// Skenario 1c: Tes page_token yang rusak ditolak
+func TestListLibrary_InvalidPageToken(t *testing.T) {
+	mockLibraryRepo := new(repository.MockLibraryRepository)
+	libraryService := NewLibraryService(mockLibraryRepo, nil)
+
+	_, err := libraryService.ListLibrary(context.Background(), &pb.ListLibraryRequest{UserId: "1", PageToken: "!!"})
+
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+	mockLibraryRepo.AssertNotCalled(t, "ListOwnedBooks", mock.Anything, mock.Anything)
+}
+
+// Skenario 1d: Tes metadata buku diambil bersamaan tetapi tidak lebih dari maxConcurrentBookLookups
+func TestListLibrary_BoundedConcurrency(t *testing.T) {
+	// --- Arrange ---
+	mockLibraryRepo := new(repository.MockLibraryRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+
+	var owned []model.Entitlement
+	for i := 0; i < 3*maxConcurrentBookLookups; i++ {
+		owned = append(owned, model.Entitlement{ID: uint(i + 1), UserID: 1, BookID: fmt.Sprintf("%d", i)})
+	}
+	mockLibraryRepo.On("ListOwnedBooks", mock.Anything, mock.Anything).Return(owned, nil)
+
+	var inFlight, peak int32
+	mockBookClient.On("GetBookByID", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&peak)
+			if current <= seen || atomic.CompareAndSwapInt32(&peak, seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}).Return(&client.BookDTO{Title: "Buku"}, nil)
+
+	libraryService := NewLibraryService(mockLibraryRepo, mockBookClient)
+
+	// --- Act ---
+	result, err := libraryService.ListLibrary(context.Background(), &pb.ListLibraryRequest{UserId: "1", PageSize: 100})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Len(t, result.Books, len(owned))
+	for i, book := range result.Books {
+		assert.Equal(t, owned[i].BookID, book.Entitlement.BookId)
+		assert.Equal(t, "Buku", book.Title)
+	}
+	assert.Greater(t, peak, int32(1))
+	assert.LessOrEqual(t, peak, int32(maxConcurrentBookLookups))
+}
+
+// Skenario 2: Tes CheckOwnership untuk buku yang dimiliki dan yang tidak
+func TestCheckOwnership(t *testing.T) {
+	mockLibraryRepo := new(repository.MockLibraryRepository)
+	mockLibraryRepo.On("GetEntitlement", mock.Anything, uint(1), "101").Return(&model.Entitlement{UserID: 1, BookID: "101", Source: "purchase", ReferenceID: "10"}, nil)
+	mockLibraryRepo.On("GetEntitlement", mock.Anything, uint(1), "102").Return(nil, nil)
+	libraryService := NewLibraryService(mockLibraryRepo, nil)
+
+	owned, err := libraryService.CheckOwnership(context.Background(), &pb.CheckOwnershipRequest{UserId: "1", BookId: "101"})
+	assert.NoError(t, err)
+	assert.True(t, owned.Owned)
+	assert.Equal(t, "10", owned.Entitlement.ReferenceId)
+
+	notOwned, err := libraryService.CheckOwnership(context.Background(), &pb.CheckOwnershipRequest{UserId: "1", BookId: "102"})
+	assert.NoError(t, err)
+	assert.False(t, notOwned.Owned)
+	assert.Nil(t, notOwned.Entitlement)
+}
+
+// Skenario 3: Tes GrantEntitlement mencatat hadiah dan menolak sumber lain
+func TestGrantEntitlement(t *testing.T) {
+	t.Run("gift", func(t *testing.T) {
+		mockLibraryRepo := new(repository.MockLibraryRepository)
+		entitlement := &model.Entitlement{UserID: 1, BookID: "101", Source: "gift", ReferenceID: "gift-3"}
+		mockLibraryRepo.On("GrantEntitlement", mock.Anything, entitlement).Return(entitlement, nil)
+		libraryService := NewLibraryService(mockLibraryRepo, nil)
+
+		result, err := libraryService.GrantEntitlement(context.Background(), &pb.GrantEntitlementRequest{
+			UserId: "1", BookId: "101", Source: "gift", ReferenceId: "gift-3",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1", result.UserId)
+		assert.Equal(t, "gift-3", result.ReferenceId)
+		mockLibraryRepo.AssertExpectations(t)
+	})
+
+	t.Run("purchase source is rejected", func(t *testing.T) {
+		mockLibraryRepo := new(repository.MockLibraryRepository)
+		libraryService := NewLibraryService(mockLibraryRepo, nil)
+
+		_, err := libraryService.GrantEntitlement(context.Background(), &pb.GrantEntitlementRequest{
+			UserId: "1", BookId: "101", Source: "purchase", ReferenceId: "10",
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidEntitlement)
+		mockLibraryRepo.AssertNotCalled(t, "GrantEntitlement", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reference granted to another user", func(t *testing.T) {
+		mockLibraryRepo := new(repository.MockLibraryRepository)
+		mockLibraryRepo.On("GrantEntitlement", mock.Anything, mock.Anything).
+			Return(&model.Entitlement{UserID: 2, BookID: "101", Source: "gift", ReferenceID: "gift-3"}, nil)
+		libraryService := NewLibraryService(mockLibraryRepo, nil)
+
+		_, err := libraryService.GrantEntitlement(context.Background(), &pb.GrantEntitlementRequest{
+			UserId: "1", BookId: "101", Source: "gift", ReferenceId: "gift-3",
+		})
+
+		assert.ErrorIs(t, err, ErrEntitlementConflict)
+	})
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"shared/money"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"transaction-service/internal/model"
+	"transaction-service/internal/repository"
+	pb "transaction-service/proto"
+	wallet_pb "wallet-service/proto"
+)
+
+const (
+	// ReconciliationSettleDelay adalah jeda sebelum transaksi ikut direkonsiliasi, agar event
+	// pembayaran yang masih dalam perjalanan tidak dianggap sebagai ketidakcocokan.
+	ReconciliationSettleDelay = time.Hour
+
+	reconciliationDefaultWindow = 24 * time.Hour
+	reconciliationBatchSize     = 500 // Sama dengan batas ListTransactionMovements di wallet-service
+)
+
+// ErrReconciliationRunNotFound dikembalikan jika run rekonsiliasi tidak ditemukan.
+var ErrReconciliationRunNotFound = errors.New("reconciliation run not found")
+
+// ReconciliationService mencocokkan transaksi dengan debit dan refund di wallet-service.
+type ReconciliationService interface {
+	// RunScheduled menjalankan rekonsiliasi untuk rentang sejak run terjadwal terakhir.
+	// Mengembalikan (nil, nil) jika belum ada rentang baru yang perlu dicek.
+	RunScheduled(ctx context.Context, now time.Time) (*model.ReconciliationRun, error)
+	RunReconciliation(ctx context.Context, req *pb.RunReconciliationRequest) (*pb.ReconciliationRun, error)
+	ListReconciliationRuns(ctx context.Context, req *pb.ListReconciliationRunsRequest) (*pb.ListReconciliationRunsResponse, error)
+	GetReconciliationRun(ctx context.Context, req *pb.GetReconciliationRunRequest) (*pb.ReconciliationRun, error)
+}
+
+type reconciliationService struct {
+	repo         repository.ReconciliationRepository
+	walletClient wallet_pb.WalletServiceClient
+}
+
+// NewReconciliationService adalah constructor untuk reconciliation service.
+func NewReconciliationService(repo repository.ReconciliationRepository, walletClient wallet_pb.WalletServiceClient) ReconciliationService {
+	return &reconciliationService{repo: repo, walletClient: walletClient}
+}
+
+func (s *reconciliationService) RunScheduled(ctx context.Context, now time.Time) (*model.ReconciliationRun, error) {
+	to := now.Add(-ReconciliationSettleDelay)
+	from := to.Add(-reconciliationDefaultWindow)
+
+	last, err := s.repo.LastCompletedRun(ctx, model.ReconciliationTriggerScheduled)
+	if err != nil {
+		return nil, err
+	}
+	if last != nil {
+		from = last.WindowEnd
+	}
+	if !from.Before(to) {
+		return nil, nil
+	}
+	return s.reconcile(ctx, model.ReconciliationTriggerScheduled, from, to)
+}
+
+// RunReconciliation menjalankan rekonsiliasi manual. Run yang gagal tetap dikembalikan
+// dengan status failed beserta errornya.
+func (s *reconciliationService) RunReconciliation(ctx context.Context, req *pb.RunReconciliationRequest) (*pb.ReconciliationRun, error) {
+	to := time.Now().Add(-ReconciliationSettleDelay)
+	if req.EndTime != nil {
+		to = req.EndTime.AsTime()
+	}
+	from := to.Add(-reconciliationDefaultWindow)
+	if req.StartTime != nil {
+		from = req.StartTime.AsTime()
+	}
+	if !from.Before(to) {
+		return nil, ErrInvalidDateRange
+	}
+
+	run, err := s.reconcile(ctx, model.ReconciliationTriggerManual, from, to)
+	if run == nil {
+		return nil, err
+	}
+	return toReconciliationRunProto(run), nil
+}
+
+// ListReconciliationRuns mengambil daftar run (terbaru lebih dulu) tanpa detail ketidakcocokan.
+func (s *reconciliationService) ListReconciliationRuns(ctx context.Context, req *pb.ListReconciliationRunsRequest) (*pb.ListReconciliationRunsResponse, error) {
+	page := int(req.Page)
+	if page < 1 {
+		page = 1
+	}
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	runs, total, err := s.repo.ListRuns(ctx, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListReconciliationRunsResponse{
+		Runs:     make([]*pb.ReconciliationRun, len(runs)),
+		Total:    total,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}
+	for i := range runs {
+		response.Runs[i] = toReconciliationRunProto(&runs[i])
+	}
+	return response, nil
+}
+
+// GetReconciliationRun mengambil satu run beserta semua ketidakcocokannya.
+func (s *reconciliationService) GetReconciliationRun(ctx context.Context, req *pb.GetReconciliationRunRequest) (*pb.ReconciliationRun, error) {
+	id, err := strconv.ParseUint(req.RunId, 10, 32)
+	if err != nil {
+		return nil, ErrReconciliationRunNotFound
+	}
+	run, err := s.repo.GetRun(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, ErrReconciliationRunNotFound
+	}
+	return toReconciliationRunProto(run), nil
+}
+
+// reconcile mencatat run baru lalu mengecek semua transaksi di rentang [from, to) per batch.
+// Run yang gagal di tengah jalan disimpan dengan status failed; ketidakcocokan dari batch
+// sebelumnya tetap tersimpan.
+func (s *reconciliationService) reconcile(ctx context.Context, trigger string, from, to time.Time) (*model.ReconciliationRun, error) {
+	run := &model.ReconciliationRun{
+		Trigger:     trigger,
+		Status:      model.ReconciliationStatusRunning,
+		WindowStart: from,
+		WindowEnd:   to,
+		StartedAt:   time.Now(),
+	}
+	if err := s.repo.CreateRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	err := s.checkTransactions(ctx, run)
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Status = model.ReconciliationStatusCompleted
+	if err != nil {
+		run.Status = model.ReconciliationStatusFailed
+		run.Error = err.Error()
+		log.Printf("Reconciliation run %d failed: %v", run.ID, err)
+	} else {
+		log.Printf("Reconciliation run %d checked %d transaction(s), found %d mismatch(es)", run.ID, run.CheckedCount, run.MismatchCount)
+	}
+
+	if updateErr := s.repo.UpdateRun(ctx, run); updateErr != nil {
+		log.Printf("Failed to save reconciliation run %d: %v", run.ID, updateErr)
+		if err == nil {
+			err = updateErr
+		}
+	}
+	return run, err
+}
+
+func (s *reconciliationService) checkTransactions(ctx context.Context, run *model.ReconciliationRun) error {
+	var afterID uint
+	for {
+		transactions, err := s.repo.FindTransactionsToReconcile(ctx, run.WindowStart, run.WindowEnd, afterID, reconciliationBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to load transactions: %w", err)
+		}
+		if len(transactions) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(transactions))
+		for i, txModel := range transactions {
+			ids[i] = strconv.FormatUint(uint64(txModel.ID), 10)
+		}
+		movements, err := s.walletClient.ListTransactionMovements(ctx, &wallet_pb.ListTransactionMovementsRequest{TransactionIds: ids})
+		if err != nil {
+			return fmt.Errorf("failed to load wallet movements: %w", err)
+		}
+
+		// Refund dicatat dengan referensi "refund-<id>", kelompokkan berdasarkan ID transaksi
+		entriesByTransaction := make(map[string][]*wallet_pb.LedgerEntry)
+		for _, entry := range movements.Entries {
+			id := strings.TrimPrefix(entry.ReferenceId, "refund-")
+			entriesByTransaction[id] = append(entriesByTransaction[id], entry)
+		}
+
+		var mismatches []model.ReconciliationMismatch
+		for i := range transactions {
+			found, err := reconcileTransaction(&transactions[i], entriesByTransaction[ids[i]])
+			if err != nil {
+				return err
+			}
+			mismatches = append(mismatches, found...)
+		}
+		for i := range mismatches {
+			mismatches[i].RunID = run.ID
+		}
+		if err := s.repo.AddMismatches(ctx, mismatches); err != nil {
+			return fmt.Errorf("failed to save mismatches: %w", err)
+		}
+
+		run.CheckedCount += len(transactions)
+		run.MismatchCount += len(mismatches)
+		afterID = transactions[len(transactions)-1].ID
+		if len(transactions) < reconciliationBatchSize {
+			return nil
+		}
+	}
+}
+
+// reconcileTransaction membandingkan satu transaksi dengan debit dan refund miliknya di wallet.
+// Transaksi completed dan refunded harus punya tepat satu debit sebesar total; refunded juga harus
+// punya tepat satu refund sebesar total. Transaksi cancelled tidak boleh punya debit yang belum
+// di-refund, dan transaksi yang masih pending setelah jeda settle selalu dilaporkan.
+func reconcileTransaction(txModel *model.Transaction, entries []*wallet_pb.LedgerEntry) ([]model.ReconciliationMismatch, error) {
+	var mismatches []model.ReconciliationMismatch
+	add := func(kind string, expected, actual money.Money, detail string) {
+		mismatches = append(mismatches, model.ReconciliationMismatch{
+			TransactionID:     txModel.ID,
+			UserID:            txModel.UserID,
+			TransactionStatus: txModel.Status,
+			Type:              kind,
+			ExpectedAmount:    expected,
+			ActualAmount:      actual,
+			Detail:            detail,
+		})
+	}
+
+	userID := strconv.FormatUint(uint64(txModel.UserID), 10)
+	var debits, refunds []money.Money
+	var debitTotal, refundTotal money.Money
+	for _, entry := range entries {
+		amount, err := money.FromProto(entry.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount on ledger entry %s: %w", entry.Id, err)
+		}
+		if entry.UserId != userID {
+			add(model.MismatchUserMismatch, 0, amount, fmt.Sprintf("ledger entry %s belongs to user %s", entry.Id, entry.UserId))
+		}
+		switch entry.EntryType {
+		case "debit":
+			debits = append(debits, -amount) // Debit dicatat negatif di ledger
+			debitTotal -= amount
+		case "refund":
+			refunds = append(refunds, amount)
+			refundTotal += amount
+		}
+	}
+
+	switch txModel.Status {
+	case model.StatusPending:
+		detail := "no wallet debit"
+		if len(debits) > 0 {
+			detail = "wallet already debited but transaction was never finalized"
+		}
+		add(model.MismatchStuckPending, txModel.TotalAmount, debitTotal, detail)
+		return mismatches, nil
+
+	case model.StatusCancelled:
+		switch {
+		case len(debits) > 1:
+			add(model.MismatchDoubleDebit, 0, debitTotal, fmt.Sprintf("%d wallet debits for transaction", len(debits)))
+		case len(debits) == 1 && len(refunds) == 0:
+			add(model.MismatchUnexpectedDebit, 0, debitTotal, "cancelled transaction was debited and never refunded")
+		case len(debits) == 0 && len(refunds) > 0:
+			add(model.MismatchUnexpectedRefund, 0, refundTotal, "refund without a debit")
+		}
+
+	case model.StatusCompleted, model.StatusRefunded:
+		switch {
+		case len(debits) == 0:
+			add(model.MismatchMissingDebit, txModel.TotalAmount, 0, "no wallet debit for transaction")
+		case len(debits) > 1:
+			add(model.MismatchDoubleDebit, txModel.TotalAmount, debitTotal, fmt.Sprintf("%d wallet debits for transaction", len(debits)))
+		case debits[0] != txModel.TotalAmount:
+			add(model.MismatchAmountMismatch, txModel.TotalAmount, debits[0], "debit amount differs from transaction total")
+		}
+
+		if txModel.Status == model.StatusCompleted {
+			if len(refunds) > 0 {
+				add(model.MismatchUnexpectedRefund, 0, refundTotal, "completed transaction was refunded")
+			}
+			break
+		}
+		switch {
+		case len(refunds) == 0:
+			add(model.MismatchMissingRefund, txModel.TotalAmount, 0, "no wallet refund for refunded transaction")
+		case len(refunds) > 1:
+			add(model.MismatchDoubleRefund, txModel.TotalAmount, refundTotal, fmt.Sprintf("%d wallet refunds for transaction", len(refunds)))
+		case refunds[0] != txModel.TotalAmount:
+			add(model.MismatchAmountMismatch, txModel.TotalAmount, refunds[0], "refund amount differs from transaction total")
+		}
+	}
+	return mismatches, nil
+}
+
+// toReconciliationRunProto mengubah model run rekonsiliasi menjadi pesan gRPC.
+func toReconciliationRunProto(run *model.ReconciliationRun) *pb.ReconciliationRun {
+	out := &pb.ReconciliationRun{
+		Id:            strconv.FormatUint(uint64(run.ID), 10),
+		Trigger:       run.Trigger,
+		Status:        run.Status,
+		WindowStart:   timestamppb.New(run.WindowStart),
+		WindowEnd:     timestamppb.New(run.WindowEnd),
+		CheckedCount:  int32(run.CheckedCount),
+		MismatchCount: int32(run.MismatchCount),
+		Error:         run.Error,
+		StartedAt:     timestamppb.New(run.StartedAt),
+	}
+	if run.FinishedAt != nil {
+		out.FinishedAt = timestamppb.New(*run.FinishedAt)
+	}
+	for _, m := range run.Mismatches {
+		out.Mismatches = append(out.Mismatches, &pb.ReconciliationMismatch{
+			Id:                strconv.FormatUint(uint64(m.ID), 10),
+			TransactionId:     strconv.FormatUint(uint64(m.TransactionID), 10),
+			UserId:            strconv.FormatUint(uint64(m.UserID), 10),
+			TransactionStatus: m.TransactionStatus,
+			Type:              m.Type,
+			ExpectedAmount:    m.ExpectedAmount.ToProto(),
+			ActualAmount:      m.ActualAmount.ToProto(),
+			Detail:            m.Detail,
+		})
+	}
+	return out
+}
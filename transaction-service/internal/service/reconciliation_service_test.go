@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"shared/money"
+	"testing"
+	"time"
+	"transaction-service/internal/model"
+	"transaction-service/internal/repository"
+	pb "transaction-service/proto"
+	walletMocks "transaction-service/proto/mocks"
+	wallet_pb "wallet-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func ledgerEntry(userID, entryType, reference string, amount money.Money) *wallet_pb.LedgerEntry {
+	return &wallet_pb.LedgerEntry{Id: reference, UserId: userID, EntryType: entryType, ReferenceId: reference, Amount: amount.ToProto()}
+}
+
+// Skenario 1: Tes pencocokan satu transaksi dengan debit dan refund di wallet
+func TestReconcileTransaction(t *testing.T) {
+	total := money.FromRupiah(50000)
+	debit := ledgerEntry("1", "debit", "99", -total)
+	refund := ledgerEntry("1", "refund", "refund-99", total)
+
+	tests := []struct {
+		name    string
+		status  string
+		entries []*wallet_pb.LedgerEntry
+		want    []string
+	}{
+		{"completed ok", model.StatusCompleted, []*wallet_pb.LedgerEntry{debit}, nil},
+		{"completed without debit", model.StatusCompleted, nil, []string{model.MismatchMissingDebit}},
+		{"completed double debit", model.StatusCompleted, []*wallet_pb.LedgerEntry{debit, debit}, []string{model.MismatchDoubleDebit}},
+		{"completed wrong amount", model.StatusCompleted, []*wallet_pb.LedgerEntry{ledgerEntry("1", "debit", "99", money.FromRupiah(-45000))}, []string{model.MismatchAmountMismatch}},
+		{"completed but refunded", model.StatusCompleted, []*wallet_pb.LedgerEntry{debit, refund}, []string{model.MismatchUnexpectedRefund}},
+		{"cancelled ok", model.StatusCancelled, nil, nil},
+		{"cancelled but debited", model.StatusCancelled, []*wallet_pb.LedgerEntry{debit}, []string{model.MismatchUnexpectedDebit}},
+		{"refunded ok", model.StatusRefunded, []*wallet_pb.LedgerEntry{debit, refund}, nil},
+		{"refunded without refund", model.StatusRefunded, []*wallet_pb.LedgerEntry{debit}, []string{model.MismatchMissingRefund}},
+		{"stuck pending", model.StatusPending, []*wallet_pb.LedgerEntry{debit}, []string{model.MismatchStuckPending}},
+		{"other user", model.StatusCompleted, []*wallet_pb.LedgerEntry{ledgerEntry("2", "debit", "99", -total)}, []string{model.MismatchUserMismatch}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txModel := &model.Transaction{ID: 99, UserID: 1, Status: tt.status, TotalAmount: total}
+
+			mismatches, err := reconcileTransaction(txModel, tt.entries)
+
+			assert.NoError(t, err)
+			var types []string
+			for _, m := range mismatches {
+				types = append(types, m.Type)
+			}
+			assert.Equal(t, tt.want, types)
+		})
+	}
+}
+
+// Skenario 2: Tes run terjadwal melanjutkan dari run terakhir dan menyimpan ketidakcocokan
+func TestRunScheduled_ContinuesFromLastRun(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockReconciliationRepository)
+	mockWalletClient := new(walletMocks.MockWalletServiceClient)
+	now := time.Date(2025, 6, 2, 2, 0, 0, 0, time.UTC)
+	lastEnd := time.Date(2025, 6, 1, 1, 0, 0, 0, time.UTC)
+	windowEnd := now.Add(-ReconciliationSettleDelay)
+
+	mockRepo.On("LastCompletedRun", mock.Anything, model.ReconciliationTriggerScheduled).
+		Return(&model.ReconciliationRun{WindowEnd: lastEnd}, nil)
+	mockRepo.On("CreateRun", mock.Anything, mock.MatchedBy(func(run *model.ReconciliationRun) bool {
+		return run.WindowStart.Equal(lastEnd) && run.WindowEnd.Equal(windowEnd)
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*model.ReconciliationRun).ID = 5
+	}).Return(nil)
+	mockRepo.On("FindTransactionsToReconcile", mock.Anything, lastEnd, windowEnd, uint(0), reconciliationBatchSize).
+		Return([]model.Transaction{
+			{ID: 98, UserID: 1, Status: model.StatusCompleted, TotalAmount: money.FromRupiah(30000)},
+			{ID: 99, UserID: 1, Status: model.StatusCompleted, TotalAmount: money.FromRupiah(50000)},
+		}, nil)
+	mockWalletClient.On("ListTransactionMovements", mock.Anything, &wallet_pb.ListTransactionMovementsRequest{TransactionIds: []string{"98", "99"}}).
+		Return(&wallet_pb.ListTransactionMovementsResponse{Entries: []*wallet_pb.LedgerEntry{
+			ledgerEntry("1", "debit", "98", money.FromRupiah(-30000)),
+		}}, nil)
+	mockRepo.On("AddMismatches", mock.Anything, mock.MatchedBy(func(mismatches []model.ReconciliationMismatch) bool {
+		return len(mismatches) == 1 && mismatches[0].RunID == 5 && mismatches[0].TransactionID == 99 &&
+			mismatches[0].Type == model.MismatchMissingDebit
+	})).Return(nil)
+	mockRepo.On("UpdateRun", mock.Anything, mock.AnythingOfType("*model.ReconciliationRun")).Return(nil)
+
+	reconciliationService := NewReconciliationService(mockRepo, mockWalletClient)
+
+	// --- Act ---
+	run, err := reconciliationService.RunScheduled(context.Background(), now)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReconciliationStatusCompleted, run.Status)
+	assert.Equal(t, 2, run.CheckedCount)
+	assert.Equal(t, 1, run.MismatchCount)
+	mockRepo.AssertExpectations(t)
+	mockWalletClient.AssertExpectations(t)
+}
+
+// Skenario 3: Tes run manual yang gagal menghubungi wallet-service tetap tercatat sebagai failed
+func TestRunReconciliation_WalletUnavailable(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockReconciliationRepository)
+	mockWalletClient := new(walletMocks.MockWalletServiceClient)
+
+	mockRepo.On("CreateRun", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("FindTransactionsToReconcile", mock.Anything, mock.Anything, mock.Anything, uint(0), reconciliationBatchSize).
+		Return([]model.Transaction{{ID: 99, UserID: 1, Status: model.StatusCompleted}}, nil)
+	mockWalletClient.On("ListTransactionMovements", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+	mockRepo.On("UpdateRun", mock.Anything, mock.MatchedBy(func(run *model.ReconciliationRun) bool {
+		return run.Status == model.ReconciliationStatusFailed && run.FinishedAt != nil
+	})).Return(nil)
+
+	reconciliationService := NewReconciliationService(mockRepo, mockWalletClient)
+
+	// --- Act ---
+	result, err := reconciliationService.RunReconciliation(context.Background(), &pb.RunReconciliationRequest{})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReconciliationStatusFailed, result.Status)
+	assert.Contains(t, result.Error, "connection refused")
+	mockRepo.AssertExpectations(t)
+}
+
+// Skenario 4: Tes GetReconciliationRun untuk run yang tidak ada
+func TestGetReconciliationRun_NotFound(t *testing.T) {
+	mockRepo := new(repository.MockReconciliationRepository)
+	mockRepo.On("GetRun", mock.Anything, uint(42)).Return(nil, nil)
+
+	reconciliationService := NewReconciliationService(mockRepo, nil)
+	result, err := reconciliationService.GetReconciliationRun(context.Background(), &pb.GetReconciliationRunRequest{RunId: "42"})
+
+	assert.ErrorIs(t, err, ErrReconciliationRunNotFound)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"transaction-service/internal/repository"
+	pb "transaction-service/proto"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultTopBooksLimit = 10
+	maxTopBooksLimit     = 100
+)
+
+// ErrInvalidReportPeriod dikembalikan jika periode laporan bukan day, week, atau month.
+var ErrInvalidReportPeriod = errors.New("period must be day, week, or month")
+
+// ReportService adalah interface untuk laporan penjualan admin.
+type ReportService interface {
+	GetRevenueReport(ctx context.Context, req *pb.RevenueReportRequest) (*pb.RevenueReportResponse, error)
+	GetTopBooksReport(ctx context.Context, req *pb.TopBooksReportRequest) (*pb.TopBooksReportResponse, error)
+	GetCategoryReport(ctx context.Context, req *pb.CategoryReportRequest) (*pb.CategoryReportResponse, error)
+}
+
+type reportService struct {
+	repo repository.ReportRepository
+}
+
+// NewReportService adalah constructor untuk report service.
+func NewReportService(repo repository.ReportRepository) ReportService {
+	return &reportService{repo: repo}
+}
+
+// GetRevenueReport mengambil pendapatan per periode beserta total di seluruh rentang.
+func (s *reportService) GetRevenueReport(ctx context.Context, req *pb.RevenueReportRequest) (*pb.RevenueReportResponse, error) {
+	period := req.Period
+	switch period {
+	case "":
+		period = "day"
+	case "day", "week", "month":
+	default:
+		return nil, ErrInvalidReportPeriod
+	}
+	filter, err := reportFilter(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.RevenueByPeriod(ctx, period, filter)
+	if err != nil {
+		log.Printf("Failed to get revenue report: %v", err)
+		return nil, errors.New("failed to get revenue report")
+	}
+	totals, err := s.repo.RevenueTotals(ctx, filter)
+	if err != nil {
+		log.Printf("Failed to get revenue totals: %v", err)
+		return nil, errors.New("failed to get revenue report")
+	}
+
+	response := &pb.RevenueReportResponse{
+		Period:            period,
+		Buckets:           make([]*pb.RevenueBucket, len(rows)),
+		TotalRevenue:      totals.Revenue.ToProto(),
+		TotalTransactions: totals.TransactionCount,
+		TotalBuyers:       totals.BuyerCount,
+	}
+	for i, row := range rows {
+		response.Buckets[i] = &pb.RevenueBucket{
+			PeriodStart:      timestamppb.New(row.PeriodStart),
+			Revenue:          row.Revenue.ToProto(),
+			TransactionCount: row.TransactionCount,
+			BuyerCount:       row.BuyerCount,
+		}
+	}
+	return response, nil
+}
+
+// GetTopBooksReport mengambil buku terlaris berdasarkan jumlah terjual.
+func (s *reportService) GetTopBooksReport(ctx context.Context, req *pb.TopBooksReportRequest) (*pb.TopBooksReportResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultTopBooksLimit
+	}
+	if limit > maxTopBooksLimit {
+		limit = maxTopBooksLimit
+	}
+	filter, err := reportFilter(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.TopBooks(ctx, filter, limit)
+	if err != nil {
+		log.Printf("Failed to get top books report: %v", err)
+		return nil, errors.New("failed to get top books report")
+	}
+
+	response := &pb.TopBooksReportResponse{Books: make([]*pb.BookSales, len(rows))}
+	for i, row := range rows {
+		response.Books[i] = &pb.BookSales{BookId: row.BookID, Quantity: row.Quantity, GrossSales: row.GrossSales.ToProto(), NetSales: row.NetSales.ToProto()}
+	}
+	return response, nil
+}
+
+// GetCategoryReport mengambil penjualan per kategori buku.
+func (s *reportService) GetCategoryReport(ctx context.Context, req *pb.CategoryReportRequest) (*pb.CategoryReportResponse, error) {
+	filter, err := reportFilter(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.SalesByCategory(ctx, filter)
+	if err != nil {
+		log.Printf("Failed to get category report: %v", err)
+		return nil, errors.New("failed to get category report")
+	}
+
+	response := &pb.CategoryReportResponse{Categories: make([]*pb.CategorySales, len(rows))}
+	for i, row := range rows {
+		response.Categories[i] = &pb.CategorySales{Category: row.Category, Quantity: row.Quantity, GrossSales: row.GrossSales.ToProto(), NetSales: row.NetSales.ToProto()}
+	}
+	return response, nil
+}
+
+// reportFilter mengubah rentang waktu dari request menjadi filter repository.
+func reportFilter(start, end *timestamppb.Timestamp) (repository.ReportFilter, error) {
+	var filter repository.ReportFilter
+	if start != nil {
+		from := start.AsTime()
+		filter.From = &from
+	}
+	if end != nil {
+		to := end.AsTime()
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, ErrInvalidDateRange
+	}
+	return filter, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"shared/money"
+	"testing"
+	"time"
+	"transaction-service/internal/repository"
+	pb "transaction-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Skenario 1: Tes laporan pendapatan per periode beserta totalnya
+func TestGetRevenueReport_Success(t *testing.T) {
+	// --- Arrange ---
+	mockReportRepo := new(repository.MockReportRepository)
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	filter := repository.ReportFilter{From: &from, To: &to}
+
+	mockReportRepo.On("RevenueByPeriod", mock.Anything, "week", filter).Return([]repository.RevenueRow{
+		{PeriodStart: from, Revenue: money.FromRupiah(150000), TransactionCount: 2, BuyerCount: 2},
+		{PeriodStart: from.AddDate(0, 0, 7), Revenue: money.FromRupiah(50000), TransactionCount: 1, BuyerCount: 1},
+	}, nil)
+	mockReportRepo.On("RevenueTotals", mock.Anything, filter).Return(&repository.RevenueRow{
+		Revenue: money.FromRupiah(200000), TransactionCount: 3, BuyerCount: 2,
+	}, nil)
+
+	reportService := NewReportService(mockReportRepo)
+
+	// --- Act ---
+	result, err := reportService.GetRevenueReport(context.Background(), &pb.RevenueReportRequest{
+		Period:    "week",
+		StartTime: timestamppb.New(from),
+		EndTime:   timestamppb.New(to),
+	})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, "week", result.Period)
+	assert.Len(t, result.Buckets, 2)
+	assert.Equal(t, money.FromRupiah(150000).Minor(), result.Buckets[0].Revenue.MinorUnits)
+	assert.Equal(t, money.FromRupiah(200000).Minor(), result.TotalRevenue.MinorUnits)
+	assert.Equal(t, int64(3), result.TotalTransactions)
+	// Buyer di seluruh rentang dihitung unik, bukan penjumlahan per periode
+	assert.Equal(t, int64(2), result.TotalBuyers)
+	mockReportRepo.AssertExpectations(t)
+}
+
+// Skenario 2: Tes laporan pendapatan menolak periode dan rentang waktu yang tidak valid
+func TestGetRevenueReport_InvalidRequest(t *testing.T) {
+	mockReportRepo := new(repository.MockReportRepository)
+	reportService := NewReportService(mockReportRepo)
+	now := time.Now()
+
+	_, err := reportService.GetRevenueReport(context.Background(), &pb.RevenueReportRequest{Period: "year"})
+	assert.ErrorIs(t, err, ErrInvalidReportPeriod)
+
+	_, err = reportService.GetRevenueReport(context.Background(), &pb.RevenueReportRequest{
+		StartTime: timestamppb.New(now),
+		EndTime:   timestamppb.New(now.Add(-time.Hour)),
+	})
+	assert.ErrorIs(t, err, ErrInvalidDateRange)
+	mockReportRepo.AssertNotCalled(t, "RevenueByPeriod", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Skenario 3: Tes laporan buku terlaris memakai limit default dan maksimal
+func TestGetTopBooksReport_Limit(t *testing.T) {
+	mockReportRepo := new(repository.MockReportRepository)
+	mockReportRepo.On("TopBooks", mock.Anything, repository.ReportFilter{}, 10).Return([]repository.BookSalesRow{
+		{BookID: "101", Quantity: 5, GrossSales: money.FromRupiah(250000), NetSales: money.FromRupiah(225000)},
+	}, nil)
+	mockReportRepo.On("TopBooks", mock.Anything, repository.ReportFilter{}, 100).Return([]repository.BookSalesRow{}, nil)
+
+	reportService := NewReportService(mockReportRepo)
+
+	result, err := reportService.GetTopBooksReport(context.Background(), &pb.TopBooksReportRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "101", result.Books[0].BookId)
+	assert.Equal(t, int64(5), result.Books[0].Quantity)
+	assert.Equal(t, money.FromRupiah(250000).ToProto().GetMinorUnits(), result.Books[0].GrossSales.GetMinorUnits())
+	assert.Equal(t, money.FromRupiah(225000).ToProto().GetMinorUnits(), result.Books[0].NetSales.GetMinorUnits())
+
+	_, err = reportService.GetTopBooksReport(context.Background(), &pb.TopBooksReportRequest{Limit: 500})
+	assert.NoError(t, err)
+	mockReportRepo.AssertExpectations(t)
+}
+
+// Skenario 4: Tes laporan kategori saat database gagal
+func TestGetCategoryReport_RepositoryError(t *testing.T) {
+	mockReportRepo := new(repository.MockReportRepository)
+	mockReportRepo.On("SalesByCategory", mock.Anything, repository.ReportFilter{}).Return(nil, errors.New("db down"))
+
+	reportService := NewReportService(mockReportRepo)
+	result, err := reportService.GetCategoryReport(context.Background(), &pb.CategoryReportRequest{})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
@@ -2,162 +2,789 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
+	"shared/events"
+	"shared/money"
 	"strconv"
+	"strings"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"transaction-service/internal/model"
 	"transaction-service/internal/repository"
 	"transaction-service/pkg/client"
-	"transaction-service/pkg/messagebroker"
+	"transaction-service/pkg/quote"
 	pb "transaction-service/proto"
 	wallet_pb "wallet-service/proto"
 )
 
+// Error untuk ID pada request yang bukan angka.
+var (
+	ErrInvalidTransactionID = errors.New("invalid transaction id format")
+	ErrInvalidUserID        = errors.New("invalid user id format")
+)
+
+// Error yang dikembalikan GetTransaction dan CancelTransaction.
+var (
+	ErrTransactionNotFound       = errors.New("transaction not found")
+	ErrNotTransactionOwner       = errors.New("transaction does not belong to user")
+	ErrTransactionNotCancellable = errors.New("transaction can no longer be cancelled")
+)
+
+// Error yang dikembalikan CreateTransaction.
+var (
+	ErrOutOfStock   = errors.New("some books are out of stock")
+	ErrInvalidQuote = errors.New("invalid quote")
+	ErrQuoteExpired = errors.New("quote has expired, please request a new one")
+	// ErrPaymentNotAuthorized dikembalikan (sebagai *PaymentNotAuthorizedError) jika wallet-service
+	// menolak menahan dana, mis. saldo tidak cukup atau batas pengeluaran terlampaui.
+	ErrPaymentNotAuthorized = errors.New("payment could not be authorized")
+)
+
+// PaymentNotAuthorizedError membawa alasan penolakan dari wallet-service.
+type PaymentNotAuthorizedError struct {
+	Code   string // Kode dari wallet-service, mis. daily_limit_exceeded; kosong jika tidak dikirim
+	Reason string // Pesan dari wallet-service
+}
+
+func (e *PaymentNotAuthorizedError) Error() string {
+	return ErrPaymentNotAuthorized.Error() + ": " + e.Reason
+}
+
+// Is membuat errors.Is(err, ErrPaymentNotAuthorized) bernilai true.
+func (e *PaymentNotAuthorizedError) Is(target error) bool {
+	return target == ErrPaymentNotAuthorized
+}
+
+// Error validasi untuk filter riwayat transaksi.
+var (
+	ErrInvalidPageToken    = errors.New("invalid page token")
+	ErrInvalidDateRange    = errors.New("start_time must be before end_time")
+	ErrInvalidStatusFilter = errors.New("invalid status filter")
+)
+
+// EventProducer adalah nama service ini di envelope event yang dikirim.
+const EventProducer = "transaction-service"
+
+// Batas ukuran halaman riwayat transaksi.
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
 // TransactionService adalah interface untuk logika bisnis transaksi.
 type TransactionService interface {
+	QuoteOrder(ctx context.Context, req *pb.QuoteOrderRequest) (*pb.QuoteResponse, error)
 	CreateTransaction(ctx context.Context, req *pb.CreateTransactionRequest) (*pb.TransactionResponse, error)
 	GetUserTransactions(ctx context.Context, req *pb.GetUserTransactionsRequest) (*pb.GetUserTransactionsResponse, error)
+	GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.TransactionResponse, error)
+	CancelTransaction(ctx context.Context, req *pb.CancelTransactionRequest) (*pb.TransactionResponse, error)
+	WatchTransaction(ctx context.Context, req *pb.WatchTransactionRequest, send func(*pb.TransactionStatusEvent) error) error
+	CompleteTransaction(ctx context.Context, transactionID string) error
+	FailTransaction(ctx context.Context, transactionID, failureCode, reason string) error
 }
 
 type transactionService struct {
 	repo         repository.TransactionRepository
 	bookClient   client.BookServiceClient
 	walletClient wallet_pb.WalletServiceClient // gRPC Client untuk wallet-service
-	producer     messagebroker.Producer
+	quotes       *quote.Signer                 // Penanda tangan quote harga
+	coupons      repository.CouponRepository
+	watchers     *statusNotifier // Watcher WatchTransaction yang menunggu perubahan status
+	holdTTL      time.Duration   // Masa berlaku hold pembayaran di wallet-service
+
+	watchPollInterval time.Duration
 }
 
+// DefaultHoldTTL adalah masa berlaku hold pembayaran jika tidak dikonfigurasi. Hold baru di-capture
+// wallet-service setelah event transaction_created dikonsumsi, sehingga masa berlakunya harus lebih
+// lama dari keterlambatan consumer terburuk; hold transaksi yang batal tetap di-void lebih awal.
+const DefaultHoldTTL = 6 * time.Hour
+
 // NewTransactionService adalah constructor untuk service.
 func NewTransactionService(
 	repo repository.TransactionRepository,
 	bookClient client.BookServiceClient,
 	walletClient wallet_pb.WalletServiceClient,
-	producer messagebroker.Producer,
+	quotes *quote.Signer,
+	coupons repository.CouponRepository,
+	holdTTL time.Duration,
 ) TransactionService {
+	if holdTTL <= 0 {
+		holdTTL = DefaultHoldTTL
+	}
 	return &transactionService{
 		repo:         repo,
 		bookClient:   bookClient,
 		walletClient: walletClient,
-		producer:     producer,
+		quotes:       quotes,
+		coupons:      coupons,
+		watchers:     newStatusNotifier(),
+		holdTTL:      holdTTL,
+
+		watchPollInterval: defaultWatchPollInterval,
+	}
+}
+
+// QuoteOrder menghitung harga keranjang dengan harga buku saat ini dan mengembalikan
+// quote bertanda tangan. Harga di quote dipakai saat checkout selama quote belum kedaluwarsa.
+func (s *transactionService) QuoteOrder(ctx context.Context, req *pb.QuoteOrderRequest) (*pb.QuoteResponse, error) {
+	if s.quotes == nil {
+		return nil, errors.New("quotes are not enabled")
+	}
+	if _, err := strconv.ParseUint(req.UserId, 10, 32); err != nil {
+		return nil, ErrInvalidUserID
+	}
+	if len(req.Items) == 0 {
+		return nil, errors.New("order must contain at least one item")
+	}
+
+	details, totalAmount, err := s.priceItems(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &quote.Quote{UserID: req.UserId, TotalAmount: totalAmount}
+	for _, detail := range details {
+		q.Items = append(q.Items, quote.Item{
+			BookID:       detail.BookID,
+			Quantity:     int32(detail.Quantity),
+			PricePerUnit: detail.PricePerUnit,
+		})
+	}
+	token, err := s.quotes.Sign(q)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*pb.TransactionDetail, len(details))
+	for i, detail := range details {
+		items[i] = &pb.TransactionDetail{
+			BookId:       detail.BookID,
+			Quantity:     int32(detail.Quantity),
+			PricePerUnit: detail.PricePerUnit.ToProto(),
+		}
 	}
+	return &pb.QuoteResponse{
+		QuoteId:     token,
+		UserId:      req.UserId,
+		Items:       items,
+		TotalAmount: totalAmount.ToProto(),
+		ExpiresAt:   timestamppb.New(q.ExpiresAt),
+	}, nil
 }
 
 // CreateTransaction mengorkestrasi seluruh proses pembuatan transaksi.
 func (s *transactionService) CreateTransaction(ctx context.Context, req *pb.CreateTransactionRequest) (*pb.TransactionResponse, error) {
-	var totalAmount float64
-	var transactionDetailsModel []model.TransactionDetail
-
 	userID, err := strconv.ParseUint(req.UserId, 10, 32)
 	if err != nil {
-		return nil, errors.New("invalid user id format")
+		return nil, ErrInvalidUserID
 	}
 
-	// 1. Validasi buku & hitung total (sinkron)
-	for _, item := range req.Items {
-		book, err := s.bookClient.GetBookByID(ctx, item.BookId)
+	// 1. Jika ada quote, pakai item dan harga yang sudah dikunci di quote
+	var q *quote.Quote
+	if req.QuoteId != "" {
+		q, err = s.verifyQuote(req)
 		if err != nil {
-			return nil, fmt.Errorf("failed to validate book with id %s", item.BookId)
+			return nil, err
 		}
-		if book.Status != "available" {
-			return nil, fmt.Errorf("book '%s' is not available", book.Title)
+		req.Items = make([]*pb.BookOrderItem, len(q.Items))
+		for i, item := range q.Items {
+			req.Items[i] = &pb.BookOrderItem{BookId: item.BookID, Quantity: item.Quantity}
 		}
+	}
 
-		itemPrice := book.Price * float64(item.Quantity)
-		totalAmount += itemPrice
+	// Validasi buku & hitung total (sinkron)
+	transactionDetailsModel, totalAmount, err := s.priceItems(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+	if q != nil {
+		for i := range transactionDetailsModel {
+			transactionDetailsModel[i].PricePerUnit = q.Items[i].PricePerUnit
+		}
+		totalAmount = q.TotalAmount
+	}
 
-		transactionDetailsModel = append(transactionDetailsModel, model.TransactionDetail{
-			BookID:       book.ID,
-			Quantity:     int(item.Quantity),
-			PricePerUnit: book.Price,
-		})
+	// Terapkan kupon; potongan dicatat terpisah dan total yang ditagih dikurangi
+	var coupon *model.Coupon
+	var discountAmount money.Money
+	if req.CouponCode != "" {
+		coupon, discountAmount, err = s.evaluateCoupon(ctx, req.CouponCode, transactionDetailsModel, totalAmount)
+		if err != nil {
+			return nil, err
+		}
+		totalAmount -= discountAmount
 	}
-	
-	// 2. Simpan transaksi dengan status PENDING
-	txModel := &model.Transaction{
-		UserID:      uint(userID),
-		TotalAmount: totalAmount,
-		Status:      "pending",
-		Details:     transactionDetailsModel,
+
+	// 2. Tahan stok semua buku sebelum transaksi disimpan agar tidak terjadi overselling
+	stockItems := make([]client.StockItem, len(req.Items))
+	for i, item := range req.Items {
+		stockItems[i] = client.StockItem{BookID: item.BookId, Quantity: int(item.Quantity)}
+	}
+	reservationID, err := newReservationID()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.bookClient.ReserveStock(ctx, reservationID, stockItems); err != nil {
+		if errors.Is(err, client.ErrOutOfStock) {
+			return nil, ErrOutOfStock
+		}
+		log.Printf("Failed to reserve stock for user %s: %v", req.UserId, err)
+		return nil, errors.New("failed to reserve stock")
 	}
 
-	savedTransaction, err := s.repo.CreateTransaction(ctx, txModel)
+	// 3. Cadangkan dana di wallet secara sinkron agar beberapa pesanan sekaligus tidak bisa
+	// melebihi saldo. Hold baru di-capture saat wallet-service memproses transaction_created.
+	holdID, err := s.authorizePayment(ctx, req.UserId, totalAmount, reservationID)
 	if err != nil {
-		return nil, errors.New("failed to create initial transaction")
+		if releaseErr := s.bookClient.ReleaseReservation(ctx, reservationID); releaseErr != nil {
+			log.Printf("Failed to release stock reservation %s: %v", reservationID, releaseErr)
+		}
+		return nil, err
 	}
 
-	// 3. Buat dan kirim pesan ke Kafka (asinkron)
-	eventPayload := map[string]interface{}{
-		"transaction_id": fmt.Sprintf("%d", savedTransaction.ID),
-		"user_id":        req.UserId,
-		"total_amount":   totalAmount,
+	// Simpan transaksi dengan status PENDING beserta event outbox-nya (atomik)
+	txModel := &model.Transaction{
+		UserID:         uint(userID),
+		TotalAmount:    totalAmount,
+		DiscountAmount: discountAmount,
+		Status:         model.StatusPending,
+		ReservationID:  reservationID,
+		HoldID:         holdID,
+		Details:        transactionDetailsModel,
+	}
+	if coupon != nil {
+		txModel.CouponID = &coupon.ID
+		txModel.CouponCode = coupon.Code
 	}
 
-	if err := s.producer.Publish(ctx, "transaction_created", eventPayload); err != nil {
-		log.Printf("CRITICAL: Failed to publish event for tx_id %d.", savedTransaction.ID)
-		// Di sini Anda TIDAK memanggil walletClient.Credit karena debit belum terjadi
-		return nil, errors.New("failed to queue transaction")
+	// 4. Event 'transaction_created' akan dikirim ke Kafka oleh outbox relay (asinkron)
+	newEvent := func(saved *model.Transaction) (*model.OutboxEvent, error) {
+		envelope, err := events.NewTransactionCreated(EventProducer, &events.TransactionCreated{
+			TransactionId: fmt.Sprintf("%d", saved.ID),
+			UserId:        req.UserId,
+			TotalAmount:   totalAmount.ToProto(),
+			HoldId:        holdID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		payload, err := events.Marshal(envelope)
+		if err != nil {
+			return nil, err
+		}
+		// Event di-key dengan user_id agar semua event milik satu user diproses berurutan
+		return &model.OutboxEvent{
+			Topic:   "transaction_created",
+			Key:     req.UserId,
+			Headers: events.Headers(envelope, fmt.Sprintf("%d", saved.ID)),
+			Payload: payload,
+		}, nil
 	}
 
-	// 4. Buat response gRPC untuk dikirim kembali ke client
-	transactionDetailsProto := make([]*pb.TransactionDetail, len(savedTransaction.Details))
-	for i, detail := range savedTransaction.Details {
-		transactionDetailsProto[i] = &pb.TransactionDetail{
-			BookId:       detail.BookID,
-			Quantity:     int32(detail.Quantity),
-			PricePerUnit: detail.PricePerUnit,
+	savedTransaction, err := s.repo.CreateTransaction(ctx, txModel, newEvent)
+	if err != nil {
+		log.Printf("Failed to create transaction for user %s: %v", req.UserId, err)
+		if releaseErr := s.bookClient.ReleaseReservation(ctx, reservationID); releaseErr != nil {
+			log.Printf("Failed to release stock reservation %s: %v", reservationID, releaseErr)
+		}
+		s.voidHold(ctx, txModel)
+		if errors.Is(err, ErrCouponUsageLimitReached) || errors.Is(err, ErrCouponUserLimitReached) {
+			return nil, err
+		}
+		return nil, errors.New("failed to create initial transaction")
+	}
+
+	// Pesanan gratis (mis. kupon 100%) tidak perlu dibayar sehingga langsung diselesaikan. Jika
+	// gagal, transaksi tetap pending dan diselesaikan saat wallet-service membalas event
+	// transaction_created-nya dengan payment_success tanpa mendebit.
+	if !totalAmount.IsPositive() {
+		if err := s.CompleteTransaction(ctx, fmt.Sprintf("%d", savedTransaction.ID)); err != nil {
+			log.Printf("Failed to complete free transaction %d, waiting for payment event: %v", savedTransaction.ID, err)
+		} else {
+			savedTransaction.Status = model.StatusCompleted
 		}
 	}
 
-	// 4. Kembalikan respons cepat ke pengguna
-	return &pb.TransactionResponse{
-		TransactionId:   fmt.Sprintf("%d", savedTransaction.ID),
-		UserId:          fmt.Sprintf("%d", savedTransaction.UserID),
-		TransactionDate: timestamppb.New(savedTransaction.CreatedAt),
-		TotalAmount:     savedTransaction.TotalAmount,
-		Status:          savedTransaction.Status,
-		Details:         transactionDetailsProto,
-	}, nil
+	// 5. Kembalikan respons cepat ke pengguna
+	return toTransactionResponse(savedTransaction), nil
 }
 
+// GetUserTransactions mengambil riwayat transaksi user per halaman menggunakan cursor.
+// Cursor berisi waktu dan ID transaksi terakhir sehingga halaman tetap stabil walaupun
+// ada transaksi baru yang masuk di antara dua permintaan.
 func (s *transactionService) GetUserTransactions(ctx context.Context, req *pb.GetUserTransactionsRequest) (*pb.GetUserTransactionsResponse, error) {
 	userID, err := strconv.ParseUint(req.UserId, 10, 32)
 	if err != nil {
-		return nil, errors.New("invalid user id format")
+		return nil, ErrInvalidUserID
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	filter := repository.TransactionFilter{
+		UserID: uint(userID),
+		Status: req.Status,
+		Limit:  pageSize + 1, // Satu baris ekstra untuk mengetahui apakah ada halaman berikutnya
+	}
+	switch req.Status {
+	case "", model.StatusPending, model.StatusCompleted, model.StatusCancelled, model.StatusRefunded:
+	default:
+		return nil, ErrInvalidStatusFilter
+	}
+	if req.StartTime != nil {
+		from := req.StartTime.AsTime()
+		filter.From = &from
+	}
+	if req.EndTime != nil {
+		to := req.EndTime.AsTime()
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, ErrInvalidDateRange
+	}
+	if req.PageToken != "" {
+		cursor, err := decodeCursor(req.PageToken)
+		if err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		filter.After = cursor
 	}
 
-	transactions, err := s.repo.GetTransactionsByUserID(ctx, uint(userID))
+	transactions, total, err := s.repo.GetTransactionsByUserID(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
+	var nextPageToken string
+	if len(transactions) > pageSize {
+		transactions = transactions[:pageSize]
+		last := transactions[pageSize-1]
+		nextPageToken = encodeCursor(repository.TransactionCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
 	var protoTransactions []*pb.TransactionResponse
-	// Gunakan nama variabel yang jelas, misal: 'txModel'
-	for _, txModel := range transactions {
-		// Konversi detail transaksi
-		detailsProto := make([]*pb.TransactionDetail, len(txModel.Details))
-		for i, detail := range txModel.Details {
-			detailsProto[i] = &pb.TransactionDetail{
-				BookId:       detail.BookID,
-				Quantity:     int32(detail.Quantity),
-				PricePerUnit: detail.PricePerUnit,
+	for i := range transactions {
+		protoTransactions = append(protoTransactions, toTransactionResponse(&transactions[i]))
+	}
+
+	return &pb.GetUserTransactionsResponse{
+		Transactions:  protoTransactions,
+		NextPageToken: nextPageToken,
+		TotalCount:    total,
+	}, nil
+}
+
+// GetTransaction mengambil satu transaksi beserta detailnya.
+// Transaksi milik user lain ditolak dengan ErrNotTransactionOwner.
+func (s *transactionService) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.TransactionResponse, error) {
+	txModel, err := s.getOwnedTransaction(ctx, req.TransactionId, req.UserId, false)
+	if err != nil {
+		return nil, err
+	}
+	return toTransactionResponse(txModel), nil
+}
+
+// CancelTransaction membatalkan transaksi milik user (atau milik siapa saja jika admin).
+// Transaksi pending langsung menjadi cancelled; transaksi completed dananya dikembalikan
+// ke wallet lalu menjadi refunded. Transaksi yang sudah cancelled/refunded tidak bisa dibatalkan lagi.
+func (s *transactionService) CancelTransaction(ctx context.Context, req *pb.CancelTransactionRequest) (*pb.TransactionResponse, error) {
+	txModel, err := s.getOwnedTransaction(ctx, req.TransactionId, req.UserId, req.IsAdmin)
+	if err != nil {
+		return nil, err
+	}
+	id := txModel.ID
+
+	reason := req.Reason
+	if reason == "" {
+		reason = "cancelled by user"
+		if req.IsAdmin {
+			reason = "cancelled by admin"
+		}
+	}
+
+	if txModel.Status == model.StatusPending {
+		updated, err := s.repo.UpdateTransactionStatus(ctx, txModel.ID, model.StatusPending, model.StatusCancelled, reason)
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			log.Printf("Transaction %d cancelled: %s", txModel.ID, reason)
+			s.watchers.notify(txModel.ID)
+			s.releaseReservation(ctx, txModel)
+			s.releaseCoupon(ctx, txModel)
+			s.voidHold(ctx, txModel)
+			txModel.Status = model.StatusCancelled
+			txModel.FailureReason = reason
+			return toTransactionResponse(txModel), nil
+		}
+
+		// Status berubah bersamaan (mis. hasil pembayaran baru saja diproses), baca ulang
+		txModel, err = s.repo.GetTransactionByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if txModel == nil {
+			return nil, ErrTransactionNotFound
+		}
+	}
+
+	if txModel.Status != model.StatusCompleted {
+		return nil, ErrTransactionNotCancellable
+	}
+
+	if err := s.refundTransaction(ctx, txModel, model.StatusCompleted, reason); err != nil {
+		return nil, err
+	}
+	txModel.Status = model.StatusRefunded
+	txModel.FailureReason = reason
+	return toTransactionResponse(txModel), nil
+}
+
+// CompleteTransaction dipanggil saat wallet-service melaporkan debit berhasil.
+// Hanya transaksi berstatus pending yang diubah menjadi completed, bersamaan dengan pencatatan
+// buku-bukunya ke library user. Jika transaksi sudah dibatalkan sebelum debit selesai, dana
+// yang terlanjur terpotong dikembalikan.
+func (s *transactionService) CompleteTransaction(ctx context.Context, transactionID string) error {
+	id, err := strconv.ParseUint(transactionID, 10, 32)
+	if err != nil {
+		return ErrInvalidTransactionID
+	}
+
+	updated, err := s.repo.CompletePendingTransaction(ctx, uint(id))
+	if err != nil {
+		return err
+	}
+	if updated {
+		log.Printf("Transaction %s marked as %s", transactionID, model.StatusCompleted)
+		s.watchers.notify(uint(id))
+	} else {
+		// Event duplikat atau transaksi sudah final, tidak ada yang perlu diubah
+		log.Printf("Transaction %s is no longer pending, skipping update to %s", transactionID, model.StatusCompleted)
+	}
+
+	txModel, err := s.repo.GetTransactionByID(ctx, uint(id))
+	if err != nil || txModel == nil {
+		return err
+	}
+
+	// Pembayaran berhasil, stok yang ditahan menjadi terjual. Dipanggil juga untuk event yang
+	// terkirim ulang agar commit yang sebelumnya gagal tetap terjadi; book-service mengabaikan
+	// commit berulang untuk reservasi yang sama.
+	if txModel.Status == model.StatusCompleted {
+		if txModel.ReservationID == "" {
+			return nil
+		}
+		err := s.bookClient.CommitReservation(ctx, txModel.ReservationID)
+		if errors.Is(err, client.ErrReservationClosed) {
+			// Mengulang tidak akan membantu, perlu ditangani manual
+			log.Printf("CRITICAL: Stock reservation %s for completed transaction %s cannot be committed: %v", txModel.ReservationID, transactionID, err)
+			return nil
+		}
+		if err != nil {
+			log.Printf("Failed to commit stock reservation %s for transaction %s: %v", txModel.ReservationID, transactionID, err)
+			return err
+		}
+		return nil
+	}
+	if txModel.Status != model.StatusCancelled {
+		return nil
+	}
+
+	log.Printf("Payment succeeded for cancelled transaction %s, refunding", transactionID)
+	return s.refundTransaction(ctx, txModel, model.StatusCancelled, txModel.FailureReason)
+}
+
+// FailTransaction dipanggil saat wallet-service melaporkan debit gagal.
+// Transaksi dibatalkan dan kode serta alasan kegagalan disimpan agar bisa ditampilkan ke user.
+func (s *transactionService) FailTransaction(ctx context.Context, transactionID, failureCode, reason string) error {
+	id, err := strconv.ParseUint(transactionID, 10, 32)
+	if err != nil {
+		return ErrInvalidTransactionID
+	}
+
+	updated, err := s.repo.FailPendingTransaction(ctx, uint(id), failureCode, reason)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		// Event duplikat atau transaksi sudah final, tidak ada yang perlu diubah
+		log.Printf("Transaction %s is no longer pending, skipping update to %s", transactionID, model.StatusCancelled)
+		return nil
+	}
+	log.Printf("Transaction %s marked as %s (%s)", transactionID, model.StatusCancelled, failureCode)
+	s.watchers.notify(uint(id))
+
+	// Pembayaran gagal, kembalikan stok yang ditahan
+	txModel, err := s.repo.GetTransactionByID(ctx, uint(id))
+	if err != nil || txModel == nil {
+		return err
+	}
+	s.releaseReservation(ctx, txModel)
+	s.releaseCoupon(ctx, txModel)
+	s.voidHold(ctx, txModel)
+	return nil
+}
+
+// getOwnedTransaction mengambil transaksi dan memastikan transaksi tersebut milik userID,
+// kecuali jika pemanggilnya admin.
+func (s *transactionService) getOwnedTransaction(ctx context.Context, transactionID, userID string, isAdmin bool) (*model.Transaction, error) {
+	id, err := strconv.ParseUint(transactionID, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidTransactionID
+	}
+
+	txModel, err := s.repo.GetTransactionByID(ctx, uint(id))
+	if err != nil {
+		return nil, err
+	}
+	if txModel == nil {
+		return nil, ErrTransactionNotFound
+	}
+	if !isAdmin && fmt.Sprintf("%d", txModel.UserID) != userID {
+		return nil, ErrNotTransactionOwner
+	}
+	return txModel, nil
+}
+
+// refundTransaction mengembalikan total transaksi ke wallet user lalu mengubah status
+// dari fromStatus menjadi refunded sekaligus mencabut buku-bukunya dari library user. Kredit memakai referensi "refund-<id>" sehingga
+// aman dipanggil ulang jika langkah berikutnya gagal. Kuota kupon yang dipakai transaksi ikut dikembalikan.
+func (s *transactionService) refundTransaction(ctx context.Context, txModel *model.Transaction, fromStatus, reason string) error {
+	_, err := s.walletClient.Credit(ctx, &wallet_pb.CreditRequest{
+		UserId:      fmt.Sprintf("%d", txModel.UserID),
+		Amount:      txModel.TotalAmount.ToProto(),
+		ReferenceId: fmt.Sprintf("refund-%d", txModel.ID),
+		Refund:      true,
+	})
+	if err != nil {
+		log.Printf("Failed to refund transaction %d: %v", txModel.ID, err)
+		return fmt.Errorf("failed to refund transaction: %w", err)
+	}
+
+	if _, err := s.repo.RefundTransaction(ctx, txModel.ID, fromStatus, reason); err != nil {
+		return err
+	}
+	log.Printf("Transaction %d refunded", txModel.ID)
+	s.watchers.notify(txModel.ID)
+	s.releaseCoupon(ctx, txModel)
+	return nil
+}
+
+// priceItems memvalidasi setiap buku lewat book-service dan menghitung total dengan harga saat ini.
+func (s *transactionService) priceItems(ctx context.Context, items []*pb.BookOrderItem) ([]model.TransactionDetail, money.Money, error) {
+	var totalAmount money.Money
+	var details []model.TransactionDetail
+	for _, item := range items {
+		book, err := s.bookClient.GetBookByID(ctx, item.BookId)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to validate book with id %s", item.BookId)
+		}
+		if book.Status != "available" {
+			return nil, 0, fmt.Errorf("book '%s' is not available", book.Title)
+		}
+
+		totalAmount += book.Price.Mul(int64(item.Quantity))
+		details = append(details, model.TransactionDetail{
+			BookID:       book.ID,
+			Category:     book.Category,
+			Quantity:     int(item.Quantity),
+			PricePerUnit: book.Price,
+		})
+	}
+	return details, totalAmount, nil
+}
+
+// verifyQuote memastikan quote asli, belum kedaluwarsa, milik user yang sama,
+// dan (jika items dikirim) berisi item yang sama dengan pesanan.
+func (s *transactionService) verifyQuote(req *pb.CreateTransactionRequest) (*quote.Quote, error) {
+	if s.quotes == nil {
+		return nil, errors.New("quotes are not enabled")
+	}
+
+	q, err := s.quotes.Verify(req.QuoteId)
+	if errors.Is(err, quote.ErrExpired) {
+		return nil, ErrQuoteExpired
+	}
+	if err != nil || q.UserID != req.UserId || len(q.Items) == 0 {
+		return nil, ErrInvalidQuote
+	}
+
+	if len(req.Items) > 0 {
+		if len(req.Items) != len(q.Items) {
+			return nil, ErrInvalidQuote
+		}
+		for i, item := range req.Items {
+			if item.BookId != q.Items[i].BookID || item.Quantity != q.Items[i].Quantity {
+				return nil, ErrInvalidQuote
 			}
 		}
+	}
+	return q, nil
+}
+
+// releaseReservation mengembalikan stok yang ditahan untuk transaksi yang batal.
+// Kegagalan hanya dicatat karena status transaksi sudah final; release aman diulang.
+func (s *transactionService) releaseReservation(ctx context.Context, txModel *model.Transaction) {
+	if txModel.ReservationID == "" {
+		return
+	}
+	if err := s.bookClient.ReleaseReservation(ctx, txModel.ReservationID); err != nil {
+		log.Printf("Failed to release stock reservation %s for transaction %d: %v", txModel.ReservationID, txModel.ID, err)
+	}
+}
 
-		// Buat variabel mappedTx dan isi nilainya
-		mappedTx := &pb.TransactionResponse{
-			TransactionId:   fmt.Sprintf("%d", txModel.ID),
-			UserId:          fmt.Sprintf("%d", txModel.UserID),
-			TransactionDate: timestamppb.New(txModel.CreatedAt),
-			TotalAmount:     txModel.TotalAmount,
-			Status:          txModel.Status,
-			Details:         detailsProto,
+// releaseCoupon mengembalikan kuota kupon milik transaksi yang batal atau di-refund.
+// Seperti releaseReservation, kegagalan hanya dicatat.
+func (s *transactionService) releaseCoupon(ctx context.Context, txModel *model.Transaction) {
+	if txModel.CouponID == nil {
+		return
+	}
+	if err := s.coupons.ReleaseRedemption(ctx, txModel.ID); err != nil {
+		log.Printf("Failed to release coupon %s for transaction %d: %v", txModel.CouponCode, txModel.ID, err)
+	}
+}
+
+// authorizePayment membuat hold sebesar amount di wallet user dengan reservationID sebagai
+// referensi idempotensi, lalu mengembalikan ID hold. Pesanan gratis (amount 0) tidak memerlukan hold.
+func (s *transactionService) authorizePayment(ctx context.Context, userID string, amount money.Money, reservationID string) (string, error) {
+	if !amount.IsPositive() {
+		return "", nil
+	}
+
+	response, err := s.walletClient.Authorize(ctx, &wallet_pb.AuthorizeRequest{
+		UserId:           userID,
+		Amount:           amount.ToProto(),
+		ReferenceId:      reservationID,
+		ExpiresInSeconds: int32(s.holdTTL / time.Second),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition {
+			notAuthorized := &PaymentNotAuthorizedError{Reason: st.Message()}
+			for _, detail := range st.Details() {
+				if info, ok := detail.(*errdetails.ErrorInfo); ok {
+					notAuthorized.Code = info.Reason
+				}
+			}
+			return "", notAuthorized
 		}
+		log.Printf("Failed to authorize payment for user %s: %v", userID, err)
+		return "", errors.New("failed to authorize payment")
+	}
+	return response.Hold.GetId(), nil
+}
 
-		protoTransactions = append(protoTransactions, mappedTx)
+// voidHold melepas dana yang ditahan untuk transaksi yang batal sebelum di-capture.
+// Seperti releaseReservation, kegagalan hanya dicatat; hold yang tidak di-void tetap kedaluwarsa sendiri.
+func (s *transactionService) voidHold(ctx context.Context, txModel *model.Transaction) {
+	if txModel.HoldID == "" {
+		return
+	}
+	if _, err := s.walletClient.Void(ctx, &wallet_pb.VoidRequest{HoldId: txModel.HoldID}); err != nil {
+		log.Printf("Failed to void hold %s for transaction %d: %v", txModel.HoldID, txModel.ID, err)
 	}
+}
 
-	return &pb.GetUserTransactionsResponse{Transactions: protoTransactions}, nil
+// evaluateCoupon mencari kupon berdasarkan kode lalu menghitung potongan untuk pesanan.
+func (s *transactionService) evaluateCoupon(ctx context.Context, code string, details []model.TransactionDetail, subtotal money.Money) (*model.Coupon, money.Money, error) {
+	if s.coupons == nil {
+		return nil, 0, ErrCouponInvalid
+	}
+	coupon, err := s.coupons.FindByCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		log.Printf("Failed to get coupon %s: %v", code, err)
+		return nil, 0, errors.New("failed to validate coupon")
+	}
+	if coupon == nil {
+		return nil, 0, ErrCouponInvalid
+	}
+
+	discount, err := couponDiscount(coupon, details, subtotal, time.Now())
+	if err != nil {
+		return nil, 0, err
+	}
+	return coupon, discount, nil
+}
+
+// newReservationID membuat ID acak untuk reservasi stok di book-service.
+func newReservationID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "txn-" + hex.EncodeToString(b), nil
+}
+
+// encodeCursor mengubah posisi transaksi terakhir menjadi page token yang opaque bagi klien.
+func encodeCursor(cursor repository.TransactionCursor) string {
+	raw := fmt.Sprintf("%d:%d", cursor.CreatedAt.UnixNano(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor adalah kebalikan dari encodeCursor.
+func decodeCursor(token string) (*repository.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	nanos, id, found := strings.Cut(string(raw), ":")
+	if !found {
+		return nil, errors.New("malformed cursor")
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	txID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.TransactionCursor{CreatedAt: time.Unix(0, unixNano).UTC(), ID: uint(txID)}, nil
+}
+
+// toTransactionResponse mengubah model transaksi menjadi response gRPC.
+func toTransactionResponse(txModel *model.Transaction) *pb.TransactionResponse {
+	detailsProto := make([]*pb.TransactionDetail, len(txModel.Details))
+	for i, detail := range txModel.Details {
+		detailsProto[i] = &pb.TransactionDetail{
+			BookId:       detail.BookID,
+			Quantity:     int32(detail.Quantity),
+			PricePerUnit: detail.PricePerUnit.ToProto(),
+		}
+	}
+
+	response := &pb.TransactionResponse{
+		TransactionId:   fmt.Sprintf("%d", txModel.ID),
+		UserId:          fmt.Sprintf("%d", txModel.UserID),
+		TransactionDate: timestamppb.New(txModel.CreatedAt),
+		TotalAmount:     txModel.TotalAmount.ToProto(),
+		SubtotalAmount:  (txModel.TotalAmount + txModel.DiscountAmount).ToProto(),
+		Status:          txModel.Status,
+		FailureReason:   txModel.FailureReason,
+		FailureCode:     txModel.FailureCode,
+		Details:         detailsProto,
+	}
+	if txModel.CouponCode != "" {
+		response.Discount = &pb.DiscountLine{
+			CouponCode:  txModel.CouponCode,
+			Description: "Coupon " + txModel.CouponCode,
+			Amount:      txModel.DiscountAmount.ToProto(),
+		}
+	}
+	return response
 }
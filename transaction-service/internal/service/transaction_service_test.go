@@ -3,40 +3,68 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"shared/events"
+	"shared/money"
 	"testing"
+	"time"
 	"transaction-service/internal/model"
 	"transaction-service/internal/repository"
 	"transaction-service/pkg/client"
-	"transaction-service/pkg/messagebroker"
+	"transaction-service/pkg/quote"
 	pb "transaction-service/proto"
+	walletMocks "transaction-service/proto/mocks"
+	wallet_pb "wallet-service/proto"
 	
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// Skenario 1: Tes CreateTransaction dengan alur Kafka yang sukses
-func TestCreateTransaction_KafkaSuccess(t *testing.T) {
+// Skenario 1: Tes CreateTransaction menyimpan transaksi beserta event outbox
+func TestCreateTransaction_Success(t *testing.T) {
 	// --- Arrange ---
 	mockRepo := new(repository.MockTransactionRepository)
 	mockBookClient := new(client.MockBookServiceClient)
-	mockProducer := new(messagebroker.MockProducer)
 
 	req := &pb.CreateTransactionRequest{
 		UserId: "1",
 		Items:  []*pb.BookOrderItem{{BookId: "101", Quantity: 2}},
 	}
-	mockBook := &client.BookDTO{ID: "101", Status: "available", Price: 50000}
+	mockBook := &client.BookDTO{ID: "101", Status: "available", Price: money.FromRupiah(50000)}
 	
 	// GORM akan mengisi ID setelah Create, jadi kita siapkan modelnya
 	mockSavedTx := &model.Transaction{ID: 99, UserID: 1, Status: "pending"}
 
+	// Event outbox harus dibangun dari transaksi yang sudah tersimpan
+	isTransactionCreatedEvent := mock.MatchedBy(func(newEvent repository.OutboxEventFunc) bool {
+		event, err := newEvent(mockSavedTx)
+		if err != nil || event.Topic != "transaction_created" || event.Key != "1" ||
+			event.Headers[events.HeaderEventType] != events.TypeTransactionCreated || event.Headers[events.HeaderCorrelationID] != "99" {
+			return false
+		}
+		envelope, err := events.Unmarshal(event.Payload)
+		if err != nil || envelope.EventType != events.TypeTransactionCreated || envelope.Producer != EventProducer {
+			return false
+		}
+		payload := envelope.GetTransactionCreated()
+		return payload.TransactionId == "99" && payload.UserId == "1" &&
+			payload.TotalAmount.GetMinorUnits() == money.FromRupiah(100000).Minor() && payload.HoldId == "7"
+	})
+
 	// Program semua mock
 	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(mockBook, nil)
-	mockRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*model.Transaction")).Return(mockSavedTx, nil)
-	mockProducer.On("Publish", mock.Anything, "transaction_created", mock.Anything).Return(nil)
+	mockBookClient.On("ReserveStock", mock.Anything, mock.AnythingOfType("string"), []client.StockItem{{BookID: "101", Quantity: 2}}).Return(nil)
+	mockRepo.On("CreateTransaction", mock.Anything, mock.MatchedBy(func(tx *model.Transaction) bool {
+		return tx.ReservationID != "" && tx.HoldID == "7"
+	}), isTransactionCreatedEvent).Return(mockSavedTx, nil)
+
+	// Dana harus di-authorize sebesar total dengan referensi reservasi stok
+	mockWalletClient := newAuthorizingWallet()
 
-	// Buat instance service dengan mock producer
-	transactionService := NewTransactionService(mockRepo, mockBookClient, nil, mockProducer)
+	transactionService := NewTransactionService(mockRepo, mockBookClient, mockWalletClient, nil, nil, 0)
 
 	// --- Act ---
 	result, err := transactionService.CreateTransaction(context.Background(), req)
@@ -50,30 +78,67 @@ func TestCreateTransaction_KafkaSuccess(t *testing.T) {
 	// Verifikasi semua mock dipanggil
 	mockBookClient.AssertExpectations(t)
 	mockRepo.AssertExpectations(t)
-	mockProducer.AssertExpectations(t)
+	mockWalletClient.AssertCalled(t, "Authorize", mock.Anything, mock.MatchedBy(func(r *wallet_pb.AuthorizeRequest) bool {
+		return r.UserId == "1" && r.Amount.GetMinorUnits() == money.FromRupiah(100000).Minor() && r.ReferenceId != "" &&
+			r.ExpiresInSeconds == int32(DefaultHoldTTL/time.Second)
+	}))
+}
+
+// Skenario 1b: Tes total transaksi dihitung eksak tanpa pembulatan float
+func TestCreateTransaction_ExactTotal(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+
+	req := &pb.CreateTransactionRequest{
+		UserId: "1",
+		Items:  []*pb.BookOrderItem{{BookId: "101", Quantity: 3}, {BookId: "102", Quantity: 3}},
+	}
+	priceA, _ := money.Parse("19999.99")
+	priceB, _ := money.Parse("0.10")
+	expectedTotal, _ := money.Parse("60000.27")
+
+	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Status: "available", Price: priceA}, nil)
+	mockBookClient.On("GetBookByID", mock.Anything, "102").Return(&client.BookDTO{ID: "102", Status: "available", Price: priceB}, nil)
+	mockBookClient.On("ReserveStock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateTransaction", mock.Anything, mock.MatchedBy(func(tx *model.Transaction) bool {
+		return tx.TotalAmount == expectedTotal
+	}), mock.Anything).Return(&model.Transaction{ID: 100, UserID: 1, TotalAmount: expectedTotal, Status: "pending"}, nil)
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, newAuthorizingWallet(), nil, nil, 0)
+
+	// --- Act ---
+	result, err := transactionService.CreateTransaction(context.Background(), req)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, "IDR", result.TotalAmount.CurrencyCode)
+	assert.Equal(t, int64(6000027), result.TotalAmount.MinorUnits)
+	mockRepo.AssertExpectations(t)
 }
 
-// Skenario 2: Tes jika gagal mengirim pesan ke Kafka
-func TestCreateTransaction_KafkaPublishFailed(t *testing.T) {
+// Skenario 2: Tes jika transaksi (beserta outbox) gagal disimpan
+func TestCreateTransaction_RepositoryFailed(t *testing.T) {
 	// --- Arrange ---
 	mockRepo := new(repository.MockTransactionRepository)
 	mockBookClient := new(client.MockBookServiceClient)
-	mockProducer := new(messagebroker.MockProducer)
 
 	req := &pb.CreateTransactionRequest{
 		UserId: "1",
 		Items:  []*pb.BookOrderItem{{BookId: "101", Quantity: 1}},
 	}
-	mockBook := &client.BookDTO{ID: "101", Status: "available", Price: 50000}
-	mockSavedTx := &model.Transaction{ID: 99, UserID: 1}
+	mockBook := &client.BookDTO{ID: "101", Status: "available", Price: money.FromRupiah(50000)}
 
 	// Program mock
 	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(mockBook, nil)
-	mockRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*model.Transaction")).Return(mockSavedTx, nil)
-	// Program Producer untuk GAGAL
-	mockProducer.On("Publish", mock.Anything, "transaction_created", mock.Anything).Return(errors.New("kafka is down"))
+	mockBookClient.On("ReserveStock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	// Program repository untuk GAGAL, stok yang sudah ditahan harus dikembalikan
+	mockRepo.On("CreateTransaction", mock.Anything, mock.AnythingOfType("*model.Transaction"), mock.Anything).Return(nil, errors.New("database is down"))
+	mockBookClient.On("ReleaseReservation", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+	// Hold yang sudah dibuat juga harus dilepas
+	mockWalletClient := newAuthorizingWallet()
 	
-	transactionService := NewTransactionService(mockRepo, mockBookClient, nil, mockProducer)
+	transactionService := NewTransactionService(mockRepo, mockBookClient, mockWalletClient, nil, nil, 0)
 
 	// --- Act ---
 	result, err := transactionService.CreateTransaction(context.Background(), req)
@@ -81,6 +146,519 @@ func TestCreateTransaction_KafkaPublishFailed(t *testing.T) {
 	// --- Assert ---
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Equal(t, "failed to queue transaction", err.Error())
-	mockProducer.AssertExpectations(t)
-}
\ No newline at end of file
+	assert.Equal(t, "failed to create initial transaction", err.Error())
+	mockRepo.AssertExpectations(t)
+	mockBookClient.AssertExpectations(t)
+	mockWalletClient.AssertCalled(t, "Void", mock.Anything, mock.Anything)
+}
+
+// Skenario 2b: Tes CreateTransaction ditolak jika dana tidak bisa di-authorize
+func TestCreateTransaction_PaymentNotAuthorized(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+	mockWalletClient := new(walletMocks.MockWalletServiceClient)
+
+	req := &pb.CreateTransactionRequest{
+		UserId: "1",
+		Items:  []*pb.BookOrderItem{{BookId: "101", Quantity: 1}},
+	}
+	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Status: "available", Price: money.FromRupiah(50000)}, nil)
+	mockBookClient.On("ReserveStock", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockBookClient.On("ReleaseReservation", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+	limitStatus, _ := status.New(codes.FailedPrecondition, "daily spending limit of Rp100.000 exceeded").
+		WithDetails(&errdetails.ErrorInfo{Reason: "daily_limit_exceeded", Domain: "wallet-service"})
+	mockWalletClient.On("Authorize", mock.Anything, mock.Anything).Return(nil, limitStatus.Err())
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, mockWalletClient, nil, nil, 0)
+
+	// --- Act ---
+	result, err := transactionService.CreateTransaction(context.Background(), req)
+
+	// --- Assert ---
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrPaymentNotAuthorized)
+	assert.Contains(t, err.Error(), "daily spending limit")
+	var notAuthorized *PaymentNotAuthorizedError
+	assert.ErrorAs(t, err, &notAuthorized)
+	assert.Equal(t, "daily_limit_exceeded", notAuthorized.Code)
+	mockBookClient.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "CreateTransaction", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Skenario 3: Tes CompleteTransaction saat pembayaran berhasil
+func TestCompleteTransaction_Success(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+	mockRepo.On("CompletePendingTransaction", mock.Anything, uint(99)).Return(true, nil)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, Status: "completed", ReservationID: "txn-abc"}, nil)
+	mockBookClient.On("CommitReservation", mock.Anything, "txn-abc").Return(nil)
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, nil, nil, nil, 0)
+
+	// --- Act ---
+	err := transactionService.CompleteTransaction(context.Background(), "99")
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockBookClient.AssertExpectations(t)
+}
+
+// Skenario 3b: Tes membatalkan transaksi pending melepas hold dananya
+func TestCancelTransaction_PendingVoidsHold(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockWalletClient := newAuthorizingWallet()
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, UserID: 1, Status: "pending", HoldID: "7"}, nil)
+	mockRepo.On("UpdateTransactionStatus", mock.Anything, uint(99), "pending", "cancelled", "cancelled by user").Return(true, nil)
+
+	transactionService := NewTransactionService(mockRepo, nil, mockWalletClient, nil, nil, 0)
+
+	// --- Act ---
+	result, err := transactionService.CancelTransaction(context.Background(), &pb.CancelTransactionRequest{TransactionId: "99", UserId: "1"})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, "cancelled", result.Status)
+	mockWalletClient.AssertCalled(t, "Void", mock.Anything, mock.Anything)
+}
+
+// Skenario 4: Tes FailTransaction menyimpan alasan kegagalan
+func TestFailTransaction_StoresReason(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+	mockRepo.On("FailPendingTransaction", mock.Anything, uint(99), "insufficient_funds", "insufficient funds").Return(true, nil)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, Status: "cancelled", ReservationID: "txn-abc"}, nil)
+	mockBookClient.On("ReleaseReservation", mock.Anything, "txn-abc").Return(nil)
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, nil, nil, nil, 0)
+
+	// --- Act ---
+	err := transactionService.FailTransaction(context.Background(), "99", "insufficient_funds", "insufficient funds")
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockBookClient.AssertExpectations(t)
+}
+
+// Skenario 4a: Tes hold yang kedaluwarsa sebelum di-capture membatalkan transaksi, mengembalikan
+// stok, dan tidak gagal walaupun hold sudah tidak bisa di-void
+func TestFailTransaction_HoldExpiredAtCapture(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+	mockWalletClient := new(walletMocks.MockWalletServiceClient)
+	mockRepo.On("FailPendingTransaction", mock.Anything, uint(99), "authorization_expired", "hold has expired").Return(true, nil)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, Status: "cancelled", ReservationID: "txn-abc", HoldID: "7"}, nil)
+	mockBookClient.On("ReleaseReservation", mock.Anything, "txn-abc").Return(nil)
+	mockWalletClient.On("Void", mock.Anything, mock.MatchedBy(func(r *wallet_pb.VoidRequest) bool { return r.HoldId == "7" })).
+		Return(&wallet_pb.HoldResponse{Hold: &wallet_pb.Hold{Id: "7", Status: "expired"}}, nil)
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, mockWalletClient, nil, nil, time.Hour)
+
+	// --- Act ---
+	err := transactionService.FailTransaction(context.Background(), "99", "authorization_expired", "hold has expired")
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockBookClient.AssertExpectations(t)
+	mockWalletClient.AssertExpectations(t)
+}
+
+// Skenario 4b: Tes CreateTransaction ditolak jika stok tidak cukup
+func TestCreateTransaction_OutOfStock(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+
+	req := &pb.CreateTransactionRequest{
+		UserId: "1",
+		Items:  []*pb.BookOrderItem{{BookId: "101", Quantity: 1}},
+	}
+	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Status: "available", Price: money.FromRupiah(50000)}, nil)
+	mockBookClient.On("ReserveStock", mock.Anything, mock.Anything, mock.Anything).Return(client.ErrOutOfStock)
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, nil, nil, nil, 0)
+
+	// --- Act ---
+	result, err := transactionService.CreateTransaction(context.Background(), req)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, ErrOutOfStock)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "CreateTransaction", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Skenario 4c: Tes QuoteOrder mengembalikan quote bertanda tangan dengan harga saat ini
+func TestQuoteOrder_Success(t *testing.T) {
+	// --- Arrange ---
+	mockBookClient := new(client.MockBookServiceClient)
+	signer := quote.NewSigner([]byte("secret"), 15*time.Minute)
+	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Status: "available", Price: money.FromRupiah(50000)}, nil)
+
+	transactionService := NewTransactionService(nil, mockBookClient, nil, signer, nil, 0)
+
+	// --- Act ---
+	result, err := transactionService.QuoteOrder(context.Background(), &pb.QuoteOrderRequest{
+		UserId: "1",
+		Items:  []*pb.BookOrderItem{{BookId: "101", Quantity: 2}},
+	})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.QuoteId)
+	assert.Equal(t, int64(10000000), result.TotalAmount.MinorUnits)
+	assert.True(t, result.ExpiresAt.AsTime().After(time.Now()))
+
+	q, err := signer.Verify(result.QuoteId)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", q.UserID)
+	assert.Equal(t, money.FromRupiah(100000), q.TotalAmount)
+}
+
+// Skenario 4d: Tes checkout dengan quote memakai harga di quote walaupun harga buku sudah berubah
+func TestCreateTransaction_WithQuoteChargesQuotedPrice(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+	signer := quote.NewSigner([]byte("secret"), 15*time.Minute)
+
+	token, _ := signer.Sign(&quote.Quote{
+		UserID:      "1",
+		Items:       []quote.Item{{BookID: "101", Quantity: 2, PricePerUnit: money.FromRupiah(50000)}},
+		TotalAmount: money.FromRupiah(100000),
+	})
+
+	// Harga buku naik setelah quote dibuat
+	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Status: "available", Price: money.FromRupiah(75000)}, nil)
+	mockBookClient.On("ReserveStock", mock.Anything, mock.Anything, []client.StockItem{{BookID: "101", Quantity: 2}}).Return(nil)
+	mockRepo.On("CreateTransaction", mock.Anything, mock.MatchedBy(func(tx *model.Transaction) bool {
+		return tx.TotalAmount == money.FromRupiah(100000) && tx.Details[0].PricePerUnit == money.FromRupiah(50000)
+	}), mock.Anything).Return(&model.Transaction{ID: 99, UserID: 1, TotalAmount: money.FromRupiah(100000), Status: "pending"}, nil)
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, newAuthorizingWallet(), signer, nil, 0)
+
+	// --- Act ---
+	result, err := transactionService.CreateTransaction(context.Background(), &pb.CreateTransactionRequest{UserId: "1", QuoteId: token})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, "99", result.TransactionId)
+	mockRepo.AssertExpectations(t)
+	mockBookClient.AssertExpectations(t)
+}
+
+// Skenario 4e: Tes checkout ditolak jika quote kedaluwarsa, dipalsukan, atau milik user lain
+func TestCreateTransaction_RejectsBadQuote(t *testing.T) {
+	valid := quote.NewSigner([]byte("secret"), 15*time.Minute)
+	expired := quote.NewSigner([]byte("secret"), -time.Minute)
+	newQuote := func() *quote.Quote {
+		return &quote.Quote{
+			UserID:      "1",
+			Items:       []quote.Item{{BookID: "101", Quantity: 1, PricePerUnit: money.FromRupiah(50000)}},
+			TotalAmount: money.FromRupiah(50000),
+		}
+	}
+	validToken, _ := valid.Sign(newQuote())
+	expiredToken, _ := expired.Sign(newQuote())
+
+	tests := []struct {
+		name    string
+		req     *pb.CreateTransactionRequest
+		wantErr error
+	}{
+		{"expired", &pb.CreateTransactionRequest{UserId: "1", QuoteId: expiredToken}, ErrQuoteExpired},
+		{"tampered", &pb.CreateTransactionRequest{UserId: "1", QuoteId: validToken + "x"}, ErrInvalidQuote},
+		{"other user", &pb.CreateTransactionRequest{UserId: "2", QuoteId: validToken}, ErrInvalidQuote},
+		{"different items", &pb.CreateTransactionRequest{
+			UserId:  "1",
+			QuoteId: validToken,
+			Items:   []*pb.BookOrderItem{{BookId: "101", Quantity: 5}},
+		}, ErrInvalidQuote},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockTransactionRepository)
+			mockBookClient := new(client.MockBookServiceClient)
+			transactionService := NewTransactionService(mockRepo, mockBookClient, nil, valid, nil, 0)
+
+			result, err := transactionService.CreateTransaction(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, result)
+			mockBookClient.AssertNotCalled(t, "ReserveStock", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// Skenario 5: Tes event duplikat untuk transaksi yang sudah final diabaikan
+func TestCompleteTransaction_AlreadyFinal(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockRepo.On("CompletePendingTransaction", mock.Anything, uint(99)).Return(false, nil)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, Status: "completed"}, nil)
+
+	transactionService := NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+	// --- Act ---
+	err := transactionService.CompleteTransaction(context.Background(), "99")
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// Skenario 5b: Tes event yang terkirim ulang tetap meng-commit reservasi stok, sehingga
+// commit yang gagal pada pengiriman pertama tidak membuat reservasi tertahan selamanya
+func TestCompleteTransaction_RedeliveryCommitsReservation(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+	mockRepo.On("CompletePendingTransaction", mock.Anything, uint(99)).Return(true, nil).Once()
+	mockRepo.On("CompletePendingTransaction", mock.Anything, uint(99)).Return(false, nil)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, Status: "completed", ReservationID: "txn-abc"}, nil)
+	mockBookClient.On("CommitReservation", mock.Anything, "txn-abc").Return(errors.New("book-service unavailable")).Once()
+	mockBookClient.On("CommitReservation", mock.Anything, "txn-abc").Return(nil).Once()
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, nil, nil, nil, 0)
+
+	// --- Act ---
+	firstErr := transactionService.CompleteTransaction(context.Background(), "99")
+	err := transactionService.CompleteTransaction(context.Background(), "99")
+
+	// --- Assert ---
+	assert.Error(t, firstErr)
+	assert.NoError(t, err)
+	mockBookClient.AssertExpectations(t)
+}
+
+// Skenario 5c: Tes reservasi yang sudah ditutup tidak dicoba ulang terus-menerus
+func TestCompleteTransaction_ReservationClosed(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+	mockRepo.On("CompletePendingTransaction", mock.Anything, uint(99)).Return(false, nil)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, Status: "completed", ReservationID: "txn-abc"}, nil)
+	mockBookClient.On("CommitReservation", mock.Anything, "txn-abc").Return(fmt.Errorf("%w: book-service returned status 409 on commit", client.ErrReservationClosed))
+
+	transactionService := NewTransactionService(mockRepo, mockBookClient, nil, nil, nil, 0)
+
+	err := transactionService.CompleteTransaction(context.Background(), "99")
+
+	assert.NoError(t, err)
+	mockBookClient.AssertExpectations(t)
+}
+
+// Skenario 6: Tes ID transaksi tidak valid dari event
+func TestFailTransaction_InvalidID(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+	transactionService := NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+	err := transactionService.FailTransaction(context.Background(), "abc", "insufficient_funds", "insufficient funds")
+
+	assert.Error(t, err)
+	assert.Equal(t, "invalid transaction id format", err.Error())
+	mockRepo.AssertNotCalled(t, "FailPendingTransaction")
+}
+
+// Skenario 7: Tes pembatalan transaksi pending oleh pemiliknya
+func TestCancelTransaction_Pending(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, UserID: 1, Status: "pending"}, nil)
+	mockRepo.On("UpdateTransactionStatus", mock.Anything, uint(99), "pending", "cancelled", "cancelled by user").Return(true, nil)
+
+	transactionService := NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+	// --- Act ---
+	result, err := transactionService.CancelTransaction(context.Background(), &pb.CancelTransactionRequest{TransactionId: "99", UserId: "1"})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, "cancelled", result.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+// Skenario 8: Tes pembatalan transaksi completed oleh admin mengembalikan dana
+func TestCancelTransaction_CompletedIsRefunded(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockWalletClient := new(walletMocks.MockWalletServiceClient)
+
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, UserID: 1, Status: "completed", TotalAmount: money.FromRupiah(100000)}, nil)
+	mockWalletClient.On("Credit", mock.Anything, mock.MatchedBy(func(req *wallet_pb.CreditRequest) bool {
+		return req.UserId == "1" && req.ReferenceId == "refund-99" && req.Refund &&
+			req.Amount.GetMinorUnits() == money.FromRupiah(100000).Minor()
+	})).Return(&wallet_pb.CreditResponse{Success: true}, nil)
+	mockRepo.On("RefundTransaction", mock.Anything, uint(99), "completed", "duplicate order").Return(true, nil)
+
+	transactionService := NewTransactionService(mockRepo, nil, mockWalletClient, nil, nil, 0)
+
+	// --- Act ---
+	result, err := transactionService.CancelTransaction(context.Background(), &pb.CancelTransactionRequest{
+		TransactionId: "99", UserId: "7", IsAdmin: true, Reason: "duplicate order",
+	})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, "refunded", result.Status)
+	mockRepo.AssertExpectations(t)
+	mockWalletClient.AssertExpectations(t)
+}
+
+// Skenario 9: Tes user tidak bisa membatalkan transaksi milik user lain
+func TestCancelTransaction_NotOwner(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, UserID: 1, Status: "pending"}, nil)
+
+	transactionService := NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+	_, err := transactionService.CancelTransaction(context.Background(), &pb.CancelTransactionRequest{TransactionId: "99", UserId: "2"})
+
+	assert.ErrorIs(t, err, ErrNotTransactionOwner)
+	mockRepo.AssertNotCalled(t, "UpdateTransactionStatus")
+}
+
+// Skenario 10: Tes transaksi yang sudah dibatalkan tidak bisa dibatalkan lagi
+func TestCancelTransaction_AlreadyCancelled(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, UserID: 1, Status: "cancelled"}, nil)
+
+	transactionService := NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+	_, err := transactionService.CancelTransaction(context.Background(), &pb.CancelTransactionRequest{TransactionId: "99", UserId: "1"})
+
+	assert.ErrorIs(t, err, ErrTransactionNotCancellable)
+}
+
+// Skenario 11: Tes pembayaran yang berhasil setelah transaksi dibatalkan langsung di-refund
+func TestCompleteTransaction_CancelledIsRefunded(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockWalletClient := new(walletMocks.MockWalletServiceClient)
+
+	mockRepo.On("CompletePendingTransaction", mock.Anything, uint(99)).Return(false, nil)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, UserID: 1, Status: "cancelled", FailureReason: "cancelled by user", TotalAmount: money.FromRupiah(50000)}, nil)
+	mockWalletClient.On("Credit", mock.Anything, mock.MatchedBy(func(req *wallet_pb.CreditRequest) bool {
+		return req.ReferenceId == "refund-99" && req.Refund
+	})).Return(&wallet_pb.CreditResponse{Success: true}, nil)
+	mockRepo.On("RefundTransaction", mock.Anything, uint(99), "cancelled", "cancelled by user").Return(true, nil)
+
+	transactionService := NewTransactionService(mockRepo, nil, mockWalletClient, nil, nil, 0)
+
+	// --- Act ---
+	err := transactionService.CompleteTransaction(context.Background(), "99")
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockWalletClient.AssertExpectations(t)
+}
+
+// Skenario 12: Tes GetTransaction mengembalikan transaksi beserta detailnya
+func TestGetTransaction_Success(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{
+		ID: 99, UserID: 1, Status: "completed", TotalAmount: money.FromRupiah(100000),
+		Details: []model.TransactionDetail{{BookID: "101", Quantity: 2, PricePerUnit: money.FromRupiah(50000)}},
+	}, nil)
+
+	transactionService := NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+	// --- Act ---
+	result, err := transactionService.GetTransaction(context.Background(), &pb.GetTransactionRequest{TransactionId: "99", UserId: "1"})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, "99", result.TransactionId)
+	assert.Equal(t, "completed", result.Status)
+	assert.Len(t, result.Details, 1)
+	assert.Equal(t, "101", result.Details[0].BookId)
+}
+
+// Skenario 13: Tes GetTransaction menolak transaksi milik user lain dan ID yang tidak ada
+func TestGetTransaction_NotOwnerOrNotFound(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, UserID: 1}, nil)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(100)).Return(nil, nil)
+
+	transactionService := NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+	_, err := transactionService.GetTransaction(context.Background(), &pb.GetTransactionRequest{TransactionId: "99", UserId: "2"})
+	assert.ErrorIs(t, err, ErrNotTransactionOwner)
+
+	_, err = transactionService.GetTransaction(context.Background(), &pb.GetTransactionRequest{TransactionId: "100", UserId: "1"})
+	assert.ErrorIs(t, err, ErrTransactionNotFound)
+}
+
+// Skenario 14: Tes GetUserTransactions mengembalikan cursor jika masih ada halaman berikutnya
+func TestGetUserTransactions_Paginated(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	rows := []model.Transaction{
+		{ID: 12, UserID: 1, Status: "completed", CreatedAt: createdAt.Add(2 * time.Minute)},
+		{ID: 11, UserID: 1, Status: "completed", CreatedAt: createdAt.Add(time.Minute)},
+		{ID: 10, UserID: 1, Status: "completed", CreatedAt: createdAt},
+	}
+	mockRepo.On("GetTransactionsByUserID", mock.Anything, mock.MatchedBy(func(f repository.TransactionFilter) bool {
+		return f.UserID == 1 && f.Status == "completed" && f.Limit == 3 && f.After == nil
+	})).Return(rows, int64(5), nil)
+
+	transactionService := NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+	// --- Act ---
+	result, err := transactionService.GetUserTransactions(context.Background(), &pb.GetUserTransactionsRequest{
+		UserId: "1", PageSize: 2, Status: "completed",
+	})
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Len(t, result.Transactions, 2)
+	assert.Equal(t, int64(5), result.TotalCount)
+	assert.NotEmpty(t, result.NextPageToken)
+
+	// Cursor menunjuk ke transaksi terakhir di halaman ini
+	cursor, err := decodeCursor(result.NextPageToken)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(11), cursor.ID)
+	assert.True(t, cursor.CreatedAt.Equal(createdAt.Add(time.Minute)))
+}
+
+// Skenario 15: Tes GetUserTransactions memakai cursor dan menolak filter yang tidak valid
+func TestGetUserTransactions_CursorAndValidation(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+	token := encodeCursor(repository.TransactionCursor{CreatedAt: time.Unix(1700000000, 0).UTC(), ID: 11})
+	mockRepo.On("GetTransactionsByUserID", mock.Anything, mock.MatchedBy(func(f repository.TransactionFilter) bool {
+		return f.After != nil && f.After.ID == 11 && f.Limit == defaultPageSize+1
+	})).Return([]model.Transaction{{ID: 10, UserID: 1}}, int64(3), nil)
+
+	transactionService := NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+	result, err := transactionService.GetUserTransactions(context.Background(), &pb.GetUserTransactionsRequest{UserId: "1", PageToken: token})
+	assert.NoError(t, err)
+	assert.Len(t, result.Transactions, 1)
+	assert.Empty(t, result.NextPageToken)
+
+	_, err = transactionService.GetUserTransactions(context.Background(), &pb.GetUserTransactionsRequest{UserId: "1", PageToken: "not-a-token"})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, err = transactionService.GetUserTransactions(context.Background(), &pb.GetUserTransactionsRequest{UserId: "1", Status: "shipped"})
+	assert.ErrorIs(t, err, ErrInvalidStatusFilter)
+}
+
+// newAuthorizingWallet mengembalikan mock wallet-service yang selalu berhasil membuat hold "7".
+func newAuthorizingWallet() *walletMocks.MockWalletServiceClient {
+	mockWalletClient := new(walletMocks.MockWalletServiceClient)
+	mockWalletClient.On("Authorize", mock.Anything, mock.Anything).
+		Return(&wallet_pb.HoldResponse{Hold: &wallet_pb.Hold{Id: "7", Status: "active"}}, nil)
+	mockWalletClient.On("Void", mock.Anything, mock.MatchedBy(func(r *wallet_pb.VoidRequest) bool { return r.HoldId == "7" })).
+		Return(&wallet_pb.HoldResponse{Hold: &wallet_pb.Hold{Id: "7", Status: "voided"}}, nil).Maybe()
+	return mockWalletClient
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"transaction-service/internal/model"
+	pb "transaction-service/proto"
+)
+
+// defaultWatchPollInterval adalah jeda pengecekan ulang status oleh WatchTransaction. Perubahan
+// yang diproses instance ini langsung dikirim; polling menangkap perubahan dari instance lain.
+const defaultWatchPollInterval = 2 * time.Second
+
+// statusNotifier membangunkan WatchTransaction yang sedang menunggu perubahan status
+// transaksi tertentu di instance ini.
+type statusNotifier struct {
+	mu       sync.Mutex
+	watchers map[uint]map[chan struct{}]struct{}
+}
+
+func newStatusNotifier() *statusNotifier {
+	return &statusNotifier{watchers: make(map[uint]map[chan struct{}]struct{})}
+}
+
+// subscribe mendaftarkan watcher untuk transaksi id. Channel menerima sinyal setiap kali
+// notify dipanggil; fungsi yang dikembalikan wajib dipanggil untuk berhenti mendengarkan.
+func (n *statusNotifier) subscribe(id uint) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.watchers[id] == nil {
+		n.watchers[id] = make(map[chan struct{}]struct{})
+	}
+	n.watchers[id][ch] = struct{}{}
+
+	return ch, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.watchers[id], ch)
+		if len(n.watchers[id]) == 0 {
+			delete(n.watchers, id)
+		}
+	}
+}
+
+// notify memberi tahu semua watcher transaksi id bahwa statusnya berubah.
+func (n *statusNotifier) notify(id uint) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for ch := range n.watchers[id] {
+		select {
+		case ch <- struct{}{}:
+		default: // Sinyal sebelumnya belum dibaca, watcher tetap akan membaca ulang status
+		}
+	}
+}
+
+// WatchTransaction mengirim status transaksi saat ini lalu setiap perubahan statusnya lewat send,
+// sampai transaksi tidak lagi pending atau ctx selesai. Transaksi milik user lain ditolak
+// dengan ErrNotTransactionOwner.
+func (s *transactionService) WatchTransaction(ctx context.Context, req *pb.WatchTransactionRequest, send func(*pb.TransactionStatusEvent) error) error {
+	id, err := strconv.ParseUint(req.TransactionId, 10, 32)
+	if err != nil {
+		return ErrInvalidTransactionID
+	}
+
+	// Daftar sebelum membaca status agar perubahan di antaranya tidak terlewat
+	changed, unsubscribe := s.watchers.subscribe(uint(id))
+	defer unsubscribe()
+
+	txModel, err := s.getOwnedTransaction(ctx, req.TransactionId, req.UserId, false)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(s.watchPollInterval)
+	defer ticker.Stop()
+
+	lastStatus := ""
+	for {
+		if txModel.Status != lastStatus {
+			if err := send(toTransactionStatusEvent(txModel)); err != nil {
+				return err
+			}
+			lastStatus = txModel.Status
+		}
+		if txModel.Status != model.StatusPending {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		case <-ticker.C:
+		}
+
+		txModel, err = s.repo.GetTransactionByID(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+		if txModel == nil {
+			return ErrTransactionNotFound
+		}
+	}
+}
+
+func toTransactionStatusEvent(txModel *model.Transaction) *pb.TransactionStatusEvent {
+	return &pb.TransactionStatusEvent{
+		TransactionId: fmt.Sprintf("%d", txModel.ID),
+		Status:        txModel.Status,
+		FailureCode:   txModel.FailureCode,
+		FailureReason: txModel.FailureReason,
+		UpdatedAt:     timestamppb.New(txModel.UpdatedAt),
+	}
+}
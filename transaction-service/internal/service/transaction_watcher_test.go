@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	"transaction-service/internal/model"
+	"transaction-service/internal/repository"
+	pb "transaction-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Skenario 1: Status awal dikirim, lalu perubahan dari CompleteTransaction langsung diteruskan
+// tanpa menunggu polling, dan stream selesai setelah transaksi tidak lagi pending
+func TestWatchTransaction_StreamsUntilFinal(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, UserID: 1, Status: "pending"}, nil).Once()
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, UserID: 1, Status: "completed"}, nil)
+	mockRepo.On("CompletePendingTransaction", mock.Anything, uint(99)).Return(true, nil)
+
+	svc := NewTransactionService(mockRepo, nil, nil, nil, nil, 0).(*transactionService)
+	svc.watchPollInterval = time.Hour
+
+	received := make(chan *pb.TransactionStatusEvent, 2)
+	done := make(chan error, 1)
+
+	// --- Act ---
+	go func() {
+		done <- svc.WatchTransaction(context.Background(), &pb.WatchTransactionRequest{TransactionId: "99", UserId: "1"},
+			func(event *pb.TransactionStatusEvent) error {
+				received <- event
+				return nil
+			})
+	}()
+	assert.Equal(t, "pending", (<-received).Status)
+	assert.NoError(t, svc.CompleteTransaction(context.Background(), "99"))
+
+	// --- Assert ---
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("WatchTransaction did not finish after the transaction completed")
+	}
+	final := <-received
+	assert.Equal(t, "99", final.TransactionId)
+	assert.Equal(t, "completed", final.Status)
+}
+
+// Skenario 2: Transaksi milik user lain tidak bisa dipantau
+func TestWatchTransaction_NotOwner(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, UserID: 2, Status: "pending"}, nil)
+	transactionService := NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+	err := transactionService.WatchTransaction(context.Background(), &pb.WatchTransactionRequest{TransactionId: "99", UserId: "1"},
+		func(*pb.TransactionStatusEvent) error {
+			t.Fatal("no event should be sent")
+			return nil
+		})
+
+	assert.ErrorIs(t, err, ErrNotTransactionOwner)
+}
+
+// Skenario 3: Polling tidak mengirim ulang status yang sama, dan stream berhenti saat client pergi
+func TestWatchTransaction_ContextCancelled(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, UserID: 1, Status: "pending"}, nil)
+	svc := NewTransactionService(mockRepo, nil, nil, nil, nil, 0).(*transactionService)
+	svc.watchPollInterval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	sent := 0
+	err := svc.WatchTransaction(ctx, &pb.WatchTransactionRequest{TransactionId: "99", UserId: "1"},
+		func(*pb.TransactionStatusEvent) error {
+			sent++
+			return nil
+		})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, sent)
+}
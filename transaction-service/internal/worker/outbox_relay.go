@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"shared/messagebroker"
+	"time"
+	"transaction-service/internal/repository"
+)
+
+const (
+	outboxBatchSize  = 100
+	outboxMaxBackoff = 5 * time.Minute
+)
+
+// StartOutboxRelay memulai goroutine yang secara berkala mengirim event outbox
+// yang masih pending ke Kafka. Event yang gagal dikirim akan dicoba lagi dengan
+// backoff eksponensial sampai berhasil (at-least-once).
+func StartOutboxRelay(ctx context.Context, repo repository.OutboxRepository, producer messagebroker.Producer, interval time.Duration) {
+	log.Printf("Outbox relay started (interval %s)\n", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("Outbox relay stopped.")
+				return
+			case <-ticker.C:
+				if _, err := RelayPendingEvents(ctx, repo, producer); err != nil {
+					log.Printf("Outbox relay failed to load pending events: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// RelayPendingEvents mengirim satu batch event pending dan mengembalikan jumlah event yang terkirim.
+// Jika sebuah event gagal dikirim, event berikutnya dengan key yang sama di batch ini dilewati
+// agar consumer tidak menerimanya mendahului event yang gagal.
+func RelayPendingEvents(ctx context.Context, repo repository.OutboxRepository, producer messagebroker.Producer) (int, error) {
+	events, err := repo.GetPendingOutboxEvents(ctx, outboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	blockedKeys := make(map[string]bool)
+	for _, event := range events {
+		if event.Key != "" && blockedKeys[event.Key] {
+			continue
+		}
+		if err := producer.Publish(ctx, messagebroker.Message{
+			Topic:   event.Topic,
+			Key:     event.Key,
+			Value:   json.RawMessage(event.Payload),
+			Headers: event.Headers,
+		}); err != nil {
+			attempts := event.Attempts + 1
+			nextAttemptAt := time.Now().Add(outboxBackoff(attempts))
+			log.Printf("Failed to publish outbox event %d to %s (attempt %d): %v", event.ID, event.Topic, attempts, err)
+			blockedKeys[event.Key] = true
+			if err := repo.MarkOutboxEventFailed(ctx, event.ID, attempts, nextAttemptAt, err.Error()); err != nil {
+				log.Printf("Failed to record outbox failure for event %d: %v", event.ID, err)
+			}
+			continue
+		}
+
+		if err := repo.MarkOutboxEventSent(ctx, event.ID); err != nil {
+			// Event sudah terkirim; jika gagal ditandai, event akan terkirim ulang (consumer harus idempoten)
+			log.Printf("Failed to mark outbox event %d as sent: %v", event.ID, err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// outboxBackoff menghitung jeda sebelum percobaan berikutnya: 1s, 2s, 4s, ... maksimal 5 menit.
+func outboxBackoff(attempts int) time.Duration {
+	if attempts > 9 {
+		return outboxMaxBackoff
+	}
+	backoff := time.Second << uint(attempts-1)
+	if backoff > outboxMaxBackoff {
+		return outboxMaxBackoff
+	}
+	return backoff
+}
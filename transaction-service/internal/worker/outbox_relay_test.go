@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"shared/messagebroker"
+	"testing"
+	"time"
+	"transaction-service/internal/model"
+	"transaction-service/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Skenario 1: Event pending berhasil dikirim lalu ditandai sent
+func TestRelayPendingEvents_Success(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockOutboxRepository)
+	mockProducer := new(messagebroker.MockProducer)
+
+	payload := []byte(`{"transaction_id":"99"}`)
+	headers := map[string]string{"event-type": "transaction.created", "correlation-id": "99"}
+	events := []model.OutboxEvent{{ID: 1, Topic: "transaction_created", Key: "1", Headers: headers, Payload: payload}}
+
+	mockRepo.On("GetPendingOutboxEvents", mock.Anything, outboxBatchSize).Return(events, nil)
+	mockProducer.On("Publish", mock.Anything, messagebroker.Message{
+		Topic:   "transaction_created",
+		Key:     "1",
+		Value:   json.RawMessage(payload),
+		Headers: headers,
+	}).Return(nil)
+	mockRepo.On("MarkOutboxEventSent", mock.Anything, uint(1)).Return(nil)
+
+	// --- Act ---
+	sent, err := RelayPendingEvents(context.Background(), mockRepo, mockProducer)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	mockRepo.AssertExpectations(t)
+	mockProducer.AssertExpectations(t)
+}
+
+// Skenario 2: Kafka mati, event tetap pending dan dijadwalkan ulang
+func TestRelayPendingEvents_PublishFailed(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockOutboxRepository)
+	mockProducer := new(messagebroker.MockProducer)
+
+	events := []model.OutboxEvent{{ID: 1, Topic: "transaction_created", Payload: []byte(`{}`), Attempts: 2}}
+
+	mockRepo.On("GetPendingOutboxEvents", mock.Anything, outboxBatchSize).Return(events, nil)
+	mockProducer.On("Publish", mock.Anything, mock.Anything).Return(errors.New("kafka is down"))
+	mockRepo.On("MarkOutboxEventFailed", mock.Anything, uint(1), 3, mock.AnythingOfType("time.Time"), "kafka is down").Return(nil)
+
+	// --- Act ---
+	sent, err := RelayPendingEvents(context.Background(), mockRepo, mockProducer)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "MarkOutboxEventSent", mock.Anything, mock.Anything)
+}
+
+// Skenario 3: Event gagal dikirim, event berikutnya dengan key yang sama di batch ditahan
+func TestRelayPendingEvents_PreservesKeyOrder(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockOutboxRepository)
+	mockProducer := new(messagebroker.MockProducer)
+
+	events := []model.OutboxEvent{
+		{ID: 1, Topic: "transaction_created", Key: "1", Payload: []byte(`{"transaction_id":"10"}`)},
+		{ID: 2, Topic: "transaction_created", Key: "1", Payload: []byte(`{"transaction_id":"11"}`)},
+		{ID: 3, Topic: "transaction_created", Key: "2", Payload: []byte(`{"transaction_id":"12"}`)},
+	}
+
+	mockRepo.On("GetPendingOutboxEvents", mock.Anything, outboxBatchSize).Return(events, nil)
+	mockProducer.On("Publish", mock.Anything, mock.MatchedBy(func(m messagebroker.Message) bool { return m.Key == "1" })).
+		Return(errors.New("kafka is down")).Once()
+	mockProducer.On("Publish", mock.Anything, mock.MatchedBy(func(m messagebroker.Message) bool { return m.Key == "2" })).
+		Return(nil).Once()
+	mockRepo.On("MarkOutboxEventFailed", mock.Anything, uint(1), 1, mock.AnythingOfType("time.Time"), "kafka is down").Return(nil)
+	mockRepo.On("MarkOutboxEventSent", mock.Anything, uint(3)).Return(nil)
+
+	// --- Act ---
+	sent, err := RelayPendingEvents(context.Background(), mockRepo, mockProducer)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	mockRepo.AssertExpectations(t)
+	mockProducer.AssertExpectations(t)
+	mockProducer.AssertNumberOfCalls(t, "Publish", 2)
+	mockRepo.AssertNotCalled(t, "MarkOutboxEventFailed", mock.Anything, uint(2), mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, outboxBackoff(1))
+	assert.Equal(t, 4*time.Second, outboxBackoff(3))
+	assert.Equal(t, outboxMaxBackoff, outboxBackoff(20))
+}
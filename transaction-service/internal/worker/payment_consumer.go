@@ -0,0 +1,196 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"shared/events"
+	"shared/messagebroker"
+	"time"
+	"transaction-service/internal/model"
+	"transaction-service/internal/repository"
+	"transaction-service/internal/service"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Topic hasil pembayaran yang dikirim oleh wallet-service.
+const (
+	TopicPaymentSuccess = "payment_success"
+	TopicPaymentFailed  = "payment_failed"
+)
+
+// ErrRejectedPaymentEvent menandai event yang tidak akan pernah bisa diproses, mis. jenis
+// atau versi skema yang tidak dikenal. Event seperti ini dilewati tanpa dicoba ulang.
+var ErrRejectedPaymentEvent = errors.New("rejected payment event")
+
+// DeadLetterSuffix ditambahkan ke nama topic asal untuk membentuk nama topic dead-letter.
+const DeadLetterSuffix = ".dlq"
+
+// DeadLetterMessage adalah isi pesan yang dikirim ke topic dead-letter.
+type DeadLetterMessage struct {
+	OriginalTopic string    `json:"original_topic"`
+	Partition     int       `json:"partition"`
+	Offset        int64     `json:"offset"`
+	Key           string    `json:"key,omitempty"`
+	Payload       string    `json:"payload"`
+	Error         string    `json:"error"`
+	Attempts      int       `json:"attempts"`
+	FailedAt      time.Time `json:"failed_at"`
+}
+
+// Jeda sebelum event yang gagal dicoba ulang. Jeda berlipat dua setiap percobaan sampai
+// maxPaymentRetryBackoff; setelah maxPaymentAttempts percobaan event dipindahkan ke dead-letter.
+var (
+	paymentRetryBackoff    = 500 * time.Millisecond
+	maxPaymentRetryBackoff = 30 * time.Second
+	maxPaymentAttempts     = 10
+)
+
+// StartPaymentConsumer memulai worker yang mendengarkan topic hasil pembayaran
+// dan memfinalisasi transaksi yang masih pending.
+func StartPaymentConsumer(ctx context.Context, broker messagebroker.Broker, topic string, transactionService service.TransactionService, deadLetters repository.DeadLetterRepository) {
+	r := broker.Consumer(topic, "transaction-service-group")
+
+	log.Printf("Transaction consumer started on topic '%s'\n", topic)
+
+	go func() {
+		for {
+			m, err := r.FetchMessage(ctx)
+			if err != nil {
+				// Jika context dibatalkan (aplikasi mati), hentikan loop
+				if ctx.Err() != nil {
+					break
+				}
+				log.Println("Could not read message from broker: ", err)
+				continue
+			}
+
+			log.Printf("Received message on %s: %s", topic, string(m.Value))
+
+			// Offset hanya di-commit setelah event selesai diproses, ditolak permanen, atau
+			// tersimpan di dead-letter, sehingga event tidak hilang jika aplikasi mati di tengah
+			// percobaan ulang
+			if err := handlePaymentResultWithRetry(ctx, m, transactionService, deadLetters, broker); err != nil {
+				break
+			}
+			if err := r.CommitMessages(ctx, m); err != nil {
+				log.Printf("Failed to commit offset %d on %s: %v", m.Offset, topic, err)
+			}
+		}
+		r.Close()
+		log.Printf("Transaction consumer on '%s' stopped.", topic)
+	}()
+}
+
+// handlePaymentResultWithRetry memproses event sampai berhasil, ditolak permanen, atau percobaan
+// habis. Error (database, book-service, wallet-service) dicoba ulang dengan backoff karena
+// finalisasi transaksi aman diulang; setelah maxPaymentAttempts percobaan event dipindahkan ke
+// dead-letter agar tidak menahan event berikutnya di partisi yang sama. Error hanya dikembalikan
+// jika ctx selesai sebelum event selesai ditangani.
+func handlePaymentResultWithRetry(ctx context.Context, m kafka.Message, transactionService service.TransactionService, deadLetters repository.DeadLetterRepository, producer messagebroker.Producer) error {
+	backoff := paymentRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := HandlePaymentResult(ctx, m.Value, transactionService)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrRejectedPaymentEvent) || errors.Is(err, service.ErrInvalidTransactionID) {
+			log.Printf("Skipping payment event: %v", err)
+			return nil
+		}
+		if attempt >= maxPaymentAttempts {
+			log.Printf("Giving up on payment event at offset %d after %d attempts: %v", m.Offset, attempt, err)
+			return deadLetterPaymentEvent(ctx, m, err, attempt, deadLetters, producer)
+		}
+
+		log.Printf("Failed to handle payment event (attempt %d/%d), retrying in %s: %v", attempt, maxPaymentAttempts, backoff, err)
+		if err := sleep(ctx, backoff); err != nil {
+			return err
+		}
+		if backoff *= 2; backoff > maxPaymentRetryBackoff {
+			backoff = maxPaymentRetryBackoff
+		}
+	}
+}
+
+// deadLetterPaymentEvent menyimpan event ke tabel dead_letters lalu mengirimnya ke topic
+// dead-letter. Penyimpanan ke database dicoba terus sampai berhasil agar offset tidak di-commit
+// sebelum event tersimpan di suatu tempat.
+func deadLetterPaymentEvent(ctx context.Context, m kafka.Message, cause error, attempts int, deadLetters repository.DeadLetterRepository, producer messagebroker.Producer) error {
+	deadLetter := &model.DeadLetter{
+		Topic:     m.Topic,
+		Partition: m.Partition,
+		Offset:    m.Offset,
+		Key:       string(m.Key),
+		Payload:   m.Value,
+		Error:     cause.Error(),
+		Attempts:  attempts,
+	}
+
+	backoff := paymentRetryBackoff
+	for {
+		err := deadLetters.CreateDeadLetter(ctx, deadLetter)
+		if err == nil {
+			break
+		}
+		log.Printf("CRITICAL: Failed to store dead letter for offset %d: %v", m.Offset, err)
+		if err := sleep(ctx, backoff); err != nil {
+			return err
+		}
+		if backoff *= 2; backoff > maxPaymentRetryBackoff {
+			backoff = maxPaymentRetryBackoff
+		}
+	}
+
+	message := DeadLetterMessage{
+		OriginalTopic: m.Topic,
+		Partition:     m.Partition,
+		Offset:        m.Offset,
+		Key:           string(m.Key),
+		Payload:       string(m.Value),
+		Error:         cause.Error(),
+		Attempts:      attempts,
+		FailedAt:      time.Now(),
+	}
+	headers := make(map[string]string, len(m.Headers))
+	for _, header := range m.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	if err := producer.Publish(ctx, messagebroker.Message{Topic: m.Topic + DeadLetterSuffix, Key: string(m.Key), Value: message, Headers: headers}); err != nil {
+		// Event sudah tersimpan di tabel dead_letters
+		log.Printf("Failed to publish dead letter for offset %d to %s: %v", m.Offset, m.Topic+DeadLetterSuffix, err)
+	}
+	return nil
+}
+
+// sleep menunggu selama d atau sampai ctx dibatalkan.
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// HandlePaymentResult membaca envelope hasil pembayaran lalu memfinalisasi transaksinya.
+// Event dengan jenis atau versi skema yang tidak dikenal ditolak tanpa mengubah transaksi.
+func HandlePaymentResult(ctx context.Context, value []byte, transactionService service.TransactionService) error {
+	envelope, err := events.Unmarshal(value)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRejectedPaymentEvent, err)
+	}
+
+	switch envelope.EventType {
+	case events.TypePaymentSucceeded:
+		payload := envelope.GetPaymentSucceeded()
+		return transactionService.CompleteTransaction(ctx, payload.TransactionId)
+	case events.TypePaymentFailed:
+		payload := envelope.GetPaymentFailed()
+		return transactionService.FailTransaction(ctx, payload.TransactionId, payload.FailureCode, payload.Reason)
+	default:
+		return fmt.Errorf("%w: unexpected event type %s", ErrRejectedPaymentEvent, envelope.EventType)
+	}
+}
@@ -0,0 +1,257 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"shared/events"
+	"shared/messagebroker"
+	"shared/money"
+	"testing"
+	"time"
+	"transaction-service/internal/model"
+	"transaction-service/internal/repository"
+	"transaction-service/internal/service"
+	"transaction-service/pkg/client"
+	pb "transaction-service/proto"
+	walletMocks "transaction-service/proto/mocks"
+	wallet_pb "wallet-service/proto"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func paymentFailedEvent(t *testing.T, mutate func(*events.Envelope)) []byte {
+	envelope, err := events.NewPaymentFailed("wallet-service", &events.PaymentFailed{
+		TransactionId: "99",
+		UserId:        "1",
+		Amount:        money.FromRupiah(50000).ToProto(),
+		Reason:        "insufficient funds",
+		FailureCode:   "insufficient_funds",
+	})
+	assert.NoError(t, err)
+	mutate(envelope)
+	data, err := protojson.Marshal(envelope)
+	assert.NoError(t, err)
+	return data
+}
+
+// paymentMessage membungkus event hasil pembayaran sebagai pesan di topic payment_failed milik user 1.
+func paymentMessage(value []byte) kafka.Message {
+	return kafka.Message{Topic: TopicPaymentFailed, Offset: 7, Key: []byte("1"), Value: value}
+}
+
+// Skenario 1: Event payment.failed membatalkan transaksi beserta kode kegagalannya
+func TestHandlePaymentResult_Failed(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	mockRepo.On("FailPendingTransaction", mock.Anything, uint(99), "insufficient_funds", "insufficient funds").Return(true, nil)
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, Status: "cancelled"}, nil)
+	transactionService := service.NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+	// --- Act ---
+	err := HandlePaymentResult(context.Background(), paymentFailedEvent(t, func(*events.Envelope) {}), transactionService)
+
+	// --- Assert ---
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// Skenario 2: Versi skema yang tidak dikenal ditolak tanpa mengubah transaksi
+func TestHandlePaymentResult_UnsupportedVersion(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	transactionService := service.NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+	value := paymentFailedEvent(t, func(e *events.Envelope) { e.SchemaVersion = 2 })
+
+	// --- Act ---
+	err := HandlePaymentResult(context.Background(), value, transactionService)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, events.ErrUnsupportedVersion)
+	mockRepo.AssertNotCalled(t, "FailPendingTransaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Skenario 3: Payload lama tanpa envelope ditolak
+func TestHandlePaymentResult_LegacyPayload(t *testing.T) {
+	// --- Arrange ---
+	mockRepo := new(repository.MockTransactionRepository)
+	transactionService := service.NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+	// --- Act ---
+	err := HandlePaymentResult(context.Background(), []byte(`{"transaction_id":"99","reason":"insufficient funds"}`), transactionService)
+
+	// --- Assert ---
+	assert.ErrorIs(t, err, events.ErrMalformedEvent)
+	mockRepo.AssertNotCalled(t, "FailPendingTransaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Skenario 4: Error dicoba ulang sampai berhasil, event yang ditolak tidak dicoba ulang, dan
+// event yang tetap gagal setelah percobaan habis dipindahkan ke dead-letter
+func TestHandlePaymentResultWithRetry(t *testing.T) {
+	paymentRetryBackoff = time.Millisecond
+	defer func() { paymentRetryBackoff = 500 * time.Millisecond }()
+
+	t.Run("transient error is retried", func(t *testing.T) {
+		mockRepo := new(repository.MockTransactionRepository)
+		mockRepo.On("FailPendingTransaction", mock.Anything, uint(99), "insufficient_funds", "insufficient funds").Return(false, errors.New("connection refused")).Once()
+		mockRepo.On("FailPendingTransaction", mock.Anything, uint(99), "insufficient_funds", "insufficient funds").Return(true, nil).Once()
+		mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).Return(&model.Transaction{ID: 99, Status: "cancelled"}, nil)
+		transactionService := service.NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+		err := handlePaymentResultWithRetry(context.Background(), paymentMessage(paymentFailedEvent(t, func(*events.Envelope) {})), transactionService, nil, nil)
+
+		assert.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "FailPendingTransaction", 2)
+	})
+
+	t.Run("rejected event is skipped", func(t *testing.T) {
+		mockRepo := new(repository.MockTransactionRepository)
+		transactionService := service.NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+		value := paymentFailedEvent(t, func(e *events.Envelope) { e.SchemaVersion = 2 })
+
+		err := handlePaymentResultWithRetry(context.Background(), paymentMessage(value), transactionService, nil, nil)
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "FailPendingTransaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		mockRepo := new(repository.MockTransactionRepository)
+		mockRepo.On("FailPendingTransaction", mock.Anything, uint(99), "insufficient_funds", "insufficient funds").Return(false, errors.New("connection refused"))
+		transactionService := service.NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := handlePaymentResultWithRetry(ctx, paymentMessage(paymentFailedEvent(t, func(*events.Envelope) {})), transactionService, nil, nil)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("poison message is dead-lettered", func(t *testing.T) {
+		maxPaymentAttempts = 3
+		defer func() { maxPaymentAttempts = 10 }()
+
+		mockRepo := new(repository.MockTransactionRepository)
+		mockRepo.On("FailPendingTransaction", mock.Anything, uint(99), "insufficient_funds", "insufficient funds").Return(false, errors.New("connection refused"))
+		deadLetters := new(repository.MockDeadLetterRepository)
+		deadLetters.On("CreateDeadLetter", mock.Anything, mock.MatchedBy(func(d *model.DeadLetter) bool {
+			return d.Topic == TopicPaymentFailed && d.Offset == 7 && d.Key == "1" && d.Attempts == 3 && d.Error == "connection refused"
+		})).Return(nil).Once()
+		producer := new(messagebroker.MockProducer)
+		producer.On("Publish", mock.Anything, mock.MatchedBy(func(m messagebroker.Message) bool {
+			return m.Topic == TopicPaymentFailed+DeadLetterSuffix && m.Key == "1"
+		})).Return(nil).Once()
+		transactionService := service.NewTransactionService(mockRepo, nil, nil, nil, nil, 0)
+
+		err := handlePaymentResultWithRetry(context.Background(), paymentMessage(paymentFailedEvent(t, func(*events.Envelope) {})), transactionService, deadLetters, producer)
+
+		assert.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "FailPendingTransaction", 3)
+		deadLetters.AssertExpectations(t)
+		producer.AssertExpectations(t)
+	})
+}
+
+// Skenario 5: Sisi transaction-service dari alur pembelian lewat MemoryBroker: CreateTransaction
+// menyimpan event outbox, relay mengirimnya ke broker, dan consumer menyelesaikan transaksi saat
+// payment_success datang. Balasan wallet di sini hanya stub yang mengikuti kontrak event;
+// PaymentHandler aslinya dites di TestStartConsumer_MemoryBroker wallet-service.
+func TestTransactionFlow_MemoryBroker(t *testing.T) {
+	// --- Arrange ---
+	broker := messagebroker.NewMemoryBroker()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	mockRepo := new(repository.MockTransactionRepository)
+	mockOutbox := new(repository.MockOutboxRepository)
+	mockBookClient := new(client.MockBookServiceClient)
+	mockWalletClient := new(walletMocks.MockWalletServiceClient)
+
+	mockBookClient.On("GetBookByID", mock.Anything, "101").Return(&client.BookDTO{ID: "101", Status: "available", Price: money.FromRupiah(50000)}, nil)
+	mockBookClient.On("ReserveStock", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(nil)
+	mockWalletClient.On("Authorize", mock.Anything, mock.Anything).
+		Return(&wallet_pb.HoldResponse{Hold: &wallet_pb.Hold{Id: "7", Status: "active"}}, nil)
+
+	// Repository menyimpan transaksi dan event outbox-nya
+	saved := &model.Transaction{ID: 99, UserID: 1, Status: model.StatusPending}
+	var outboxEvent *model.OutboxEvent
+	mockRepo.On("CreateTransaction", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		event, err := args.Get(2).(repository.OutboxEventFunc)(saved)
+		require.NoError(t, err)
+		event.ID = 1
+		outboxEvent = event
+	}).Return(saved, nil)
+
+	completed := make(chan struct{})
+	mockRepo.On("CompletePendingTransaction", mock.Anything, uint(99)).Return(true, nil).Once()
+	mockRepo.On("GetTransactionByID", mock.Anything, uint(99)).
+		Return(&model.Transaction{ID: 99, UserID: 1, Status: model.StatusCompleted, ReservationID: "res-99"}, nil)
+	mockBookClient.On("CommitReservation", mock.Anything, "res-99").Run(func(mock.Arguments) {
+		close(completed)
+	}).Return(nil).Once()
+
+	transactionService := service.NewTransactionService(mockRepo, mockBookClient, mockWalletClient, nil, nil, 0)
+	StartPaymentConsumer(ctx, broker, TopicPaymentSuccess, transactionService, new(repository.MockDeadLetterRepository))
+
+	// Stub wallet: konsumsi transaction_created lalu kirim payment_success
+	go func() {
+		consumer := broker.Consumer("transaction_created", "wallet-service-group")
+		m, err := consumer.FetchMessage(ctx)
+		if err != nil {
+			return
+		}
+		created, err := events.Unmarshal(m.Value)
+		if !assert.NoError(t, err) {
+			return
+		}
+		payload := created.GetTransactionCreated()
+		assert.Equal(t, "7", payload.HoldId)
+		succeeded, err := events.NewPaymentSucceeded("wallet-service", &events.PaymentSucceeded{
+			TransactionId: payload.TransactionId,
+			UserId:        payload.UserId,
+			Amount:        payload.TotalAmount,
+			NewBalance:    money.FromRupiah(0).ToProto(),
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		value, err := events.Marshal(succeeded)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NoError(t, broker.Publish(ctx, messagebroker.Message{
+			Topic:   TopicPaymentSuccess,
+			Key:     string(m.Key),
+			Value:   json.RawMessage(value),
+			Headers: events.Headers(succeeded, payload.TransactionId),
+		}))
+		assert.NoError(t, consumer.CommitMessages(ctx, m))
+	}()
+
+	// --- Act ---
+	result, err := transactionService.CreateTransaction(ctx, &pb.CreateTransactionRequest{
+		UserId: "1",
+		Items:  []*pb.BookOrderItem{{BookId: "101", Quantity: 1}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusPending, result.Status)
+
+	mockOutbox.On("GetPendingOutboxEvents", mock.Anything, mock.Anything).Return([]model.OutboxEvent{*outboxEvent}, nil)
+	mockOutbox.On("MarkOutboxEventSent", mock.Anything, uint(1)).Return(nil)
+	sent, err := RelayPendingEvents(ctx, mockOutbox, broker)
+
+	// --- Assert ---
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	select {
+	case <-completed:
+	case <-ctx.Done():
+		t.Fatal("transaction was not completed through the broker")
+	}
+	mockRepo.AssertExpectations(t)
+	mockBookClient.AssertExpectations(t)
+}
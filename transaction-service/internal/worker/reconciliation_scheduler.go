@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+	"transaction-service/internal/service"
+)
+
+// StartReconciliationScheduler memulai goroutine yang menjalankan rekonsiliasi transaksi
+// dengan wallet setiap hari pada jam hour (waktu lokal server). Setiap run melanjutkan
+// rentang dari run terjadwal terakhir yang berhasil, sehingga hari yang terlewat ikut dicek.
+func StartReconciliationScheduler(ctx context.Context, reconciliationService service.ReconciliationService, hour int) {
+	log.Printf("Reconciliation scheduler started (daily at %02d:00)\n", hour)
+
+	go func() {
+		for {
+			timer := time.NewTimer(time.Until(nextDailyRun(time.Now(), hour)))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				log.Println("Reconciliation scheduler stopped.")
+				return
+			case <-timer.C:
+				if _, err := reconciliationService.RunScheduled(ctx, time.Now()); err != nil {
+					log.Printf("Scheduled reconciliation failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// nextDailyRun menghitung waktu run berikutnya: jam hour hari ini, atau besok jika sudah lewat.
+func nextDailyRun(now time.Time, hour int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
@@ -0,0 +1,17 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Skenario 1: Run berikutnya hari ini jika jamnya belum lewat, besok jika sudah
+func TestNextDailyRun(t *testing.T) {
+	before := time.Date(2025, 6, 1, 1, 30, 0, 0, time.UTC)
+	after := time.Date(2025, 6, 1, 2, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 6, 1, 2, 0, 0, 0, time.UTC), nextDailyRun(before, 2))
+	assert.Equal(t, time.Date(2025, 6, 2, 2, 0, 0, 0, time.UTC), nextDailyRun(after, 2))
+}
@@ -1,29 +1,53 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"shared/money"
 	"time"
 )
 
 // BookDTO adalah representasi data buku dari REST API book-service.
 // Ini adalah DTO yang dilihat oleh transaction-service.
 type BookDTO struct {
-	ID     string  `json:"id"`
-	Title  string  `json:"title"`
-	Price  float64 `json:"price"`
-	Status string  `json:"status"`
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	Author      string      `json:"author"`
+	Publisher   string      `json:"publisher"`
+	Category    string      `json:"category"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price"`
+	Status      string      `json:"status"`
 }
 
 type BookServiceTemplateResponse struct {
 	Data BookDTO `json:"data"`
 }
 
+// StockItem adalah jumlah eksemplar satu buku yang ingin ditahan.
+type StockItem struct {
+	BookID   string `json:"book_id"`
+	Quantity int    `json:"quantity"`
+}
+
+// ErrOutOfStock dikembalikan jika book-service menolak reservasi karena stok tidak cukup.
+var ErrOutOfStock = errors.New("book is out of stock")
+
+// ErrReservationClosed dikembalikan jika reservasi tidak ditemukan atau sudah ditutup dengan
+// status lain, sehingga commit atau release tidak akan pernah berhasil walaupun diulang.
+var ErrReservationClosed = errors.New("stock reservation is not open")
+
 // BookServiceClient adalah interface untuk klien HTTP ke book-service.
 type BookServiceClient interface {
 	GetBookByID(ctx context.Context, bookID string) (*BookDTO, error)
+	ReserveStock(ctx context.Context, reservationID string, items []StockItem) error
+	CommitReservation(ctx context.Context, reservationID string) error
+	ReleaseReservation(ctx context.Context, reservationID string) error
 }
 
 type bookServiceClient struct {
@@ -69,3 +93,68 @@ func (c *bookServiceClient) GetBookByID(ctx context.Context, bookID string) (*Bo
 	// Kembalikan hanya bagian datanya
 	return &serviceResponse.Data, nil
 }
+
+// ReserveStock melakukan panggilan POST ke /reservations untuk menahan stok semua item.
+func (c *bookServiceClient) ReserveStock(ctx context.Context, reservationID string, items []StockItem) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"reservation_id": reservationID,
+		"items":          items,
+	})
+	if err != nil {
+		return err
+	}
+
+	statusCode, err := c.post(ctx, "/reservations", body)
+	if err != nil {
+		return err
+	}
+	switch statusCode {
+	case http.StatusCreated, http.StatusOK:
+		return nil
+	case http.StatusConflict:
+		return ErrOutOfStock
+	default:
+		return fmt.Errorf("book-service returned status %d on reserve", statusCode)
+	}
+}
+
+// CommitReservation melakukan panggilan POST ke /reservations/:id/commit setelah pembayaran berhasil.
+func (c *bookServiceClient) CommitReservation(ctx context.Context, reservationID string) error {
+	return c.closeReservation(ctx, reservationID, "commit")
+}
+
+// ReleaseReservation melakukan panggilan POST ke /reservations/:id/release agar stok bisa dibeli lagi.
+func (c *bookServiceClient) ReleaseReservation(ctx context.Context, reservationID string) error {
+	return c.closeReservation(ctx, reservationID, "release")
+}
+
+func (c *bookServiceClient) closeReservation(ctx context.Context, reservationID, action string) error {
+	statusCode, err := c.post(ctx, fmt.Sprintf("/reservations/%s/%s", url.PathEscape(reservationID), action), nil)
+	if err != nil {
+		return err
+	}
+	switch statusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound, http.StatusConflict:
+		return fmt.Errorf("%w: book-service returned status %d on %s", ErrReservationClosed, statusCode, action)
+	default:
+		return fmt.Errorf("book-service returned status %d on %s", statusCode, action)
+	}
+}
+
+// post mengirim request POST dengan body JSON dan mengembalikan status code-nya.
+func (c *bookServiceClient) post(ctx context.Context, path string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
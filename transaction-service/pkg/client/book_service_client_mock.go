@@ -15,4 +15,19 @@ func (m *MockBookServiceClient) GetBookByID(ctx context.Context, bookID string)
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*BookDTO), args.Error(1)
-}
\ No newline at end of file
+}
+
+func (m *MockBookServiceClient) ReserveStock(ctx context.Context, reservationID string, items []StockItem) error {
+	args := m.Called(ctx, reservationID, items)
+	return args.Error(0)
+}
+
+func (m *MockBookServiceClient) CommitReservation(ctx context.Context, reservationID string) error {
+	args := m.Called(ctx, reservationID)
+	return args.Error(0)
+}
+
+func (m *MockBookServiceClient) ReleaseReservation(ctx context.Context, reservationID string) error {
+	args := m.Called(ctx, reservationID)
+	return args.Error(0)
+}
@@ -0,0 +1,86 @@
+package quote
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"shared/money"
+	"strings"
+	"time"
+)
+
+// Error yang dikembalikan Verify.
+var (
+	ErrInvalid = errors.New("invalid quote")
+	ErrExpired = errors.New("quote has expired")
+)
+
+// Item adalah harga satu buku yang dikunci di dalam quote.
+type Item struct {
+	BookID       string      `json:"book_id"`
+	Quantity     int32       `json:"quantity"`
+	PricePerUnit money.Money `json:"price_per_unit"`
+}
+
+// Quote adalah harga keranjang yang sudah dihitung dan berlaku sampai ExpiresAt.
+type Quote struct {
+	UserID      string      `json:"user_id"`
+	Items       []Item      `json:"items"`
+	TotalAmount money.Money `json:"total_amount"`
+	ExpiresAt   time.Time   `json:"expires_at"`
+}
+
+// Signer membuat dan memverifikasi quote yang ditandatangani HMAC-SHA256.
+// Quote tidak perlu disimpan di database: isi dan tanda tangannya dikirim
+// ke klien sebagai satu token, dan perubahan sekecil apa pun membuat tanda tangan tidak cocok.
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewSigner adalah constructor untuk Signer. ttl adalah masa berlaku setiap quote.
+func NewSigner(secret []byte, ttl time.Duration) *Signer {
+	return &Signer{secret: secret, ttl: ttl, now: time.Now}
+}
+
+// Sign mengisi ExpiresAt lalu mengubah quote menjadi token "<payload>.<signature>".
+func (s *Signer) Sign(q *Quote) (string, error) {
+	q.ExpiresAt = s.now().Add(s.ttl).UTC().Truncate(time.Second)
+	payload, err := json.Marshal(q)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + s.signature(encoded), nil
+}
+
+// Verify memeriksa tanda tangan dan masa berlaku token lalu mengembalikan isinya.
+func (s *Signer) Verify(token string) (*Quote, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(s.signature(encoded))) {
+		return nil, ErrInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	var q Quote
+	if err := json.Unmarshal(payload, &q); err != nil {
+		return nil, ErrInvalid
+	}
+
+	if !s.now().Before(q.ExpiresAt) {
+		return nil, ErrExpired
+	}
+	return &q, nil
+}
+
+func (s *Signer) signature(encoded string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,64 @@
+package quote
+
+import (
+	"shared/money"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestQuote() *Quote {
+	return &Quote{
+		UserID:      "1",
+		Items:       []Item{{BookID: "101", Quantity: 2, PricePerUnit: money.FromRupiah(50000)}},
+		TotalAmount: money.FromRupiah(100000),
+	}
+}
+
+// Tes quote yang ditandatangani bisa diverifikasi kembali
+func TestSigner_RoundTrip(t *testing.T) {
+	signer := NewSigner([]byte("secret"), 15*time.Minute)
+
+	token, err := signer.Sign(newTestQuote())
+	assert.NoError(t, err)
+
+	q, err := signer.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", q.UserID)
+	assert.Equal(t, money.FromRupiah(100000), q.TotalAmount)
+	assert.Equal(t, money.FromRupiah(50000), q.Items[0].PricePerUnit)
+}
+
+// Tes token yang diubah atau ditandatangani dengan secret lain ditolak
+func TestSigner_Tampered(t *testing.T) {
+	signer := NewSigner([]byte("secret"), 15*time.Minute)
+	token, _ := signer.Sign(newTestQuote())
+
+	// Ganti payload dengan quote lain yang harganya lebih murah, tanda tangan lama dipertahankan
+	cheap := newTestQuote()
+	cheap.TotalAmount = money.FromRupiah(1)
+	cheapToken, _ := signer.Sign(cheap)
+	_, signature, _ := strings.Cut(token, ".")
+	payload, _, _ := strings.Cut(cheapToken, ".")
+
+	_, err := signer.Verify(payload + "." + signature)
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	_, err = NewSigner([]byte("other"), 15*time.Minute).Verify(token)
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	_, err = signer.Verify("not-a-token")
+	assert.ErrorIs(t, err, ErrInvalid)
+}
+
+// Tes quote yang sudah lewat masa berlakunya ditolak
+func TestSigner_Expired(t *testing.T) {
+	signer := NewSigner([]byte("secret"), 15*time.Minute)
+	token, _ := signer.Sign(newTestQuote())
+
+	signer.now = func() time.Time { return time.Now().Add(16 * time.Minute) }
+	_, err := signer.Verify(token)
+	assert.ErrorIs(t, err, ErrExpired)
+}
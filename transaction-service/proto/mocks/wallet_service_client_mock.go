@@ -47,4 +47,130 @@ func (m *MockWalletServiceClient) Credit(ctx context.Context, in *pb.CreditReque
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*pb.CreditResponse), args.Error(1)
-}
\ No newline at end of file
+}
+
+// GetLedger adalah implementasi mock untuk mengambil riwayat ledger.
+func (m *MockWalletServiceClient) GetLedger(ctx context.Context, in *pb.GetLedgerRequest, opts ...grpc.CallOption) (*pb.GetLedgerResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.GetLedgerResponse), args.Error(1)
+}
+
+// ListDeadLetters adalah implementasi mock untuk mengambil daftar dead-letter.
+func (m *MockWalletServiceClient) ListDeadLetters(ctx context.Context, in *pb.ListDeadLettersRequest, opts ...grpc.CallOption) (*pb.ListDeadLettersResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ListDeadLettersResponse), args.Error(1)
+}
+
+// ReplayDeadLetter adalah implementasi mock untuk memutar ulang dead-letter.
+func (m *MockWalletServiceClient) ReplayDeadLetter(ctx context.Context, in *pb.ReplayDeadLetterRequest, opts ...grpc.CallOption) (*pb.ReplayDeadLetterResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ReplayDeadLetterResponse), args.Error(1)
+}
+
+// ListTransactionMovements adalah implementasi mock untuk mengambil debit dan refund transaksi.
+func (m *MockWalletServiceClient) ListTransactionMovements(ctx context.Context, in *pb.ListTransactionMovementsRequest, opts ...grpc.CallOption) (*pb.ListTransactionMovementsResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ListTransactionMovementsResponse), args.Error(1)
+}
+
+// Transfer adalah implementasi mock untuk transfer saldo antar pengguna.
+func (m *MockWalletServiceClient) Transfer(ctx context.Context, in *pb.TransferRequest, opts ...grpc.CallOption) (*pb.TransferResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.TransferResponse), args.Error(1)
+}
+
+// ListTransfers adalah implementasi mock untuk mengambil riwayat transfer.
+func (m *MockWalletServiceClient) ListTransfers(ctx context.Context, in *pb.ListTransfersRequest, opts ...grpc.CallOption) (*pb.ListTransfersResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ListTransfersResponse), args.Error(1)
+}
+
+// HandlePaymentWebhook adalah implementasi mock untuk meneruskan webhook payment provider.
+func (m *MockWalletServiceClient) HandlePaymentWebhook(ctx context.Context, in *pb.PaymentWebhookRequest, opts ...grpc.CallOption) (*pb.PaymentWebhookResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.PaymentWebhookResponse), args.Error(1)
+}
+
+// ListTopUps adalah implementasi mock untuk mengambil riwayat top-up.
+func (m *MockWalletServiceClient) ListTopUps(ctx context.Context, in *pb.ListTopUpsRequest, opts ...grpc.CallOption) (*pb.ListTopUpsResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.ListTopUpsResponse), args.Error(1)
+}
+
+// GetTopUp adalah implementasi mock untuk mengambil detail satu top-up.
+func (m *MockWalletServiceClient) GetTopUp(ctx context.Context, in *pb.GetTopUpRequest, opts ...grpc.CallOption) (*pb.GetTopUpResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.GetTopUpResponse), args.Error(1)
+}
+
+// SetSpendingLimits adalah implementasi mock untuk mengatur batas pengeluaran.
+func (m *MockWalletServiceClient) SetSpendingLimits(ctx context.Context, in *pb.SetSpendingLimitsRequest, opts ...grpc.CallOption) (*pb.SpendingLimitsResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.SpendingLimitsResponse), args.Error(1)
+}
+
+// GetSpendingLimits adalah implementasi mock untuk mengambil batas pengeluaran.
+func (m *MockWalletServiceClient) GetSpendingLimits(ctx context.Context, in *pb.GetSpendingLimitsRequest, opts ...grpc.CallOption) (*pb.SpendingLimitsResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.SpendingLimitsResponse), args.Error(1)
+}
+
+// Authorize adalah implementasi mock untuk mencadangkan dana (hold).
+func (m *MockWalletServiceClient) Authorize(ctx context.Context, in *pb.AuthorizeRequest, opts ...grpc.CallOption) (*pb.HoldResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.HoldResponse), args.Error(1)
+}
+
+// Capture adalah implementasi mock untuk mengubah hold menjadi debit.
+func (m *MockWalletServiceClient) Capture(ctx context.Context, in *pb.CaptureRequest, opts ...grpc.CallOption) (*pb.CaptureResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.CaptureResponse), args.Error(1)
+}
+
+// Void adalah implementasi mock untuk membatalkan hold.
+func (m *MockWalletServiceClient) Void(ctx context.Context, in *pb.VoidRequest, opts ...grpc.CallOption) (*pb.HoldResponse, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pb.HoldResponse), args.Error(1)
+}
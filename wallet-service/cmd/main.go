@@ -12,11 +12,13 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"shared/messagebroker"
 	"wallet-service/internal/model"
 	"wallet-service/internal/repository"
 	"wallet-service/internal/server"
 	"wallet-service/internal/service"
 	"wallet-service/internal/worker"
+	"wallet-service/pkg/payment"
 	pb "wallet-service/proto"
 )
 
@@ -28,14 +30,10 @@ func main() {
 	// Ambil konfigurasi dari environment
 	dbURL := os.Getenv("DATABASE_URL")
 	grpcPort := os.Getenv("GRPC_PORT")
-	kafkaURL := os.Getenv("KAFKA_URL")
 
 	if dbURL == "" {
 		log.Fatal("DATABASE_URL environment variable is not set")
 	}
-	if kafkaURL == "" {
-		log.Fatal("KAFKA_URL is not set")
-	}
 	if grpcPort == "" {
 		grpcPort = "50053"
 	}
@@ -48,19 +46,24 @@ func main() {
 
 	// AutoMigrate untuk membuat tabel
 	log.Println("Running migrations...")
-	db.AutoMigrate(&model.User{}, &model.TopUp{})
+	db.AutoMigrate(&model.User{}, &model.TopUp{}, &model.ProcessedReference{}, &model.LedgerEntry{}, &model.DeadLetter{}, &model.Transfer{}, &model.TopUpStatusChange{}, &model.SpendingLimit{}, &model.Hold{})
 
 	// Inisialisasi dependensi
 	repo := repository.NewGormRepository(db)
-	svc := service.NewWalletService(repo)
-	grpcServer := server.NewGrpcServer(svc)
+	deadLetterRepo := repository.NewGormDeadLetterRepository(db)
+	broker := newMessageBroker()
+	paymentProvider := newPaymentProvider()
+	svc := service.NewWalletService(repo, paymentProvider)
+	deadLetterSvc := service.NewDeadLetterService(deadLetterRepo, broker)
+	grpcServer := server.NewGrpcServer(svc, deadLetterSvc)
 
-	// === Jalankan Kafka Consumer ===
+	// === Jalankan Consumer ===
 	// Gunakan context untuk bisa mematikan consumer saat aplikasi berhenti
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	worker.StartConsumer(ctx, kafkaURL, "transaction_created", svc)
+	paymentHandler := worker.NewPaymentHandler(svc, broker, deadLetterRepo)
+	worker.StartConsumer(ctx, broker, "transaction_created", paymentHandler)
 
 	// Setup dan jalankan server gRPC
 	lis, err := net.Listen("tcp", ":"+grpcPort)
@@ -76,3 +79,44 @@ func main() {
 		log.Fatalf("Failed to serve gRPC: %v", err)
 	}
 }
+
+// newMessageBroker memilih broker dari MESSAGE_BROKER: "kafka" (default, butuh KAFKA_URL) atau
+// "memory" untuk menjalankan service tanpa Kafka dan Zookeeper. Broker memory hanya hidup di
+// dalam proses ini, sehingga event tidak sampai ke transaction-service yang berjalan di proses lain.
+func newMessageBroker() messagebroker.Broker {
+	switch name := os.Getenv("MESSAGE_BROKER"); name {
+	case "", "kafka":
+		kafkaURL := os.Getenv("KAFKA_URL")
+		if kafkaURL == "" {
+			log.Fatal("KAFKA_URL is not set")
+		}
+		return messagebroker.NewKafkaBroker(kafkaURL)
+	case "memory":
+		log.Println("Warning: using in-memory message broker, events are not shared with other processes")
+		return messagebroker.NewMemoryBroker()
+	default:
+		log.Fatalf("Unknown MESSAGE_BROKER %q", name)
+		return nil
+	}
+}
+
+// newPaymentProvider memilih payment provider dari PAYMENT_PROVIDER. Saat ini hanya "fake"
+// (provider lokal tanpa jaringan) yang tersedia, dan itu juga nilai default-nya.
+func newPaymentProvider() payment.Provider {
+	secret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if secret == "" {
+		log.Fatal("PAYMENT_WEBHOOK_SECRET is not set")
+	}
+
+	switch name := os.Getenv("PAYMENT_PROVIDER"); name {
+	case "", "fake":
+		baseURL := os.Getenv("PAYMENT_CHECKOUT_BASE_URL")
+		if baseURL == "" {
+			baseURL = "http://localhost:8000/fake-checkout"
+		}
+		return payment.NewFakeProvider([]byte(secret), baseURL)
+	default:
+		log.Fatalf("Unknown PAYMENT_PROVIDER %q", name)
+		return nil
+	}
+}
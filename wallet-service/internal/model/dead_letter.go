@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// Status pesan dead-letter.
+const (
+	DeadLetterStatusPending  = "pending"
+	DeadLetterStatusReplayed = "replayed"
+)
+
+// DeadLetter merepresentasikan tabel 'dead_letters'.
+// Setiap baris menyimpan pesan Kafka yang gagal diproses secara permanen,
+// lengkap dengan payload asli dan error terakhir, agar bisa diperiksa dan diputar ulang.
+type DeadLetter struct {
+	ID         uint   `gorm:"primaryKey"`
+	Topic      string `gorm:"type:varchar(100);not null"` // Topic asal pesan
+	Partition  int    `gorm:"not null"`
+	Offset     int64  `gorm:"not null"`
+	Key        string `gorm:"type:varchar(255)"`
+	Payload    []byte `gorm:"type:bytea;not null"`
+	Error      string `gorm:"type:text;not null"`
+	Attempts   int    `gorm:"not null"`
+	Status     string `gorm:"type:varchar(20);not null;index"`
+	ReplayedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -2,26 +2,142 @@ package model
 
 import (
 	"time"
+
 	"gorm.io/gorm"
+	"shared/money"
 )
 
 // User merepresentasikan tabel 'users' di database.
 type User struct {
-	ID    uint           `gorm:"primaryKey"` // GORM umumnya menggunakan uint untuk ID
-	Name  string         `gorm:"type:varchar(100);not null"`
-	Email string         `gorm:"type:varchar(100);unique;not null"`
-	Saldo float64        `gorm:"type:decimal(12,2);default:0.00"`
+	ID    uint        `gorm:"primaryKey"` // GORM umumnya menggunakan uint untuk ID
+	Name  string      `gorm:"type:varchar(100);not null"`
+	Email string      `gorm:"type:varchar(100);unique;not null"`
+	Saldo money.Money `gorm:"type:decimal(12,2);default:0.00"`
 	// Kolom lain dari tabel users bisa ditambahkan di sini jika perlu
 }
 
+// Status top-up. Top-up dibuat pending dan baru menambah saldo setelah
+// payment provider mengonfirmasi pembayaran lewat webhook.
+const (
+	TopUpStatusPending = "pending"
+	TopUpStatusSuccess = "success"
+	TopUpStatusFailed  = "failed"
+)
+
 // TopUp merepresentasikan tabel 'top_up' di database.
 type TopUp struct {
-	ID        uint           `gorm:"primaryKey"`
-	UserID    uint           `gorm:"not null"` // Sesuaikan dengan tipe ID di User
-	Amount    float64        `gorm:"type:decimal(12,2);not null"`
-	Method    string         `gorm:"type:varchar(50)"`
-	Status    string         `gorm:"type:varchar(50);default:'success'"`
-	CreatedAt time.Time      // GORM otomatis mengelola `created_at`
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
-}
\ No newline at end of file
+	ID               uint        `gorm:"primaryKey"`
+	UserID           uint        `gorm:"not null"` // Sesuaikan dengan tipe ID di User
+	Amount           money.Money `gorm:"type:decimal(12,2);not null"`
+	Method           string      `gorm:"type:varchar(50)"`
+	Status           string      `gorm:"type:varchar(50);default:'success'"`
+	Provider         string      `gorm:"type:varchar(30)"`
+	PaymentReference string      `gorm:"type:varchar(100);index"` // Referensi checkout dari payment provider
+	CheckoutURL      string      `gorm:"type:varchar(255)"`
+	CreatedAt        time.Time   // GORM otomatis mengelola `created_at`
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
+
+	StatusChanges []TopUpStatusChange `gorm:"foreignKey:TopUpID"`
+}
+
+// TopUpStatusChange merepresentasikan tabel 'top_up_status_changes'.
+// Setiap perubahan status top-up (pending, lalu success atau failed) dicatat beserta waktunya.
+type TopUpStatusChange struct {
+	ID        uint   `gorm:"primaryKey"`
+	TopUpID   uint   `gorm:"not null;index"`
+	Status    string `gorm:"type:varchar(50);not null"`
+	CreatedAt time.Time
+}
+
+// Jenis entri ledger. Setiap perubahan saldo dicatat dengan salah satu jenis ini.
+const (
+	EntryTypeDebit       = "debit"
+	EntryTypeCredit      = "credit"
+	EntryTypeTopUp       = "top_up"
+	EntryTypeRefund      = "refund"
+	EntryTypeAdjustment  = "adjustment"
+	EntryTypeTransferOut = "transfer_out"
+	EntryTypeTransferIn  = "transfer_in"
+)
+
+// Jenis sumber yang dirujuk oleh entri ledger.
+const (
+	ReferenceTypeTransaction = "transaction"
+	ReferenceTypeTopUp       = "top_up"
+	ReferenceTypeTransfer    = "transfer"
+)
+
+// Transfer merepresentasikan tabel 'transfers'.
+// Setiap transfer dicatat sebagai pasangan entri ledger transfer_out (pengirim)
+// dan transfer_in (penerima) dengan reference_id = ID transfer.
+type Transfer struct {
+	ID         uint        `gorm:"primaryKey"`
+	FromUserID uint        `gorm:"not null;index"`
+	ToUserID   uint        `gorm:"not null;index"`
+	Amount     money.Money `gorm:"type:decimal(12,2);not null"`
+	Note       string      `gorm:"type:varchar(255)"`
+	CreatedAt  time.Time
+}
+
+// SpendingLimit merepresentasikan tabel 'spending_limits'.
+// Nilai 0 berarti tidak dibatasi. Pemakaian dihitung dari entri ledger debit dan transfer_out.
+type SpendingLimit struct {
+	UserID              uint        `gorm:"primaryKey;autoIncrement:false"`
+	DailyLimit          money.Money `gorm:"type:decimal(12,2);not null;default:0"`
+	MonthlyLimit        money.Money `gorm:"type:decimal(12,2);not null;default:0"`
+	PerTransactionLimit money.Money `gorm:"type:decimal(12,2);not null;default:0"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// Status hold. Hold aktif mengurangi saldo yang tersedia tanpa mengubah users.saldo,
+// sampai di-capture (menjadi debit), di-void, atau kedaluwarsa.
+const (
+	HoldStatusActive   = "active"
+	HoldStatusCaptured = "captured"
+	HoldStatusVoided   = "voided"
+	HoldStatusExpired  = "expired"
+)
+
+// Hold merepresentasikan tabel 'holds': dana yang dicadangkan untuk sebuah pembelian.
+// Hold aktif yang sudah lewat ExpiresAt tidak lagi dihitung walaupun statusnya belum diubah.
+type Hold struct {
+	ID               uint        `gorm:"primaryKey"`
+	UserID           uint        `gorm:"not null;index:idx_hold_user_status,priority:1"`
+	Amount           money.Money `gorm:"type:decimal(12,2);not null"`
+	Status           string      `gorm:"type:varchar(20);not null;index:idx_hold_user_status,priority:2"`
+	ReferenceID      string      `gorm:"type:varchar(100);not null;uniqueIndex"` // Referensi idempotensi dari pemanggil Authorize
+	CaptureReference string      `gorm:"type:varchar(100)"`                      // Referensi debit saat di-capture, mis. transaction_id
+	ExpiresAt        time.Time   `gorm:"not null"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// LedgerEntry merepresentasikan tabel 'ledger_entries'.
+// Entri bersifat immutable (hanya INSERT): saldo di users.saldo harus selalu sama
+// dengan BalanceAfter dari entri terakhir milik user tersebut.
+type LedgerEntry struct {
+	ID            uint        `gorm:"primaryKey"`
+	UserID        uint        `gorm:"not null;index:idx_ledger_user_created,priority:1"`
+	EntryType     string      `gorm:"type:varchar(20);not null"`
+	Amount        money.Money `gorm:"type:decimal(12,2);not null"` // Positif menambah saldo, negatif mengurangi
+	BalanceAfter  money.Money `gorm:"type:decimal(12,2);not null"`
+	ReferenceType string      `gorm:"type:varchar(50);index:idx_ledger_reference,priority:1"`
+	ReferenceID   string      `gorm:"type:varchar(100);index:idx_ledger_reference,priority:2"`
+	Description   string      `gorm:"type:varchar(255)"`
+	CreatedAt     time.Time   `gorm:"index:idx_ledger_user_created,priority:2"`
+}
+
+// ProcessedReference merepresentasikan tabel 'processed_references'.
+// Setiap baris menandakan bahwa referensi idempotensi (mis. transaction_id)
+// sudah pernah mengubah saldo, beserta hasil saldo akhirnya.
+type ProcessedReference struct {
+	ID            uint        `gorm:"primaryKey"`
+	Reference     string      `gorm:"type:varchar(100);not null;uniqueIndex:idx_reference_kind"`
+	Kind          string      `gorm:"type:varchar(20);not null;uniqueIndex:idx_reference_kind"` // Jenis entri ledger, mis. debit
+	UserID        uint        `gorm:"not null"`
+	Amount        money.Money `gorm:"type:decimal(12,2);not null"`
+	ResultBalance money.Money `gorm:"type:decimal(12,2);not null"`
+	CreatedAt     time.Time
+}
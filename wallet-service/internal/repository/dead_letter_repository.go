@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"wallet-service/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// DeadLetterRepository adalah interface untuk menyimpan dan membaca pesan dead-letter.
+type DeadLetterRepository interface {
+	CreateDeadLetter(deadLetter *model.DeadLetter) error
+	ListDeadLetters(status string, offset, limit int) ([]model.DeadLetter, int64, error)
+	GetDeadLetter(id uint) (*model.DeadLetter, error)
+	MarkDeadLetterReplayed(id uint) (bool, error)
+}
+
+type gormDeadLetterRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDeadLetterRepository adalah constructor untuk GORM dead-letter repository.
+func NewGormDeadLetterRepository(db *gorm.DB) DeadLetterRepository {
+	return &gormDeadLetterRepository{db: db}
+}
+
+func (r *gormDeadLetterRepository) CreateDeadLetter(deadLetter *model.DeadLetter) error {
+	return r.db.Create(deadLetter).Error
+}
+
+// ListDeadLetters mengambil pesan dead-letter (terbaru lebih dulu). Status kosong berarti semua status.
+func (r *gormDeadLetterRepository) ListDeadLetters(status string, offset, limit int) ([]model.DeadLetter, int64, error) {
+	query := r.db.Model(&model.DeadLetter{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deadLetters []model.DeadLetter
+	err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&deadLetters).Error
+	return deadLetters, total, err
+}
+
+// GetDeadLetter mengambil satu pesan dead-letter. Mengembalikan (nil, nil) jika tidak ditemukan.
+func (r *gormDeadLetterRepository) GetDeadLetter(id uint) (*model.DeadLetter, error) {
+	var deadLetter model.DeadLetter
+	err := r.db.First(&deadLetter, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &deadLetter, nil
+}
+
+// MarkDeadLetterReplayed menandai pesan sudah diputar ulang, hanya jika statusnya masih pending.
+func (r *gormDeadLetterRepository) MarkDeadLetterReplayed(id uint) (bool, error) {
+	result := r.db.Model(&model.DeadLetter{}).
+		Where("id = ? AND status = ?", id, model.DeadLetterStatusPending).
+		Updates(map[string]interface{}{"status": model.DeadLetterStatusReplayed, "replayed_at": time.Now()})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"wallet-service/internal/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDeadLetterRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeadLetterRepository) CreateDeadLetter(deadLetter *model.DeadLetter) error {
+	args := m.Called(deadLetter)
+	return args.Error(0)
+}
+
+func (m *MockDeadLetterRepository) ListDeadLetters(status string, offset, limit int) ([]model.DeadLetter, int64, error) {
+	args := m.Called(status, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]model.DeadLetter), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockDeadLetterRepository) GetDeadLetter(id uint) (*model.DeadLetter, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DeadLetter), args.Error(1)
+}
+
+func (m *MockDeadLetterRepository) MarkDeadLetterReplayed(id uint) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
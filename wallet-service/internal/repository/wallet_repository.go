@@ -2,17 +2,131 @@ package repository
 
 import (
 	"errors"
+	"shared/money"
+	"strconv"
+	"time"
 	"wallet-service/internal/model"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// ErrInsufficientFunds dikembalikan jika saldo tidak cukup untuk perubahan yang diminta.
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrLedgerMismatch dikembalikan jika users.saldo tidak sama dengan saldo menurut ledger.
+var ErrLedgerMismatch = errors.New("wallet balance does not match ledger")
+
+// ErrTopUpNotFound dikembalikan jika tidak ada top-up dengan referensi pembayaran yang diminta.
+var ErrTopUpNotFound = errors.New("top-up not found")
+
+// ErrTopUpAlreadySettled dikembalikan jika webhook mencoba mengubah hasil top-up yang sudah final.
+var ErrTopUpAlreadySettled = errors.New("top-up already settled with a different result")
+
+// ErrUserNotFound dikembalikan jika salah satu user yang terlibat dalam transfer tidak ada.
+var ErrUserNotFound = errors.New("user not found")
+
+// Error operasi hold (Authorize, Capture, Void).
+var (
+	ErrHoldNotFound = errors.New("hold not found")
+	ErrHoldExpired  = errors.New("hold has expired")
+	ErrHoldVoided   = errors.New("hold has been voided")
+	ErrHoldCaptured = errors.New("hold has already been captured")
+)
+
+// ErrSpendingLimitExceeded dikembalikan (sebagai *SpendingLimitError) jika debit melewati batas pengeluaran user.
+var ErrSpendingLimitExceeded = errors.New("spending limit exceeded")
+
+// Kode batas pengeluaran yang dilanggar. Kode ini diteruskan ke transaction-service
+// sebagai failure_code agar bisa ditampilkan ke user.
+const (
+	LimitCodePerTransaction = "per_transaction_limit_exceeded"
+	LimitCodeDaily          = "daily_limit_exceeded"
+	LimitCodeMonthly        = "monthly_limit_exceeded"
+)
+
+// SpendingLimitError menjelaskan batas pengeluaran yang dilanggar oleh sebuah debit.
+type SpendingLimitError struct {
+	Code  string      // Salah satu LimitCode*
+	Limit money.Money // Batas yang berlaku
+	Spent money.Money // Pengeluaran pada periode sebelum debit ini (0 untuk batas per transaksi)
+}
+
+func (e *SpendingLimitError) Error() string {
+	switch e.Code {
+	case LimitCodeDaily:
+		return "daily spending limit of " + e.Limit.String() + " exceeded (already spent " + e.Spent.String() + " today)"
+	case LimitCodeMonthly:
+		return "monthly spending limit of " + e.Limit.String() + " exceeded (already spent " + e.Spent.String() + " this month)"
+	default:
+		return "amount exceeds the per-transaction limit of " + e.Limit.String()
+	}
+}
+
+// Is membuat errors.Is(err, ErrSpendingLimitExceeded) bernilai true.
+func (e *SpendingLimitError) Is(target error) bool {
+	return target == ErrSpendingLimitExceeded
+}
+
+// BalanceChange menjelaskan satu perubahan saldo beserta jejaknya di ledger.
+// Jika ReferenceID diisi, perubahan bersifat idempoten terhadap (EntryType, ReferenceID).
+type BalanceChange struct {
+	UserID        uint
+	Amount        money.Money // Positif menambah saldo, negatif mengurangi
+	EntryType     string
+	ReferenceType string
+	ReferenceID   string
+	Description   string
+	// HoldID diisi jika perubahan ini adalah capture dari sebuah hold. Saldo tersedia dan
+	// batas pengeluaran sudah dicek saat hold dibuat, sehingga tidak dicek ulang.
+	HoldID uint
+}
+
+// LedgerFilter berisi filter dan pagination untuk membaca ledger.
+type LedgerFilter struct {
+	UserID uint
+	From   *time.Time // Inklusif
+	To     *time.Time // Eksklusif
+	Offset int
+	Limit  int
+}
+
+// TopUpFilter berisi filter dan pagination untuk membaca riwayat top-up.
+type TopUpFilter struct {
+	UserID uint
+	Status string // Kosong untuk semua status
+	Method string // Kosong untuk semua metode
+	Offset int
+	Limit  int
+}
+
+// TransferFilter berisi filter dan pagination untuk membaca riwayat transfer.
+type TransferFilter struct {
+	UserID uint // Transfer di mana user adalah pengirim atau penerima
+	Offset int
+	Limit  int
+}
+
 // WalletRepository adalah interface untuk operasi database.
 type WalletRepository interface {
-	GetBalance(userID uint) (float64, error)
-	UpdateBalance(userID uint, amount float64) (float64, error)
+	GetBalance(userID uint) (money.Money, error)
+	UpdateBalance(change BalanceChange) (money.Money, error)
 	CreateTopUp(topUp *model.TopUp) (*model.TopUp, error)
+	UpdateTopUpPayment(topUp *model.TopUp) error
+	SettleTopUp(paymentReference string, paid bool) (*model.TopUp, error)
+	ListTopUps(filter TopUpFilter) ([]model.TopUp, int64, error)
+	GetTopUp(id uint) (*model.TopUp, error)
+	GetLedgerEntries(filter LedgerFilter) ([]model.LedgerEntry, int64, error)
+	GetEntriesByReferences(referenceType string, referenceIDs []string) ([]model.LedgerEntry, error)
+	CreateTransfer(transfer *model.Transfer) (money.Money, error)
+	ListTransfers(filter TransferFilter) ([]model.Transfer, int64, error)
+	GetSpendingLimit(userID uint) (*model.SpendingLimit, error)
+	SaveSpendingLimit(limit *model.SpendingLimit, check func(current *model.SpendingLimit) error) error
+	GetSpending(userID uint, since time.Time) (money.Money, error)
+	GetAvailableBalance(userID uint) (money.Money, error)
+	CreateHold(hold *model.Hold) (*model.Hold, error)
+	CaptureHold(holdID uint, referenceID string) (*model.Hold, money.Money, error)
+	VoidHold(holdID uint) (*model.Hold, error)
 }
 
 type gormRepository struct {
@@ -24,41 +138,646 @@ func NewGormRepository(db *gorm.DB) WalletRepository {
 	return &gormRepository{db: db}
 }
 
-func (r *gormRepository) GetBalance(userID uint) (float64, error) {
+func (r *gormRepository) GetBalance(userID uint) (money.Money, error) {
 	var user model.User
 	// Ambil hanya kolom saldo untuk efisiensi
 	err := r.db.Select("saldo").First(&user, userID).Error
 	return user.Saldo, err
 }
 
-func (r *gormRepository) UpdateBalance(userID uint, amount float64) (float64, error) {
-	var finalBalance float64
+func (r *gormRepository) UpdateBalance(change BalanceChange) (money.Money, error) {
+	var finalBalance money.Money
 
 	// GORM's Transaction method menangani commit/rollback secara otomatis.
 	err := r.db.Transaction(func(tx *gorm.DB) error {
+		balance, err := applyBalanceChange(tx, change)
+		finalBalance = balance
+		return err // Return nil akan men-commit transaksi
+	})
+
+	return finalBalance, err
+}
+
+// CreateTopUp menyimpan data top-up baru beserta status awalnya di riwayat status.
+// Saldo belum berubah sampai top-up diselesaikan lewat SettleTopUp.
+func (r *gormRepository) CreateTopUp(topUp *model.TopUp) (*model.TopUp, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("StatusChanges").Create(topUp).Error; err != nil {
+			return err
+		}
+		return recordTopUpStatus(tx, topUp.ID, topUp.Status)
+	})
+	return topUp, err
+}
+
+// UpdateTopUpPayment menyimpan status dan data checkout dari payment provider.
+// Status selain pending (mis. failed karena checkout gagal) juga dicatat di riwayat status.
+func (r *gormRepository) UpdateTopUpPayment(topUp *model.TopUp) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(topUp).Updates(map[string]interface{}{
+			"status":            topUp.Status,
+			"provider":          topUp.Provider,
+			"payment_reference": topUp.PaymentReference,
+			"checkout_url":      topUp.CheckoutURL,
+		}).Error
+		if err != nil || topUp.Status == model.TopUpStatusPending {
+			return err
+		}
+		return recordTopUpStatus(tx, topUp.ID, topUp.Status)
+	})
+}
+
+// SettleTopUp menyelesaikan top-up pending berdasarkan hasil pembayaran. Jika paid, status menjadi
+// success dan saldo bertambah dalam transaction database yang sama; jika tidak, status menjadi failed.
+// Webhook yang dikirim ulang dengan hasil yang sama mengembalikan top-up tanpa mengubah apa pun.
+func (r *gormRepository) SettleTopUp(paymentReference string, paid bool) (*model.TopUp, error) {
+	var topUp model.TopUp
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Kunci baris top-up agar dua webhook yang sama tidak memproses bersamaan
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("payment_reference = ?", paymentReference).First(&topUp).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTopUpNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		status := model.TopUpStatusFailed
+		if paid {
+			status = model.TopUpStatusSuccess
+		}
+		if topUp.Status != model.TopUpStatusPending {
+			if topUp.Status == status {
+				return nil
+			}
+			return ErrTopUpAlreadySettled
+		}
+
+		if err := tx.Model(&topUp).Update("status", status).Error; err != nil {
+			return err
+		}
+		topUp.Status = status
+		if err := recordTopUpStatus(tx, topUp.ID, status); err != nil {
+			return err
+		}
+		if !paid {
+			return nil
+		}
+
+		_, err = applyBalanceChange(tx, BalanceChange{
+			UserID:        topUp.UserID,
+			Amount:        topUp.Amount,
+			EntryType:     model.EntryTypeTopUp,
+			ReferenceType: model.ReferenceTypeTopUp,
+			ReferenceID:   fmtID(topUp.ID),
+			Description:   "Top up via " + topUp.Method,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &topUp, nil
+}
+
+// ListTopUps mengambil top-up milik user (terbaru lebih dulu) beserta riwayat statusnya dan total yang cocok dengan filter.
+func (r *gormRepository) ListTopUps(filter TopUpFilter) ([]model.TopUp, int64, error) {
+	query := r.db.Model(&model.TopUp{}).Where("user_id = ?", filter.UserID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Method != "" {
+		query = query.Where("method = ?", filter.Method)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var topUps []model.TopUp
+	err := query.Preload("StatusChanges", orderStatusChanges).
+		Order("created_at DESC, id DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&topUps).Error
+	return topUps, total, err
+}
+
+// GetTopUp mengambil satu top-up beserta riwayat statusnya. Mengembalikan (nil, nil) jika tidak ditemukan.
+func (r *gormRepository) GetTopUp(id uint) (*model.TopUp, error) {
+	var topUp model.TopUp
+	err := r.db.Preload("StatusChanges", orderStatusChanges).First(&topUp, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &topUp, nil
+}
+
+// GetLedgerEntries mengambil entri ledger milik user (terbaru lebih dulu) beserta total entri yang cocok dengan filter.
+func (r *gormRepository) GetLedgerEntries(filter LedgerFilter) ([]model.LedgerEntry, int64, error) {
+	query := r.db.Model(&model.LedgerEntry{}).Where("user_id = ?", filter.UserID)
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []model.LedgerEntry
+	err := query.Order("created_at DESC, id DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&entries).Error
+	return entries, total, err
+}
+
+// GetEntriesByReferences mengambil semua entri ledger yang merujuk salah satu referenceIDs
+// dengan jenis referensi tertentu, diurutkan dari yang paling lama.
+func (r *gormRepository) GetEntriesByReferences(referenceType string, referenceIDs []string) ([]model.LedgerEntry, error) {
+	var entries []model.LedgerEntry
+	if len(referenceIDs) == 0 {
+		return entries, nil
+	}
+	err := r.db.Where("reference_type = ? AND reference_id IN ?", referenceType, referenceIDs).
+		Order("created_at, id").Find(&entries).Error
+	return entries, err
+}
+
+// CreateTransfer menyimpan transfer, mendebit pengirim, dan mengkredit penerima dalam satu
+// transaction database. Mengembalikan saldo pengirim setelah transfer.
+func (r *gormRepository) CreateTransfer(transfer *model.Transfer) (money.Money, error) {
+	var senderBalance money.Money
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Kunci kedua baris user dengan urutan ID menaik, apa pun arah transfernya.
+		// Dua transfer berlawanan arah (A->B dan B->A) dengan begitu selalu mengunci
+		// dalam urutan yang sama sehingga tidak saling menunggu (deadlock).
+		ids := []uint{transfer.FromUserID, transfer.ToUserID}
+		if ids[0] > ids[1] {
+			ids[0], ids[1] = ids[1], ids[0]
+		}
+		for _, id := range ids {
+			var user model.User
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, id).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(transfer).Error; err != nil {
+			return err
+		}
+
+		// Baris sudah terkunci, sehingga FOR UPDATE di applyBalanceChange tidak menunggu lagi
+		balance, err := applyBalanceChange(tx, BalanceChange{
+			UserID:        transfer.FromUserID,
+			Amount:        -transfer.Amount,
+			EntryType:     model.EntryTypeTransferOut,
+			ReferenceType: model.ReferenceTypeTransfer,
+			ReferenceID:   fmtID(transfer.ID),
+			Description:   "Transfer to user " + fmtID(transfer.ToUserID),
+		})
+		if err != nil {
+			return err
+		}
+		senderBalance = balance
+
+		_, err = applyBalanceChange(tx, BalanceChange{
+			UserID:        transfer.ToUserID,
+			Amount:        transfer.Amount,
+			EntryType:     model.EntryTypeTransferIn,
+			ReferenceType: model.ReferenceTypeTransfer,
+			ReferenceID:   fmtID(transfer.ID),
+			Description:   "Transfer from user " + fmtID(transfer.FromUserID),
+		})
+		return err
+	})
+
+	return senderBalance, err
+}
+
+// ListTransfers mengambil transfer masuk dan keluar milik user (terbaru lebih dulu) beserta totalnya.
+func (r *gormRepository) ListTransfers(filter TransferFilter) ([]model.Transfer, int64, error) {
+	query := r.db.Model(&model.Transfer{}).Where("from_user_id = ? OR to_user_id = ?", filter.UserID, filter.UserID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var transfers []model.Transfer
+	err := query.Order("created_at DESC, id DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&transfers).Error
+	return transfers, total, err
+}
+
+// GetSpendingLimit mengambil batas pengeluaran user. Mengembalikan (nil, nil) jika user belum punya batas.
+func (r *gormRepository) GetSpendingLimit(userID uint) (*model.SpendingLimit, error) {
+	return findSpendingLimit(r.db, userID)
+}
+
+// SaveSpendingLimit membuat atau mengganti batas pengeluaran user. Jika check tidak nil, check
+// dipanggil dengan batas yang tersimpan (nil jika belum ada) selagi baris user dikunci, sehingga
+// dua permintaan bersamaan tidak bisa melewati check terhadap batas yang sama.
+func (r *gormRepository) SaveSpendingLimit(limit *model.SpendingLimit, check func(current *model.SpendingLimit) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
 		var user model.User
-		// Kunci baris untuk mencegah race condition (SELECT ... FOR UPDATE)
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, limit.UserID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if check != nil {
+			current, err := findSpendingLimit(tx, limit.UserID)
+			if err != nil {
+				return err
+			}
+			if err := check(current); err != nil {
+				return err
+			}
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"daily_limit", "monthly_limit", "per_transaction_limit", "updated_at"}),
+		}).Create(limit).Error
+	})
+}
+
+// GetSpending menjumlahkan pengeluaran user (debit dan transfer keluar) sejak waktu tertentu.
+func (r *gormRepository) GetSpending(userID uint, since time.Time) (money.Money, error) {
+	return sumSpending(r.db, userID, since)
+}
+
+// GetAvailableBalance mengembalikan saldo dikurangi hold yang masih aktif.
+func (r *gormRepository) GetAvailableBalance(userID uint) (money.Money, error) {
+	var user model.User
+	if err := r.db.Select("saldo").First(&user, userID).Error; err != nil {
+		return 0, err
+	}
+	held, err := sumHeld(r.db, userID, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	return user.Saldo - held, nil
+}
+
+// CreateHold mencadangkan dana user tanpa mengubah saldo. Hold ditolak jika saldo tersedia
+// (saldo dikurangi hold aktif lain) tidak cukup atau melewati batas pengeluaran.
+// Idempoten terhadap hold.ReferenceID: permintaan ulang mengembalikan hold yang sudah ada.
+func (r *gormRepository) CreateHold(hold *model.Hold) (*model.Hold, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		// Kunci baris user agar dua authorize bersamaan tidak memakai saldo yang sama
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, hold.UserID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var existing model.Hold
+		result := tx.Where("reference_id = ?", hold.ReferenceID).Limit(1).Find(&existing)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			if existing.UserID != hold.UserID || existing.Amount != hold.Amount {
+				return errors.New("reference already used for a different operation")
+			}
+			*hold = existing
+			return nil
+		}
+
+		if err := checkLedger(tx, &user); err != nil {
 			return err
 		}
 
-		if user.Saldo+amount < 0 {
-			return errors.New("insufficient funds")
+		now := time.Now()
+		held, err := sumHeld(tx, hold.UserID, now)
+		if err != nil {
+			return err
+		}
+		if user.Saldo-held-hold.Amount < 0 {
+			return ErrInsufficientFunds
+		}
+		if err := checkSpendingLimit(tx, BalanceChange{UserID: hold.UserID, Amount: -hold.Amount}, now); err != nil {
+			return err
 		}
 
-		newBalance := user.Saldo + amount
-		if err := tx.Model(&user).Update("saldo", newBalance).Error; err != nil {
+		hold.Status = model.HoldStatusActive
+		return tx.Create(hold).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+// CaptureHold mengubah hold aktif menjadi debit dengan referensi referenceID (mis. transaction_id).
+// Capture ulang dengan referensi yang sama mengembalikan hasil pertama. Hold yang sudah
+// kedaluwarsa ditandai expired dan mengembalikan ErrHoldExpired.
+func (r *gormRepository) CaptureHold(holdID uint, referenceID string) (*model.Hold, money.Money, error) {
+	var hold model.Hold
+	var newBalance money.Money
+	var captureErr error
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&hold, holdID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrHoldNotFound
+		}
+		if err != nil {
 			return err
 		}
 
-		finalBalance = newBalance
-		return nil // Return nil akan men-commit transaksi
+		switch hold.Status {
+		case model.HoldStatusCaptured:
+			if hold.CaptureReference != referenceID {
+				return ErrHoldCaptured
+			}
+		case model.HoldStatusVoided:
+			return ErrHoldVoided
+		case model.HoldStatusExpired:
+			return ErrHoldExpired
+		default:
+			if !hold.ExpiresAt.After(time.Now()) {
+				// Status expired tetap disimpan walaupun capture gagal
+				captureErr = ErrHoldExpired
+				return tx.Model(&hold).Update("status", model.HoldStatusExpired).Error
+			}
+			hold.Status = model.HoldStatusCaptured
+			hold.CaptureReference = referenceID
+			if err := tx.Save(&hold).Error; err != nil {
+				return err
+			}
+		}
+
+		// Capture ulang melewati processed_references sehingga saldo tidak berubah lagi
+		balance, err := applyBalanceChange(tx, BalanceChange{
+			UserID:        hold.UserID,
+			Amount:        -hold.Amount,
+			EntryType:     model.EntryTypeDebit,
+			ReferenceType: model.ReferenceTypeTransaction,
+			ReferenceID:   referenceID,
+			Description:   "Payment for transaction " + referenceID,
+			HoldID:        hold.ID,
+		})
+		newBalance = balance
+		return err
 	})
+	if err == nil {
+		err = captureErr
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return &hold, newBalance, nil
+}
 
-	return finalBalance, err
+// VoidHold membatalkan hold aktif sehingga dananya kembali tersedia.
+// Hold yang sudah voided atau expired dikembalikan apa adanya; hold yang sudah di-capture ditolak.
+func (r *gormRepository) VoidHold(holdID uint) (*model.Hold, error) {
+	var hold model.Hold
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&hold, holdID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrHoldNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		switch hold.Status {
+		case model.HoldStatusCaptured:
+			return ErrHoldCaptured
+		case model.HoldStatusActive:
+			hold.Status = model.HoldStatusVoided
+			return tx.Save(&hold).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
 }
 
-func (r *gormRepository) CreateTopUp(topUp *model.TopUp) (*model.TopUp, error) {
-	err := r.db.Create(topUp).Error
-	return topUp, err
+// SpendingPeriods mengembalikan awal hari dan awal bulan dari now, dalam zona waktu now.
+func SpendingPeriods(now time.Time) (dayStart, monthStart time.Time) {
+	year, month, day := now.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, now.Location()),
+		time.Date(year, month, 1, 0, 0, 0, 0, now.Location())
+}
+
+// findSpendingLimit mengambil batas pengeluaran user, atau nil jika belum ada.
+func findSpendingLimit(db *gorm.DB, userID uint) (*model.SpendingLimit, error) {
+	var limit model.SpendingLimit
+	result := db.Where("user_id = ?", userID).Limit(1).Find(&limit)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &limit, nil
+}
+
+// sumSpending menjumlahkan nilai debit dan transfer_out milik user sejak since, sebagai angka positif.
+// Refund tidak mengurangi pemakaian: batas menghitung berapa banyak yang sudah dibelanjakan.
+func sumSpending(db *gorm.DB, userID uint, since time.Time) (money.Money, error) {
+	var spent money.Money
+	err := db.Model(&model.LedgerEntry{}).
+		Select("COALESCE(-SUM(amount), 0)").
+		Where("user_id = ? AND entry_type IN ? AND created_at >= ?",
+			userID, []string{model.EntryTypeDebit, model.EntryTypeTransferOut}, since).
+		Scan(&spent).Error
+	return spent, err
+}
+
+// sumHeld menjumlahkan hold aktif milik user yang belum kedaluwarsa pada waktu now.
+func sumHeld(db *gorm.DB, userID uint, now time.Time) (money.Money, error) {
+	var held money.Money
+	err := db.Model(&model.Hold{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("user_id = ? AND status = ? AND expires_at > ?", userID, model.HoldStatusActive, now).
+		Scan(&held).Error
+	return held, err
+}
+
+// checkSpendingLimit menolak pengeluaran yang melewati batas per transaksi, harian, atau bulanan.
+// Hold aktif ikut dihitung sebagai pengeluaran karena dananya sudah dijanjikan untuk dibelanjakan.
+// Dipanggil saat baris user sudah terkunci, sehingga debit bersamaan tidak bisa melewati batas bersama-sama.
+func checkSpendingLimit(tx *gorm.DB, change BalanceChange, now time.Time) error {
+	limit, err := findSpendingLimit(tx, change.UserID)
+	if err != nil || limit == nil {
+		return err
+	}
+
+	amount := -change.Amount
+	if limit.PerTransactionLimit.IsPositive() && amount > limit.PerTransactionLimit {
+		return &SpendingLimitError{Code: LimitCodePerTransaction, Limit: limit.PerTransactionLimit}
+	}
+
+	var held money.Money
+	if limit.DailyLimit.IsPositive() || limit.MonthlyLimit.IsPositive() {
+		if held, err = sumHeld(tx, change.UserID, now); err != nil {
+			return err
+		}
+	}
+
+	dayStart, monthStart := SpendingPeriods(now)
+	for _, period := range []struct {
+		code  string
+		limit money.Money
+		since time.Time
+	}{
+		{LimitCodeDaily, limit.DailyLimit, dayStart},
+		{LimitCodeMonthly, limit.MonthlyLimit, monthStart},
+	} {
+		if !period.limit.IsPositive() {
+			continue
+		}
+		spent, err := sumSpending(tx, change.UserID, period.since)
+		if err != nil {
+			return err
+		}
+		if spent+held+amount > period.limit {
+			return &SpendingLimitError{Code: period.code, Limit: period.limit, Spent: spent + held}
+		}
+	}
+	return nil
+}
+
+// recordTopUpStatus mencatat perubahan status top-up di dalam transaction tx.
+func recordTopUpStatus(tx *gorm.DB, topUpID uint, status string) error {
+	return tx.Create(&model.TopUpStatusChange{TopUpID: topUpID, Status: status}).Error
+}
+
+// orderStatusChanges mengurutkan riwayat status top-up dari yang paling lama.
+func orderStatusChanges(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at, id")
+}
+
+// applyBalanceChange mengubah saldo user dan mencatat entri ledger di dalam transaction tx.
+func applyBalanceChange(tx *gorm.DB, change BalanceChange) (money.Money, error) {
+	var user model.User
+	// Kunci baris untuk mencegah race condition (SELECT ... FOR UPDATE)
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, change.UserID).Error; err != nil {
+		return 0, err
+	}
+
+	// Referensi yang sudah pernah diproses tidak mengubah saldo lagi
+	if change.ReferenceID != "" {
+		var processed model.ProcessedReference
+		err := tx.Where("reference = ? AND kind = ?", change.ReferenceID, change.EntryType).First(&processed).Error
+		if err == nil {
+			if processed.UserID != change.UserID || processed.Amount != change.Amount {
+				return 0, errors.New("reference already used for a different operation")
+			}
+			return processed.ResultBalance, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, err
+		}
+	}
+
+	if err := checkLedger(tx, &user); err != nil {
+		return 0, err
+	}
+
+	// Pengurangan saldo hanya boleh memakai saldo yang tidak sedang ditahan hold lain
+	now := time.Now()
+	available := user.Saldo
+	if change.Amount < 0 && change.HoldID == 0 {
+		held, err := sumHeld(tx, change.UserID, now)
+		if err != nil {
+			return 0, err
+		}
+		available -= held
+	}
+	if available+change.Amount < 0 {
+		return 0, ErrInsufficientFunds
+	}
+
+	// Batas pengeluaran hanya berlaku untuk belanja dan transfer keluar, bukan refund atau koreksi
+	if change.HoldID == 0 && (change.EntryType == model.EntryTypeDebit || change.EntryType == model.EntryTypeTransferOut) {
+		if err := checkSpendingLimit(tx, change, now); err != nil {
+			return 0, err
+		}
+	}
+
+	newBalance := user.Saldo + change.Amount
+	if err := tx.Model(&user).Update("saldo", newBalance).Error; err != nil {
+		return 0, err
+	}
+
+	entry := model.LedgerEntry{
+		UserID:        change.UserID,
+		EntryType:     change.EntryType,
+		Amount:        change.Amount,
+		BalanceAfter:  newBalance,
+		ReferenceType: change.ReferenceType,
+		ReferenceID:   change.ReferenceID,
+		Description:   change.Description,
+	}
+	if err := tx.Create(&entry).Error; err != nil {
+		return 0, err
+	}
+
+	if change.ReferenceID != "" {
+		processed := model.ProcessedReference{
+			Reference:     change.ReferenceID,
+			Kind:          change.EntryType,
+			UserID:        change.UserID,
+			Amount:        change.Amount,
+			ResultBalance: newBalance,
+		}
+		if err := tx.Create(&processed).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	return newBalance, nil
+}
+
+// checkLedger memastikan users.saldo sama dengan saldo akhir di ledger.
+// Saldo lama yang belum punya entri ledger dicatat sebagai entri adjustment pembuka.
+func checkLedger(tx *gorm.DB, user *model.User) error {
+	var last model.LedgerEntry
+	result := tx.Where("user_id = ?", user.ID).Order("id DESC").Limit(1).Find(&last)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		if user.Saldo == 0 {
+			return nil
+		}
+		opening := model.LedgerEntry{
+			UserID:       user.ID,
+			EntryType:    model.EntryTypeAdjustment,
+			Amount:       user.Saldo,
+			BalanceAfter: user.Saldo,
+			Description:  "Opening balance",
+		}
+		return tx.Create(&opening).Error
+	}
+
+	if last.BalanceAfter != user.Saldo {
+		return ErrLedgerMismatch
+	}
+	return nil
+}
+
+func fmtID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
 }
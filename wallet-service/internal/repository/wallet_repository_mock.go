@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"shared/money"
+	"time"
 	"wallet-service/internal/model"
 
 	"github.com/stretchr/testify/mock"
@@ -10,14 +12,14 @@ type MockWalletRepository struct {
 	mock.Mock
 }
 
-func (m *MockWalletRepository) GetBalance(userID uint) (float64, error) {
+func (m *MockWalletRepository) GetBalance(userID uint) (money.Money, error) {
 	args := m.Called(userID)
-	return args.Get(0).(float64), args.Error(1)
+	return args.Get(0).(money.Money), args.Error(1)
 }
 
-func (m *MockWalletRepository) UpdateBalance(userID uint, amount float64) (float64, error) {
-	args := m.Called(userID, amount)
-	return args.Get(0).(float64), args.Error(1)
+func (m *MockWalletRepository) UpdateBalance(change BalanceChange) (money.Money, error) {
+	args := m.Called(change)
+	return args.Get(0).(money.Money), args.Error(1)
 }
 
 func (m *MockWalletRepository) CreateTopUp(topUp *model.TopUp) (*model.TopUp, error) {
@@ -26,4 +28,117 @@ func (m *MockWalletRepository) CreateTopUp(topUp *model.TopUp) (*model.TopUp, er
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.TopUp), args.Error(1)
-}
\ No newline at end of file
+}
+
+func (m *MockWalletRepository) GetLedgerEntries(filter LedgerFilter) ([]model.LedgerEntry, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]model.LedgerEntry), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWalletRepository) GetEntriesByReferences(referenceType string, referenceIDs []string) ([]model.LedgerEntry, error) {
+	args := m.Called(referenceType, referenceIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.LedgerEntry), args.Error(1)
+}
+
+func (m *MockWalletRepository) CreateTransfer(transfer *model.Transfer) (money.Money, error) {
+	args := m.Called(transfer)
+	return args.Get(0).(money.Money), args.Error(1)
+}
+
+func (m *MockWalletRepository) ListTransfers(filter TransferFilter) ([]model.Transfer, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]model.Transfer), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWalletRepository) UpdateTopUpPayment(topUp *model.TopUp) error {
+	args := m.Called(topUp)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) SettleTopUp(paymentReference string, paid bool) (*model.TopUp, error) {
+	args := m.Called(paymentReference, paid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TopUp), args.Error(1)
+}
+
+func (m *MockWalletRepository) ListTopUps(filter TopUpFilter) ([]model.TopUp, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]model.TopUp), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWalletRepository) GetTopUp(id uint) (*model.TopUp, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TopUp), args.Error(1)
+}
+
+func (m *MockWalletRepository) GetSpendingLimit(userID uint) (*model.SpendingLimit, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SpendingLimit), args.Error(1)
+}
+
+// SaveSpendingLimit menjalankan check terhadap batas tersimpan yang diberikan sebagai nilai
+// kembalian kedua, mis. Return(nil, &model.SpendingLimit{...}). Tanpa nilai itu check dilewati.
+func (m *MockWalletRepository) SaveSpendingLimit(limit *model.SpendingLimit, check func(current *model.SpendingLimit) error) error {
+	args := m.Called(limit)
+	if check != nil && len(args) > 1 {
+		current, _ := args.Get(1).(*model.SpendingLimit)
+		if err := check(current); err != nil {
+			return err
+		}
+	}
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) GetSpending(userID uint, since time.Time) (money.Money, error) {
+	args := m.Called(userID, since)
+	return args.Get(0).(money.Money), args.Error(1)
+}
+
+func (m *MockWalletRepository) GetAvailableBalance(userID uint) (money.Money, error) {
+	args := m.Called(userID)
+	return args.Get(0).(money.Money), args.Error(1)
+}
+
+func (m *MockWalletRepository) CreateHold(hold *model.Hold) (*model.Hold, error) {
+	args := m.Called(hold)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Hold), args.Error(1)
+}
+
+func (m *MockWalletRepository) CaptureHold(holdID uint, referenceID string) (*model.Hold, money.Money, error) {
+	args := m.Called(holdID, referenceID)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(money.Money), args.Error(2)
+	}
+	return args.Get(0).(*model.Hold), args.Get(1).(money.Money), args.Error(2)
+}
+
+func (m *MockWalletRepository) VoidHold(holdID uint) (*model.Hold, error) {
+	args := m.Called(holdID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Hold), args.Error(1)
+}
@@ -2,19 +2,26 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"wallet-service/internal/repository"
 	"wallet-service/internal/service"
+	"wallet-service/pkg/payment"
 	pb "wallet-service/proto"
 )
 
 type GrpcServer struct {
 	pb.UnimplementedWalletServiceServer
-	walletService service.WalletService
+	walletService     service.WalletService
+	deadLetterService service.DeadLetterService
 }
 
-func NewGrpcServer(ws service.WalletService) *GrpcServer {
-	return &GrpcServer{walletService: ws}
+func NewGrpcServer(ws service.WalletService, dls service.DeadLetterService) *GrpcServer {
+	return &GrpcServer{walletService: ws, deadLetterService: dls}
 }
 
 func (s *GrpcServer) GetBalance(ctx context.Context, req *pb.GetBalanceRequest) (*pb.GetBalanceResponse, error) {
@@ -22,7 +29,11 @@ func (s *GrpcServer) GetBalance(ctx context.Context, req *pb.GetBalanceRequest)
 	if err != nil {
 		return nil, err
 	}
-	return &pb.GetBalanceResponse{UserId: req.UserId, Balance: balance}, nil
+	available, err := s.walletService.GetAvailableBalance(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetBalanceResponse{UserId: req.UserId, Balance: balance.ToProto(), AvailableBalance: available.ToProto()}, nil
 }
 
 func (s *GrpcServer) TopUp(ctx context.Context, req *pb.TopUpRequest) (*pb.TopUpResponse, error) {
@@ -31,20 +42,55 @@ func (s *GrpcServer) TopUp(ctx context.Context, req *pb.TopUpRequest) (*pb.TopUp
 		return nil, err
 	}
 	return &pb.TopUpResponse{
-		TopUpId:   fmt.Sprintf("%d", topUp.ID),
-		UserId:    fmt.Sprintf("%d", topUp.UserID),
-		Amount:    topUp.Amount,
-		Status:    topUp.Status,
-		TopUpDate: timestamppb.New(topUp.CreatedAt),
+		TopUpId:          fmt.Sprintf("%d", topUp.ID),
+		UserId:           fmt.Sprintf("%d", topUp.UserID),
+		Amount:           topUp.Amount.ToProto(),
+		Status:           topUp.Status,
+		TopUpDate:        timestamppb.New(topUp.CreatedAt),
+		PaymentReference: topUp.PaymentReference,
+		CheckoutUrl:      topUp.CheckoutURL,
 	}, nil
 }
 
+func (s *GrpcServer) HandlePaymentWebhook(ctx context.Context, req *pb.PaymentWebhookRequest) (*pb.PaymentWebhookResponse, error) {
+	topUp, err := s.walletService.HandlePaymentWebhook(ctx, req)
+	switch {
+	case errors.Is(err, payment.ErrInvalidSignature):
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, payment.ErrInvalidPayload):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repository.ErrTopUpNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrTopUpAlreadySettled):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	return &pb.PaymentWebhookResponse{TopUpId: fmt.Sprintf("%d", topUp.ID), Status: topUp.Status}, nil
+}
+
+func (s *GrpcServer) ListTopUps(ctx context.Context, req *pb.ListTopUpsRequest) (*pb.ListTopUpsResponse, error) {
+	response, err := s.walletService.ListTopUps(ctx, req)
+	if errors.Is(err, service.ErrInvalidTopUpStatus) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return response, err
+}
+
+func (s *GrpcServer) GetTopUp(ctx context.Context, req *pb.GetTopUpRequest) (*pb.GetTopUpResponse, error) {
+	response, err := s.walletService.GetTopUp(ctx, req)
+	if errors.Is(err, repository.ErrTopUpNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return response, err
+}
+
 func (s *GrpcServer) Debit(ctx context.Context, req *pb.DebitRequest) (*pb.DebitResponse, error) {
 	newBalance, err := s.walletService.Debit(ctx, req)
 	if err != nil {
 		return &pb.DebitResponse{Success: false}, err
 	}
-	return &pb.DebitResponse{Success: true, NewBalance: newBalance}, nil
+	return &pb.DebitResponse{Success: true, NewBalance: newBalance.ToProto()}, nil
 }
 
 func (s *GrpcServer) Credit(ctx context.Context, req *pb.CreditRequest) (*pb.CreditResponse, error) {
@@ -52,5 +98,140 @@ func (s *GrpcServer) Credit(ctx context.Context, req *pb.CreditRequest) (*pb.Cre
 	if err != nil {
 		return &pb.CreditResponse{Success: false}, err
 	}
-	return &pb.CreditResponse{Success: true, NewBalance: newBalance}, nil
-}
\ No newline at end of file
+	return &pb.CreditResponse{Success: true, NewBalance: newBalance.ToProto()}, nil
+}
+
+func (s *GrpcServer) GetLedger(ctx context.Context, req *pb.GetLedgerRequest) (*pb.GetLedgerResponse, error) {
+	return s.walletService.GetLedger(ctx, req)
+}
+
+func (s *GrpcServer) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	return s.deadLetterService.ListDeadLetters(ctx, req)
+}
+
+func (s *GrpcServer) ReplayDeadLetter(ctx context.Context, req *pb.ReplayDeadLetterRequest) (*pb.ReplayDeadLetterResponse, error) {
+	err := s.deadLetterService.ReplayDeadLetter(ctx, req)
+	switch {
+	case errors.Is(err, service.ErrDeadLetterNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrDeadLetterReplayed):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	return &pb.ReplayDeadLetterResponse{Success: true}, nil
+}
+
+func (s *GrpcServer) ListTransactionMovements(ctx context.Context, req *pb.ListTransactionMovementsRequest) (*pb.ListTransactionMovementsResponse, error) {
+	response, err := s.walletService.ListTransactionMovements(ctx, req)
+	if errors.Is(err, service.ErrTooManyTransactions) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return response, err
+}
+
+func (s *GrpcServer) Transfer(ctx context.Context, req *pb.TransferRequest) (*pb.TransferResponse, error) {
+	response, err := s.walletService.Transfer(ctx, req)
+	switch {
+	case errors.Is(err, service.ErrInvalidRecipient),
+		errors.Is(err, service.ErrSelfTransfer),
+		errors.Is(err, service.ErrInvalidTransferAmount),
+		errors.Is(err, service.ErrTransferNoteTooLong):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repository.ErrUserNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrInsufficientFunds), errors.Is(err, repository.ErrSpendingLimitExceeded):
+		return nil, failedPrecondition(err)
+	case err != nil:
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *GrpcServer) ListTransfers(ctx context.Context, req *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error) {
+	return s.walletService.ListTransfers(ctx, req)
+}
+
+func (s *GrpcServer) SetSpendingLimits(ctx context.Context, req *pb.SetSpendingLimitsRequest) (*pb.SpendingLimitsResponse, error) {
+	response, err := s.walletService.SetSpendingLimits(ctx, req)
+	switch {
+	case errors.Is(err, service.ErrInvalidSpendingLimit):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrSpendingLimitLoosened):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, repository.ErrUserNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return response, err
+}
+
+func (s *GrpcServer) GetSpendingLimits(ctx context.Context, req *pb.GetSpendingLimitsRequest) (*pb.SpendingLimitsResponse, error) {
+	return s.walletService.GetSpendingLimits(ctx, req)
+}
+
+func (s *GrpcServer) Authorize(ctx context.Context, req *pb.AuthorizeRequest) (*pb.HoldResponse, error) {
+	response, err := s.walletService.Authorize(ctx, req)
+	if err != nil {
+		return nil, holdError(err)
+	}
+	return response, nil
+}
+
+func (s *GrpcServer) Capture(ctx context.Context, req *pb.CaptureRequest) (*pb.CaptureResponse, error) {
+	response, err := s.walletService.Capture(ctx, req)
+	if err != nil {
+		return nil, holdError(err)
+	}
+	return response, nil
+}
+
+func (s *GrpcServer) Void(ctx context.Context, req *pb.VoidRequest) (*pb.HoldResponse, error) {
+	response, err := s.walletService.Void(ctx, req)
+	if err != nil {
+		return nil, holdError(err)
+	}
+	return response, nil
+}
+
+// holdError menerjemahkan error Authorize, Capture, dan Void menjadi status gRPC.
+func holdError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidHold):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repository.ErrUserNotFound), errors.Is(err, repository.ErrHoldNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrInsufficientFunds),
+		errors.Is(err, repository.ErrSpendingLimitExceeded),
+		errors.Is(err, repository.ErrHoldExpired),
+		errors.Is(err, repository.ErrHoldVoided),
+		errors.Is(err, repository.ErrHoldCaptured):
+		return failedPrecondition(err)
+	}
+	return err
+}
+
+// ErrorDomain adalah domain ErrorInfo yang dilampirkan pada status gRPC dari service ini.
+const ErrorDomain = "wallet-service"
+
+// failedPrecondition membuat status FailedPrecondition. Untuk pelanggaran batas pengeluaran,
+// kode batasnya (LimitCode*) dilampirkan sebagai ErrorInfo.Reason agar pemanggil tidak perlu
+// mengurai pesan error.
+func failedPrecondition(err error) error {
+	st := status.New(codes.FailedPrecondition, err.Error())
+	var limitErr *repository.SpendingLimitError
+	if !errors.As(err, &limitErr) {
+		return st.Err()
+	}
+	detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: limitErr.Code,
+		Domain: ErrorDomain,
+		Metadata: map[string]string{
+			"limit": limitErr.Limit.String(),
+			"spent": limitErr.Spent.String(),
+		},
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"shared/messagebroker"
+	"strconv"
+	"wallet-service/internal/model"
+	"wallet-service/internal/repository"
+	pb "wallet-service/proto"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	// ErrDeadLetterNotFound dikembalikan jika pesan dead-letter tidak ada.
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	// ErrDeadLetterReplayed dikembalikan jika pesan dead-letter sudah pernah diputar ulang.
+	ErrDeadLetterReplayed = errors.New("dead letter already replayed")
+)
+
+// DeadLetterService adalah interface untuk operasi admin atas pesan dead-letter.
+type DeadLetterService interface {
+	ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error)
+	ReplayDeadLetter(ctx context.Context, req *pb.ReplayDeadLetterRequest) error
+}
+
+type deadLetterService struct {
+	repo     repository.DeadLetterRepository
+	producer messagebroker.Producer
+}
+
+func NewDeadLetterService(repo repository.DeadLetterRepository, producer messagebroker.Producer) DeadLetterService {
+	return &deadLetterService{repo: repo, producer: producer}
+}
+
+func (s *deadLetterService) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	if req.Status != "" && req.Status != model.DeadLetterStatusPending && req.Status != model.DeadLetterStatusReplayed {
+		return nil, errors.New("invalid dead letter status")
+	}
+
+	page, pageSize := normalizePage(req.Page, req.PageSize)
+	deadLetters, total, err := s.repo.ListDeadLetters(req.Status, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var pbDeadLetters []*pb.DeadLetter
+	for _, dl := range deadLetters {
+		pbDeadLetter := &pb.DeadLetter{
+			Id:        strconv.FormatUint(uint64(dl.ID), 10),
+			Topic:     dl.Topic,
+			Partition: int32(dl.Partition),
+			Offset:    dl.Offset,
+			Key:       dl.Key,
+			Payload:   string(dl.Payload),
+			Error:     dl.Error,
+			Attempts:  int32(dl.Attempts),
+			Status:    dl.Status,
+			CreatedAt: timestamppb.New(dl.CreatedAt),
+		}
+		if dl.ReplayedAt != nil {
+			pbDeadLetter.ReplayedAt = timestamppb.New(*dl.ReplayedAt)
+		}
+		pbDeadLetters = append(pbDeadLetters, pbDeadLetter)
+	}
+
+	return &pb.ListDeadLettersResponse{
+		DeadLetters: pbDeadLetters,
+		Total:       total,
+		Page:        int32(page),
+		PageSize:    int32(pageSize),
+	}, nil
+}
+
+// ReplayDeadLetter mengirim ulang payload asli ke topic asalnya. Consumer bersifat idempoten
+// (debit memakai transaction_id sebagai referensi), jadi replay tidak akan mendebit dua kali.
+func (s *deadLetterService) ReplayDeadLetter(ctx context.Context, req *pb.ReplayDeadLetterRequest) error {
+	id, err := strconv.ParseUint(req.Id, 10, 32)
+	if err != nil {
+		return errors.New("invalid dead letter id format")
+	}
+
+	deadLetter, err := s.repo.GetDeadLetter(uint(id))
+	if err != nil {
+		return err
+	}
+	if deadLetter == nil {
+		return ErrDeadLetterNotFound
+	}
+	if deadLetter.Status != model.DeadLetterStatusPending {
+		return ErrDeadLetterReplayed
+	}
+	if !json.Valid(deadLetter.Payload) {
+		return errors.New("dead letter payload is not valid JSON and cannot be replayed")
+	}
+
+	if err := s.producer.Publish(ctx, messagebroker.Message{
+		Topic: deadLetter.Topic,
+		Key:   deadLetter.Key,
+		Value: json.RawMessage(deadLetter.Payload),
+	}); err != nil {
+		return err
+	}
+
+	updated, err := s.repo.MarkDeadLetterReplayed(deadLetter.ID)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrDeadLetterReplayed
+	}
+	return nil
+}
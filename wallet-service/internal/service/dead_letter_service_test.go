@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"shared/messagebroker"
+	"testing"
+	"wallet-service/internal/model"
+	"wallet-service/internal/repository"
+	pb "wallet-service/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Tes untuk ListDeadLetters dengan filter status
+func TestListDeadLetters_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockDeadLetterRepository)
+	deadLetters := []model.DeadLetter{
+		{ID: 3, Topic: "transaction_created", Offset: 42, Payload: []byte(`{"transaction_id":"99"}`), Error: "timeout", Attempts: 5, Status: model.DeadLetterStatusPending},
+	}
+	mockRepo.On("ListDeadLetters", model.DeadLetterStatusPending, 0, 20).Return(deadLetters, int64(1), nil)
+
+	deadLetterService := NewDeadLetterService(mockRepo, nil)
+
+	// Act
+	res, err := deadLetterService.ListDeadLetters(context.Background(), &pb.ListDeadLettersRequest{Status: "pending"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.Total)
+	assert.Equal(t, "3", res.DeadLetters[0].Id)
+	assert.Equal(t, `{"transaction_id":"99"}`, res.DeadLetters[0].Payload)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk ReplayDeadLetter mengirim ulang payload asli ke topic asalnya
+func TestReplayDeadLetter_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockDeadLetterRepository)
+	mockProducer := new(messagebroker.MockProducer)
+	payload := []byte(`{"transaction_id":"99","user_id":"1","total_amount":50000}`)
+
+	mockRepo.On("GetDeadLetter", uint(3)).Return(&model.DeadLetter{ID: 3, Topic: "transaction_created", Key: "1", Payload: payload, Status: model.DeadLetterStatusPending}, nil)
+	mockProducer.On("Publish", mock.Anything, messagebroker.Message{Topic: "transaction_created", Key: "1", Value: json.RawMessage(payload)}).Return(nil)
+	mockRepo.On("MarkDeadLetterReplayed", uint(3)).Return(true, nil)
+
+	deadLetterService := NewDeadLetterService(mockRepo, mockProducer)
+
+	// Act
+	err := deadLetterService.ReplayDeadLetter(context.Background(), &pb.ReplayDeadLetterRequest{Id: "3"})
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockProducer.AssertExpectations(t)
+}
+
+// Tes untuk ReplayDeadLetter pada pesan yang sudah pernah diputar ulang
+func TestReplayDeadLetter_AlreadyReplayed(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockDeadLetterRepository)
+	mockProducer := new(messagebroker.MockProducer)
+	mockRepo.On("GetDeadLetter", uint(3)).Return(&model.DeadLetter{ID: 3, Status: model.DeadLetterStatusReplayed}, nil)
+
+	deadLetterService := NewDeadLetterService(mockRepo, mockProducer)
+
+	// Act
+	err := deadLetterService.ReplayDeadLetter(context.Background(), &pb.ReplayDeadLetterRequest{Id: "3"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDeadLetterReplayed)
+	mockProducer.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+// Tes untuk ReplayDeadLetter dengan ID yang tidak ada
+func TestReplayDeadLetter_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockDeadLetterRepository)
+	mockRepo.On("GetDeadLetter", uint(7)).Return(nil, nil)
+
+	deadLetterService := NewDeadLetterService(mockRepo, nil)
+
+	// Act
+	err := deadLetterService.ReplayDeadLetter(context.Background(), &pb.ReplayDeadLetterRequest{Id: "7"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDeadLetterNotFound)
+}
@@ -3,28 +3,91 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"shared/money"
+	sharedpb "shared/proto"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 	"wallet-service/internal/model"
 	"wallet-service/internal/repository"
+	"wallet-service/pkg/payment"
 	pb "wallet-service/proto"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type WalletService interface {
-	GetBalance(ctx context.Context, req *pb.GetBalanceRequest) (float64, error)
+	GetBalance(ctx context.Context, req *pb.GetBalanceRequest) (money.Money, error)
+	GetAvailableBalance(ctx context.Context, req *pb.GetBalanceRequest) (money.Money, error)
 	TopUp(ctx context.Context, req *pb.TopUpRequest) (*model.TopUp, error)
-	Debit(ctx context.Context, req *pb.DebitRequest) (float64, error)
-	Credit(ctx context.Context, req *pb.CreditRequest) (float64, error)
+	HandlePaymentWebhook(ctx context.Context, req *pb.PaymentWebhookRequest) (*model.TopUp, error)
+	ListTopUps(ctx context.Context, req *pb.ListTopUpsRequest) (*pb.ListTopUpsResponse, error)
+	GetTopUp(ctx context.Context, req *pb.GetTopUpRequest) (*pb.GetTopUpResponse, error)
+	Debit(ctx context.Context, req *pb.DebitRequest) (money.Money, error)
+	Credit(ctx context.Context, req *pb.CreditRequest) (money.Money, error)
+	GetLedger(ctx context.Context, req *pb.GetLedgerRequest) (*pb.GetLedgerResponse, error)
+	ListTransactionMovements(ctx context.Context, req *pb.ListTransactionMovementsRequest) (*pb.ListTransactionMovementsResponse, error)
+	Transfer(ctx context.Context, req *pb.TransferRequest) (*pb.TransferResponse, error)
+	ListTransfers(ctx context.Context, req *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error)
+	SetSpendingLimits(ctx context.Context, req *pb.SetSpendingLimitsRequest) (*pb.SpendingLimitsResponse, error)
+	GetSpendingLimits(ctx context.Context, req *pb.GetSpendingLimitsRequest) (*pb.SpendingLimitsResponse, error)
+	Authorize(ctx context.Context, req *pb.AuthorizeRequest) (*pb.HoldResponse, error)
+	Capture(ctx context.Context, req *pb.CaptureRequest) (*pb.CaptureResponse, error)
+	Void(ctx context.Context, req *pb.VoidRequest) (*pb.HoldResponse, error)
 }
 
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	// Jumlah transaksi maksimal per permintaan ListTransactionMovements
+	maxMovementTransactions = 500
+
+	// Panjang maksimal catatan transfer, sesuai kolom transfers.note
+	maxTransferNoteLength = 255
+
+	// Masa berlaku hold jika Authorize tidak menyebutkannya, dan batas maksimalnya
+	defaultHoldTTL = 15 * time.Minute
+	maxHoldTTL     = 24 * time.Hour
+)
+
+// ErrTooManyTransactions dikembalikan jika ListTransactionMovements meminta terlalu banyak transaksi sekaligus.
+var ErrTooManyTransactions = errors.New("too many transaction ids in one request")
+
+// ErrInvalidTopUpStatus dikembalikan jika filter status ListTopUps tidak dikenal.
+var ErrInvalidTopUpStatus = errors.New("invalid top-up status filter")
+
+// ErrInvalidSpendingLimit dikembalikan jika batas pengeluaran negatif atau tidak konsisten.
+var ErrInvalidSpendingLimit = errors.New("invalid spending limit")
+
+// ErrSpendingLimitLoosened dikembalikan jika user (bukan admin) mencoba menaikkan atau menghapus
+// batas pengeluarannya sendiri.
+var ErrSpendingLimitLoosened = errors.New("only an admin can raise or remove spending limits")
+
+// ErrInvalidHold dikembalikan jika permintaan Authorize, Capture, atau Void tidak valid.
+var ErrInvalidHold = errors.New("invalid hold request")
+
+// Error validasi transfer. Semuanya berarti permintaan transfer tidak valid.
+var (
+	ErrInvalidRecipient      = errors.New("invalid recipient user id")
+	ErrSelfTransfer          = errors.New("cannot transfer to yourself")
+	ErrInvalidTransferAmount = errors.New("transfer amount must be positive")
+	ErrTransferNoteTooLong   = errors.New("transfer note must be at most 255 characters")
+)
+
 type walletService struct {
-	repo repository.WalletRepository
+	repo     repository.WalletRepository
+	provider payment.Provider
 }
 
-func NewWalletService(repo repository.WalletRepository) WalletService {
-	return &walletService{repo: repo}
+func NewWalletService(repo repository.WalletRepository, provider payment.Provider) WalletService {
+	return &walletService{repo: repo, provider: provider}
 }
 
-func (s *walletService) GetBalance(ctx context.Context, req *pb.GetBalanceRequest) (float64, error) {
+func (s *walletService) GetBalance(ctx context.Context, req *pb.GetBalanceRequest) (money.Money, error) {
 	userID, err := strconv.ParseUint(req.UserId, 10, 32)
 	if err != nil {
 		return 0, errors.New("invalid user id format")
@@ -32,47 +95,575 @@ func (s *walletService) GetBalance(ctx context.Context, req *pb.GetBalanceReques
 	return s.repo.GetBalance(uint(userID))
 }
 
+// GetAvailableBalance mengembalikan saldo yang masih bisa dipakai, yaitu saldo dikurangi hold aktif.
+func (s *walletService) GetAvailableBalance(ctx context.Context, req *pb.GetBalanceRequest) (money.Money, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return 0, errors.New("invalid user id format")
+	}
+	return s.repo.GetAvailableBalance(uint(userID))
+}
+
 func (s *walletService) TopUp(ctx context.Context, req *pb.TopUpRequest) (*model.TopUp, error) {
 	userID, err := strconv.ParseUint(req.UserId, 10, 32)
 	if err != nil {
 		return nil, errors.New("invalid user id format")
 	}
-	if req.Amount <= 0 {
+	amount, err := money.FromProto(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if !amount.IsPositive() {
 		return nil, errors.New("top-up amount must be positive")
 	}
 
-	if _, err := s.repo.UpdateBalance(uint(userID), req.Amount); err != nil {
+	// Top-up dibuat pending; saldo baru bertambah saat webhook provider mengonfirmasi pembayaran
+	topUp := &model.TopUp{
+		UserID:   uint(userID),
+		Amount:   amount,
+		Method:   req.Method,
+		Status:   model.TopUpStatusPending,
+		Provider: s.provider.Name(),
+	}
+	if _, err := s.repo.CreateTopUp(topUp); err != nil {
 		return nil, err
 	}
 
-	topUp := &model.TopUp{
+	checkout, err := s.provider.CreateCheckout(ctx, payment.CheckoutRequest{
+		OrderID: strconv.FormatUint(uint64(topUp.ID), 10),
+		UserID:  req.UserId,
+		Amount:  amount,
+	})
+	if err != nil {
+		topUp.Status = model.TopUpStatusFailed
+		if updateErr := s.repo.UpdateTopUpPayment(topUp); updateErr != nil {
+			log.Printf("Failed to mark top-up %d as failed: %v", topUp.ID, updateErr)
+		}
+		return nil, err
+	}
+
+	topUp.PaymentReference = checkout.Reference
+	topUp.CheckoutURL = checkout.CheckoutURL
+	if err := s.repo.UpdateTopUpPayment(topUp); err != nil {
+		return nil, err
+	}
+	return topUp, nil
+}
+
+// HandlePaymentWebhook memverifikasi webhook dari payment provider lalu menyelesaikan top-up terkait.
+func (s *walletService) HandlePaymentWebhook(ctx context.Context, req *pb.PaymentWebhookRequest) (*model.TopUp, error) {
+	event, err := s.provider.ParseWebhook(req.Payload, req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.SettleTopUp(event.Reference, event.Status == payment.StatusPaid)
+}
+
+func (s *walletService) ListTopUps(ctx context.Context, req *pb.ListTopUpsRequest) (*pb.ListTopUpsResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid user id format")
+	}
+	switch req.Status {
+	case "", model.TopUpStatusPending, model.TopUpStatusSuccess, model.TopUpStatusFailed:
+	default:
+		return nil, ErrInvalidTopUpStatus
+	}
+
+	page, pageSize := normalizePage(req.Page, req.PageSize)
+
+	topUps, total, err := s.repo.ListTopUps(repository.TopUpFilter{
 		UserID: uint(userID),
-		Amount: req.Amount,
+		Status: req.Status,
 		Method: req.Method,
-		Status: "success",
+		Offset: (page - 1) * pageSize,
+		Limit:  pageSize,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.repo.CreateTopUp(topUp)
+	response := &pb.ListTopUpsResponse{
+		TopUps:   make([]*pb.TopUp, len(topUps)),
+		Total:    total,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}
+	for i := range topUps {
+		response.TopUps[i] = toTopUpProto(&topUps[i])
+	}
+	return response, nil
 }
 
-func (s *walletService) Debit(ctx context.Context, req *pb.DebitRequest) (float64, error) {
+// GetTopUp mengambil bukti satu top-up. Top-up milik user lain dianggap tidak ada.
+func (s *walletService) GetTopUp(ctx context.Context, req *pb.GetTopUpRequest) (*pb.GetTopUpResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid user id format")
+	}
+	topUpID, err := strconv.ParseUint(req.TopUpId, 10, 32)
+	if err != nil {
+		return nil, repository.ErrTopUpNotFound
+	}
+
+	topUp, err := s.repo.GetTopUp(uint(topUpID))
+	if err != nil {
+		return nil, err
+	}
+	if topUp == nil || topUp.UserID != uint(userID) {
+		return nil, repository.ErrTopUpNotFound
+	}
+	return &pb.GetTopUpResponse{TopUp: toTopUpProto(topUp)}, nil
+}
+
+func (s *walletService) Debit(ctx context.Context, req *pb.DebitRequest) (money.Money, error) {
 	userID, err := strconv.ParseUint(req.UserId, 10, 32)
 	if err != nil {
 		return 0, errors.New("invalid user id format")
 	}
-	if req.Amount <= 0 {
+	amount, err := money.FromProto(req.Amount)
+	if err != nil {
+		return 0, err
+	}
+	if !amount.IsPositive() {
 		return 0, errors.New("debit amount must be positive")
 	}
-	return s.repo.UpdateBalance(uint(userID), -req.Amount)
+
+	change := repository.BalanceChange{
+		UserID:      uint(userID),
+		Amount:      -amount,
+		EntryType:   model.EntryTypeDebit,
+		Description: "Debit",
+	}
+	if req.ReferenceId != "" {
+		change.ReferenceType = model.ReferenceTypeTransaction
+		change.ReferenceID = req.ReferenceId
+		change.Description = "Payment for transaction " + req.ReferenceId
+	}
+	return s.repo.UpdateBalance(change)
 }
 
-func (s *walletService) Credit(ctx context.Context, req *pb.CreditRequest) (float64, error) {
+func (s *walletService) Credit(ctx context.Context, req *pb.CreditRequest) (money.Money, error) {
 	userID, err := strconv.ParseUint(req.UserId, 10, 32)
 	if err != nil {
 		return 0, errors.New("invalid user id format")
 	}
-	if req.Amount <= 0 {
+	amount, err := money.FromProto(req.Amount)
+	if err != nil {
+		return 0, err
+	}
+	if !amount.IsPositive() {
 		return 0, errors.New("credit amount must be positive")
 	}
-	return s.repo.UpdateBalance(uint(userID), req.Amount)
-}
\ No newline at end of file
+
+	change := repository.BalanceChange{
+		UserID:      uint(userID),
+		Amount:      amount,
+		EntryType:   model.EntryTypeCredit,
+		ReferenceID: req.ReferenceId,
+		Description: "Credit",
+	}
+	if req.Refund {
+		change.EntryType = model.EntryTypeRefund
+		change.ReferenceType = model.ReferenceTypeTransaction
+		change.Description = "Refund"
+	}
+	return s.repo.UpdateBalance(change)
+}
+
+func (s *walletService) GetLedger(ctx context.Context, req *pb.GetLedgerRequest) (*pb.GetLedgerResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid user id format")
+	}
+
+	page, pageSize := normalizePage(req.Page, req.PageSize)
+
+	filter := repository.LedgerFilter{
+		UserID: uint(userID),
+		Offset: (page - 1) * pageSize,
+		Limit:  pageSize,
+	}
+	if req.StartTime != nil {
+		from := req.StartTime.AsTime()
+		filter.From = &from
+	}
+	if req.EndTime != nil {
+		to := req.EndTime.AsTime()
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, errors.New("start_time must be before end_time")
+	}
+
+	entries, total, err := s.repo.GetLedgerEntries(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var pbEntries []*pb.LedgerEntry
+	for _, entry := range entries {
+		pbEntries = append(pbEntries, toLedgerEntryProto(entry))
+	}
+
+	return &pb.GetLedgerResponse{
+		Entries:  pbEntries,
+		Total:    total,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}, nil
+}
+
+// ListTransactionMovements mengambil debit dan refund untuk transaksi-transaksi yang diminta.
+// Refund dicatat dengan referensi "refund-<transaction_id>", sehingga keduanya ikut dicari.
+func (s *walletService) ListTransactionMovements(ctx context.Context, req *pb.ListTransactionMovementsRequest) (*pb.ListTransactionMovementsResponse, error) {
+	if len(req.TransactionIds) > maxMovementTransactions {
+		return nil, ErrTooManyTransactions
+	}
+
+	references := make([]string, 0, len(req.TransactionIds)*2)
+	for _, id := range req.TransactionIds {
+		references = append(references, id, "refund-"+id)
+	}
+	entries, err := s.repo.GetEntriesByReferences(model.ReferenceTypeTransaction, references)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListTransactionMovementsResponse{Entries: make([]*pb.LedgerEntry, len(entries))}
+	for i, entry := range entries {
+		response.Entries[i] = toLedgerEntryProto(entry)
+	}
+	return response, nil
+}
+
+// Transfer memindahkan saldo dari from_user_id ke to_user_id. Debit pengirim dan kredit penerima
+// terjadi dalam satu transaction database, sehingga keduanya berhasil atau gagal bersama.
+func (s *walletService) Transfer(ctx context.Context, req *pb.TransferRequest) (*pb.TransferResponse, error) {
+	fromUserID, err := strconv.ParseUint(req.FromUserId, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid user id format")
+	}
+	toUserID, err := strconv.ParseUint(req.ToUserId, 10, 32)
+	if err != nil || toUserID == 0 {
+		return nil, ErrInvalidRecipient
+	}
+	if fromUserID == toUserID {
+		return nil, ErrSelfTransfer
+	}
+	amount, err := money.FromProto(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if !amount.IsPositive() {
+		return nil, ErrInvalidTransferAmount
+	}
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > maxTransferNoteLength {
+		return nil, ErrTransferNoteTooLong
+	}
+
+	transfer := &model.Transfer{
+		FromUserID: uint(fromUserID),
+		ToUserID:   uint(toUserID),
+		Amount:     amount,
+		Note:       note,
+	}
+	balance, err := s.repo.CreateTransfer(transfer)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TransferResponse{Transfer: toTransferProto(transfer), NewBalance: balance.ToProto()}, nil
+}
+
+func (s *walletService) ListTransfers(ctx context.Context, req *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid user id format")
+	}
+
+	page, pageSize := normalizePage(req.Page, req.PageSize)
+
+	transfers, total, err := s.repo.ListTransfers(repository.TransferFilter{
+		UserID: uint(userID),
+		Offset: (page - 1) * pageSize,
+		Limit:  pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListTransfersResponse{
+		Transfers: make([]*pb.Transfer, len(transfers)),
+		Total:     total,
+		Page:      int32(page),
+		PageSize:  int32(pageSize),
+	}
+	for i := range transfers {
+		response.Transfers[i] = toTransferProto(&transfers[i])
+	}
+	return response, nil
+}
+
+// SetSpendingLimits mengganti batas pengeluaran user. Batas yang kosong atau 0 berarti tidak dibatasi.
+// Jika pemanggil bukan admin, batas yang berlaku hanya boleh diperketat.
+func (s *walletService) SetSpendingLimits(ctx context.Context, req *pb.SetSpendingLimitsRequest) (*pb.SpendingLimitsResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid user id format")
+	}
+
+	limit := &model.SpendingLimit{UserID: uint(userID)}
+	for _, field := range []struct {
+		name   string
+		value  *sharedpb.Money
+		target *money.Money
+	}{
+		{"daily_limit", req.DailyLimit, &limit.DailyLimit},
+		{"monthly_limit", req.MonthlyLimit, &limit.MonthlyLimit},
+		{"per_transaction_limit", req.PerTransactionLimit, &limit.PerTransactionLimit},
+	} {
+		if field.value == nil {
+			continue
+		}
+		amount, err := money.FromProto(field.value)
+		if err != nil {
+			return nil, err
+		}
+		if amount.IsNegative() {
+			return nil, fmt.Errorf("%w: %s must not be negative", ErrInvalidSpendingLimit, field.name)
+		}
+		*field.target = amount
+	}
+	if limit.DailyLimit.IsPositive() && limit.MonthlyLimit.IsPositive() && limit.DailyLimit > limit.MonthlyLimit {
+		return nil, fmt.Errorf("%w: daily_limit must not exceed monthly_limit", ErrInvalidSpendingLimit)
+	}
+	if limit.PerTransactionLimit.IsPositive() && limit.DailyLimit.IsPositive() && limit.PerTransactionLimit > limit.DailyLimit {
+		return nil, fmt.Errorf("%w: per_transaction_limit must not exceed daily_limit", ErrInvalidSpendingLimit)
+	}
+
+	var check func(current *model.SpendingLimit) error
+	if !req.CallerIsAdmin {
+		check = func(current *model.SpendingLimit) error {
+			return checkStricterSpendingLimit(current, limit)
+		}
+	}
+	if err := s.repo.SaveSpendingLimit(limit, check); err != nil {
+		return nil, err
+	}
+	return s.spendingLimitsResponse(limit)
+}
+
+// checkStricterSpendingLimit menolak next jika menaikkan atau menghapus (mengisi 0) salah satu
+// batas yang berlaku di current. current nil berarti user belum punya batas.
+func checkStricterSpendingLimit(current, next *model.SpendingLimit) error {
+	if current == nil {
+		return nil
+	}
+	for _, field := range []struct {
+		name               string
+		current, requested money.Money
+	}{
+		{"daily_limit", current.DailyLimit, next.DailyLimit},
+		{"monthly_limit", current.MonthlyLimit, next.MonthlyLimit},
+		{"per_transaction_limit", current.PerTransactionLimit, next.PerTransactionLimit},
+	} {
+		if field.current.IsPositive() && (field.requested == 0 || field.requested > field.current) {
+			return fmt.Errorf("%w: %s would be raised or removed", ErrSpendingLimitLoosened, field.name)
+		}
+	}
+	return nil
+}
+
+// GetSpendingLimits mengambil batas pengeluaran user beserta pemakaiannya hari ini dan bulan ini.
+func (s *walletService) GetSpendingLimits(ctx context.Context, req *pb.GetSpendingLimitsRequest) (*pb.SpendingLimitsResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid user id format")
+	}
+
+	limit, err := s.repo.GetSpendingLimit(uint(userID))
+	if err != nil {
+		return nil, err
+	}
+	if limit == nil {
+		limit = &model.SpendingLimit{UserID: uint(userID)}
+	}
+	return s.spendingLimitsResponse(limit)
+}
+
+// spendingLimitsResponse melengkapi batas pengeluaran dengan pemakaian hari ini dan bulan ini.
+func (s *walletService) spendingLimitsResponse(limit *model.SpendingLimit) (*pb.SpendingLimitsResponse, error) {
+	dayStart, monthStart := repository.SpendingPeriods(time.Now())
+	spentToday, err := s.repo.GetSpending(limit.UserID, dayStart)
+	if err != nil {
+		return nil, err
+	}
+	spentThisMonth, err := s.repo.GetSpending(limit.UserID, monthStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SpendingLimitsResponse{
+		UserId:              strconv.FormatUint(uint64(limit.UserID), 10),
+		DailyLimit:          limit.DailyLimit.ToProto(),
+		MonthlyLimit:        limit.MonthlyLimit.ToProto(),
+		PerTransactionLimit: limit.PerTransactionLimit.ToProto(),
+		SpentToday:          spentToday.ToProto(),
+		SpentThisMonth:      spentThisMonth.ToProto(),
+	}, nil
+}
+
+// Authorize mencadangkan dana user untuk pembelian. Saldo tidak berubah sampai hold di-capture;
+// hold yang tidak di-capture sebelum kedaluwarsa otomatis tidak lagi menahan dana.
+func (s *walletService) Authorize(ctx context.Context, req *pb.AuthorizeRequest) (*pb.HoldResponse, error) {
+	userID, err := strconv.ParseUint(req.UserId, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid user id format")
+	}
+	amount, err := money.FromProto(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidHold)
+	}
+	if req.ReferenceId == "" {
+		return nil, fmt.Errorf("%w: reference_id is required", ErrInvalidHold)
+	}
+
+	ttl := defaultHoldTTL
+	if req.ExpiresInSeconds < 0 {
+		return nil, fmt.Errorf("%w: expires_in_seconds must not be negative", ErrInvalidHold)
+	}
+	if req.ExpiresInSeconds > 0 {
+		ttl = time.Duration(req.ExpiresInSeconds) * time.Second
+	}
+	if ttl > maxHoldTTL {
+		ttl = maxHoldTTL
+	}
+
+	hold, err := s.repo.CreateHold(&model.Hold{
+		UserID:      uint(userID),
+		Amount:      amount,
+		ReferenceID: req.ReferenceId,
+		ExpiresAt:   time.Now().Add(ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.HoldResponse{Hold: toHoldProto(hold)}, nil
+}
+
+// Capture mengubah hold menjadi debit dengan reference_id sebagai referensi ledger.
+func (s *walletService) Capture(ctx context.Context, req *pb.CaptureRequest) (*pb.CaptureResponse, error) {
+	holdID, err := strconv.ParseUint(req.HoldId, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid hold id format", ErrInvalidHold)
+	}
+	if req.ReferenceId == "" {
+		return nil, fmt.Errorf("%w: reference_id is required", ErrInvalidHold)
+	}
+
+	hold, newBalance, err := s.repo.CaptureHold(uint(holdID), req.ReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CaptureResponse{Hold: toHoldProto(hold), NewBalance: newBalance.ToProto()}, nil
+}
+
+// Void membatalkan hold yang belum di-capture.
+func (s *walletService) Void(ctx context.Context, req *pb.VoidRequest) (*pb.HoldResponse, error) {
+	holdID, err := strconv.ParseUint(req.HoldId, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid hold id format", ErrInvalidHold)
+	}
+
+	hold, err := s.repo.VoidHold(uint(holdID))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.HoldResponse{Hold: toHoldProto(hold)}, nil
+}
+
+// toHoldProto mengubah data hold menjadi pesan gRPC.
+func toHoldProto(hold *model.Hold) *pb.Hold {
+	return &pb.Hold{
+		Id:               strconv.FormatUint(uint64(hold.ID), 10),
+		UserId:           strconv.FormatUint(uint64(hold.UserID), 10),
+		Amount:           hold.Amount.ToProto(),
+		Status:           hold.Status,
+		ReferenceId:      hold.ReferenceID,
+		CaptureReference: hold.CaptureReference,
+		ExpiresAt:        timestamppb.New(hold.ExpiresAt),
+		CreatedAt:        timestamppb.New(hold.CreatedAt),
+	}
+}
+
+// toTopUpProto mengubah data top-up beserta riwayat statusnya menjadi pesan gRPC.
+// Top-up lama yang dibuat sebelum riwayat status ada ditampilkan dengan satu perubahan status saat dibuat.
+func toTopUpProto(topUp *model.TopUp) *pb.TopUp {
+	changes := topUp.StatusChanges
+	if len(changes) == 0 {
+		changes = []model.TopUpStatusChange{{Status: topUp.Status, CreatedAt: topUp.CreatedAt}}
+	}
+	transitions := make([]*pb.TopUpStatusChange, len(changes))
+	for i, change := range changes {
+		transitions[i] = &pb.TopUpStatusChange{Status: change.Status, CreatedAt: timestamppb.New(change.CreatedAt)}
+	}
+
+	return &pb.TopUp{
+		Id:               strconv.FormatUint(uint64(topUp.ID), 10),
+		UserId:           strconv.FormatUint(uint64(topUp.UserID), 10),
+		Amount:           topUp.Amount.ToProto(),
+		Method:           topUp.Method,
+		Status:           topUp.Status,
+		Provider:         topUp.Provider,
+		PaymentReference: topUp.PaymentReference,
+		CheckoutUrl:      topUp.CheckoutURL,
+		CreatedAt:        timestamppb.New(topUp.CreatedAt),
+		UpdatedAt:        timestamppb.New(topUp.UpdatedAt),
+		Transitions:      transitions,
+	}
+}
+
+// toTransferProto mengubah data transfer menjadi pesan gRPC.
+func toTransferProto(transfer *model.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:         strconv.FormatUint(uint64(transfer.ID), 10),
+		FromUserId: strconv.FormatUint(uint64(transfer.FromUserID), 10),
+		ToUserId:   strconv.FormatUint(uint64(transfer.ToUserID), 10),
+		Amount:     transfer.Amount.ToProto(),
+		Note:       transfer.Note,
+		CreatedAt:  timestamppb.New(transfer.CreatedAt),
+	}
+}
+
+// toLedgerEntryProto mengubah entri ledger menjadi pesan gRPC.
+func toLedgerEntryProto(entry model.LedgerEntry) *pb.LedgerEntry {
+	return &pb.LedgerEntry{
+		Id:            strconv.FormatUint(uint64(entry.ID), 10),
+		UserId:        strconv.FormatUint(uint64(entry.UserID), 10),
+		EntryType:     entry.EntryType,
+		Amount:        entry.Amount.ToProto(),
+		BalanceAfter:  entry.BalanceAfter.ToProto(),
+		ReferenceType: entry.ReferenceType,
+		ReferenceId:   entry.ReferenceID,
+		Description:   entry.Description,
+		CreatedAt:     timestamppb.New(entry.CreatedAt),
+	}
+}
+
+// normalizePage mengisi nilai default pagination dan membatasi ukuran halaman.
+func normalizePage(page, pageSize int32) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return int(page), int(pageSize)
+}
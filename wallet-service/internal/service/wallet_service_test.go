@@ -3,13 +3,19 @@ package service
 import (
 	"context"
 	"errors"
+	"shared/money"
+	sharedpb "shared/proto"
+	"strings"
 	"testing"
+	"time"
 	"wallet-service/internal/model"
 	"wallet-service/internal/repository"
+	"wallet-service/pkg/payment"
 	pb "wallet-service/proto"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Tes untuk GetBalance
@@ -19,37 +25,187 @@ func TestGetBalance_Success(t *testing.T) {
 	req := &pb.GetBalanceRequest{UserId: "1"}
 	
 	// Program mock untuk mengembalikan saldo
-	mockRepo.On("GetBalance", uint(1)).Return(float64(100000), nil)
+	mockRepo.On("GetBalance", uint(1)).Return(money.FromRupiah(100000), nil)
 	
-	walletService := NewWalletService(mockRepo)
+	walletService := NewWalletService(mockRepo, nil)
 
 	// Act
 	balance, err := walletService.GetBalance(context.Background(), req)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, float64(100000), balance)
+	assert.Equal(t, money.FromRupiah(100000), balance)
 	mockRepo.AssertExpectations(t)
 }
 
-// Tes untuk TopUp
+// Tes untuk TopUp: top-up dibuat pending dengan checkout dari provider, saldo belum berubah
 func TestTopUp_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(repository.MockWalletRepository)
-	req := &pb.TopUpRequest{UserId: "1", Amount: 50000, Method: "Transfer"}
+	req := &pb.TopUpRequest{UserId: "1", Amount: money.FromRupiah(50000).ToProto(), Method: "Transfer"}
 
-	// Program mock
-	mockRepo.On("UpdateBalance", uint(1), req.Amount).Return(float64(150000), nil)
-	mockRepo.On("CreateTopUp", mock.AnythingOfType("*model.TopUp")).Return(&model.TopUp{ID: 1}, nil)
+	mockRepo.On("CreateTopUp", mock.MatchedBy(func(topUp *model.TopUp) bool {
+		return topUp.Status == model.TopUpStatusPending && topUp.Provider == "fake"
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.TopUp).ID = 12
+	}).Return(&model.TopUp{ID: 12}, nil)
+	mockRepo.On("UpdateTopUpPayment", mock.AnythingOfType("*model.TopUp")).Return(nil)
 
-	walletService := NewWalletService(mockRepo)
+	walletService := NewWalletService(mockRepo, payment.NewFakeProvider([]byte("secret"), "http://pay.local"))
 
 	// Act
 	result, err := walletService.TopUp(context.Background(), req)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.NotNil(t, result)
+	assert.Equal(t, model.TopUpStatusPending, result.Status)
+	assert.True(t, strings.HasPrefix(result.PaymentReference, "fake_12_"))
+	assert.Equal(t, "http://pay.local/"+result.PaymentReference, result.CheckoutURL)
+	mockRepo.AssertNotCalled(t, "UpdateBalance", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk HandlePaymentWebhook: pembayaran sukses menyelesaikan top-up
+func TestHandlePaymentWebhook_Paid(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	provider := payment.NewFakeProvider([]byte("secret"), "")
+	payload := []byte(`{"reference":"fake_12_abc","status":"paid"}`)
+
+	mockRepo.On("SettleTopUp", "fake_12_abc", true).Return(&model.TopUp{ID: 12, Status: model.TopUpStatusSuccess}, nil)
+
+	walletService := NewWalletService(mockRepo, provider)
+
+	// Act
+	topUp, err := walletService.HandlePaymentWebhook(context.Background(), &pb.PaymentWebhookRequest{
+		Payload:   payload,
+		Signature: provider.Sign(payload),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, model.TopUpStatusSuccess, topUp.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk HandlePaymentWebhook: pembayaran gagal tidak menambah saldo
+func TestHandlePaymentWebhook_Failed(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	provider := payment.NewFakeProvider([]byte("secret"), "")
+	payload := []byte(`{"reference":"fake_12_abc","status":"failed"}`)
+
+	mockRepo.On("SettleTopUp", "fake_12_abc", false).Return(&model.TopUp{ID: 12, Status: model.TopUpStatusFailed}, nil)
+
+	walletService := NewWalletService(mockRepo, provider)
+
+	// Act
+	topUp, err := walletService.HandlePaymentWebhook(context.Background(), &pb.PaymentWebhookRequest{
+		Payload:   payload,
+		Signature: provider.Sign(payload),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, model.TopUpStatusFailed, topUp.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk HandlePaymentWebhook dengan tanda tangan yang salah
+func TestHandlePaymentWebhook_InvalidSignature(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	walletService := NewWalletService(mockRepo, payment.NewFakeProvider([]byte("secret"), ""))
+
+	// Act
+	_, err := walletService.HandlePaymentWebhook(context.Background(), &pb.PaymentWebhookRequest{
+		Payload:   []byte(`{"reference":"fake_12_abc","status":"paid"}`),
+		Signature: "forged",
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, payment.ErrInvalidSignature)
+	mockRepo.AssertNotCalled(t, "SettleTopUp", mock.Anything, mock.Anything)
+}
+
+// Tes untuk ListTopUps dengan filter status dan metode
+func TestListTopUps_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	createdAt := time.Date(2025, 7, 25, 10, 0, 0, 0, time.UTC)
+	paidAt := createdAt.Add(2 * time.Minute)
+	topUps := []model.TopUp{{
+		ID: 12, UserID: 1, Amount: money.FromRupiah(50000), Method: "Transfer", Status: model.TopUpStatusSuccess, CreatedAt: createdAt,
+		StatusChanges: []model.TopUpStatusChange{
+			{Status: model.TopUpStatusPending, CreatedAt: createdAt},
+			{Status: model.TopUpStatusSuccess, CreatedAt: paidAt},
+		},
+	}}
+	mockRepo.On("ListTopUps", repository.TopUpFilter{UserID: 1, Status: "success", Method: "Transfer", Offset: 0, Limit: 20}).
+		Return(topUps, int64(1), nil)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.ListTopUps(context.Background(), &pb.ListTopUpsRequest{UserId: "1", Status: "success", Method: "Transfer"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, res.TopUps, 1)
+	assert.Equal(t, "Transfer", res.TopUps[0].Method)
+	assert.Len(t, res.TopUps[0].Transitions, 2)
+	assert.Equal(t, model.TopUpStatusSuccess, res.TopUps[0].Transitions[1].Status)
+	assert.Equal(t, paidAt, res.TopUps[0].Transitions[1].CreatedAt.AsTime())
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk ListTopUps dengan filter status yang tidak dikenal
+func TestListTopUps_InvalidStatus(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	_, err := walletService.ListTopUps(context.Background(), &pb.ListTopUpsRequest{UserId: "1", Status: "refunded"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidTopUpStatus)
+	mockRepo.AssertNotCalled(t, "ListTopUps", mock.Anything)
+}
+
+// Tes untuk GetTopUp: top-up lama tanpa riwayat status tetap punya satu perubahan status
+func TestGetTopUp_LegacyWithoutHistory(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	createdAt := time.Date(2025, 7, 1, 8, 0, 0, 0, time.UTC)
+	mockRepo.On("GetTopUp", uint(5)).Return(&model.TopUp{ID: 5, UserID: 1, Status: model.TopUpStatusSuccess, CreatedAt: createdAt}, nil)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.GetTopUp(context.Background(), &pb.GetTopUpRequest{UserId: "1", TopUpId: "5"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, res.TopUp.Transitions, 1)
+	assert.Equal(t, model.TopUpStatusSuccess, res.TopUp.Transitions[0].Status)
+	assert.Equal(t, createdAt, res.TopUp.Transitions[0].CreatedAt.AsTime())
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk GetTopUp milik user lain
+func TestGetTopUp_OtherUser(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	mockRepo.On("GetTopUp", uint(5)).Return(&model.TopUp{ID: 5, UserID: 2}, nil)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	_, err := walletService.GetTopUp(context.Background(), &pb.GetTopUpRequest{UserId: "1", TopUpId: "5"})
+
+	// Assert
+	assert.ErrorIs(t, err, repository.ErrTopUpNotFound)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -57,19 +213,21 @@ func TestTopUp_Success(t *testing.T) {
 func TestDebit_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(repository.MockWalletRepository)
-	req := &pb.DebitRequest{UserId: "1", Amount: 25000}
+	req := &pb.DebitRequest{UserId: "1", Amount: money.FromRupiah(25000).ToProto()}
 
 	// Program mock untuk mengurangi saldo
-	mockRepo.On("UpdateBalance", uint(1), -req.Amount).Return(float64(75000), nil)
+	mockRepo.On("UpdateBalance", mock.MatchedBy(func(c repository.BalanceChange) bool {
+		return c.UserID == 1 && c.Amount == -money.FromRupiah(25000) && c.EntryType == model.EntryTypeDebit
+	})).Return(money.FromRupiah(75000), nil)
 
-	walletService := NewWalletService(mockRepo)
+	walletService := NewWalletService(mockRepo, nil)
 
 	// Act
 	newBalance, err := walletService.Debit(context.Background(), req)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, float64(75000), newBalance)
+	assert.Equal(t, money.FromRupiah(75000), newBalance)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -77,12 +235,12 @@ func TestDebit_Success(t *testing.T) {
 func TestDebit_InsufficientFunds(t *testing.T) {
 	// Arrange
 	mockRepo := new(repository.MockWalletRepository)
-	req := &pb.DebitRequest{UserId: "1", Amount: 100000}
+	req := &pb.DebitRequest{UserId: "1", Amount: money.FromRupiah(100000).ToProto()}
 
 	// Program mock untuk mengembalikan error "insufficient funds"
-	mockRepo.On("UpdateBalance", uint(1), -req.Amount).Return(float64(0), errors.New("insufficient funds"))
+	mockRepo.On("UpdateBalance", mock.AnythingOfType("repository.BalanceChange")).Return(money.Money(0), errors.New("insufficient funds"))
 
-	walletService := NewWalletService(mockRepo)
+	walletService := NewWalletService(mockRepo, nil)
 
 	// Act
 	_, err := walletService.Debit(context.Background(), req)
@@ -93,22 +251,527 @@ func TestDebit_InsufficientFunds(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// Tes untuk Debit dengan mata uang selain IDR
+func TestDebit_UnsupportedCurrency(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	req := &pb.DebitRequest{UserId: "1", Amount: &sharedpb.Money{CurrencyCode: "USD", MinorUnits: 1000}}
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	_, err := walletService.Debit(context.Background(), req)
+
+	// Assert
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "UpdateBalance", mock.Anything)
+}
+
 // Tes untuk Credit
 func TestCredit_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(repository.MockWalletRepository)
-	req := &pb.CreditRequest{UserId: "1", Amount: 10000}
+	req := &pb.CreditRequest{UserId: "1", Amount: money.FromRupiah(10000).ToProto()}
 
 	// Program mock untuk menambah saldo
-	mockRepo.On("UpdateBalance", uint(1), req.Amount).Return(float64(110000), nil)
+	mockRepo.On("UpdateBalance", mock.MatchedBy(func(c repository.BalanceChange) bool {
+		return c.UserID == 1 && c.Amount == money.FromRupiah(10000) && c.EntryType == model.EntryTypeCredit
+	})).Return(money.FromRupiah(110000), nil)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	newBalance, err := walletService.Credit(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromRupiah(110000), newBalance)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk Debit dengan referensi idempotensi
+func TestDebit_WithReference(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	req := &pb.DebitRequest{UserId: "1", Amount: money.FromRupiah(25000).ToProto(), ReferenceId: "99"}
+
+	// Debit dengan referensi dicatat di ledger sebagai pembayaran transaksi
+	mockRepo.On("UpdateBalance", repository.BalanceChange{
+		UserID:        1,
+		Amount:        -money.FromRupiah(25000),
+		EntryType:     model.EntryTypeDebit,
+		ReferenceType: model.ReferenceTypeTransaction,
+		ReferenceID:   "99",
+		Description:   "Payment for transaction 99",
+	}).Return(money.FromRupiah(75000), nil)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act: kirim permintaan yang sama dua kali (simulasi redelivery Kafka)
+	first, err1 := walletService.Debit(context.Background(), req)
+	second, err2 := walletService.Debit(context.Background(), req)
+
+	// Assert: repository menjamin hasil yang sama untuk referensi yang sama
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, first, second)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk Credit dengan referensi idempotensi
+func TestCredit_WithReference(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	req := &pb.CreditRequest{UserId: "1", Amount: money.FromRupiah(10000).ToProto(), ReferenceId: "refund-99", Refund: true}
 
-	walletService := NewWalletService(mockRepo)
+	// Kredit refund dicatat dengan jenis entri refund
+	mockRepo.On("UpdateBalance", mock.MatchedBy(func(c repository.BalanceChange) bool {
+		return c.EntryType == model.EntryTypeRefund && c.ReferenceID == "refund-99" && c.Amount == money.FromRupiah(10000)
+	})).Return(money.FromRupiah(110000), nil)
+
+	walletService := NewWalletService(mockRepo, nil)
 
 	// Act
 	newBalance, err := walletService.Credit(context.Background(), req)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, float64(110000), newBalance)
+	assert.Equal(t, money.FromRupiah(110000), newBalance)
 	mockRepo.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+// Tes untuk GetLedger dengan pagination dan rentang waktu
+func TestGetLedger_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	req := &pb.GetLedgerRequest{
+		UserId:    "1",
+		StartTime: timestamppb.New(start),
+		EndTime:   timestamppb.New(end),
+		Page:      2,
+		PageSize:  10,
+	}
+
+	entries := []model.LedgerEntry{
+		{ID: 11, UserID: 1, EntryType: model.EntryTypeDebit, Amount: money.FromRupiah(-25000), BalanceAfter: money.FromRupiah(75000), ReferenceType: model.ReferenceTypeTransaction, ReferenceID: "99"},
+	}
+	mockRepo.On("GetLedgerEntries", repository.LedgerFilter{UserID: 1, From: &start, To: &end, Offset: 10, Limit: 10}).
+		Return(entries, int64(11), nil)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.GetLedger(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), res.Total)
+	assert.Equal(t, int32(2), res.Page)
+	assert.Len(t, res.Entries, 1)
+	assert.Equal(t, "99", res.Entries[0].ReferenceId)
+	assert.Equal(t, money.FromRupiah(75000).Minor(), res.Entries[0].BalanceAfter.MinorUnits)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk GetLedger dengan rentang waktu terbalik
+func TestGetLedger_InvalidRange(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	now := time.Now()
+	req := &pb.GetLedgerRequest{
+		UserId:    "1",
+		StartTime: timestamppb.New(now),
+		EndTime:   timestamppb.New(now.Add(-time.Hour)),
+	}
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	_, err := walletService.GetLedger(context.Background(), req)
+
+	// Assert
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "GetLedgerEntries", mock.Anything)
+}
+
+// Tes untuk ListTransactionMovements mencari debit dan refund setiap transaksi
+func TestListTransactionMovements_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	entries := []model.LedgerEntry{
+		{ID: 11, UserID: 1, EntryType: model.EntryTypeDebit, Amount: money.FromRupiah(-25000), ReferenceType: model.ReferenceTypeTransaction, ReferenceID: "99"},
+		{ID: 12, UserID: 1, EntryType: model.EntryTypeRefund, Amount: money.FromRupiah(25000), ReferenceType: model.ReferenceTypeTransaction, ReferenceID: "refund-99"},
+	}
+	mockRepo.On("GetEntriesByReferences", model.ReferenceTypeTransaction, []string{"99", "refund-99", "100", "refund-100"}).
+		Return(entries, nil)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.ListTransactionMovements(context.Background(), &pb.ListTransactionMovementsRequest{
+		TransactionIds: []string{"99", "100"},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, res.Entries, 2)
+	assert.Equal(t, "1", res.Entries[0].UserId)
+	assert.Equal(t, "refund-99", res.Entries[1].ReferenceId)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk ListTransactionMovements dengan terlalu banyak transaksi
+func TestListTransactionMovements_TooMany(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	ids := make([]string, maxMovementTransactions+1)
+	for i := range ids {
+		ids[i] = "1"
+	}
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	_, err := walletService.ListTransactionMovements(context.Background(), &pb.ListTransactionMovementsRequest{TransactionIds: ids})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrTooManyTransactions)
+	mockRepo.AssertNotCalled(t, "GetEntriesByReferences", mock.Anything, mock.Anything)
+}
+
+// Tes untuk Transfer
+func TestTransfer_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	mockRepo.On("CreateTransfer", mock.MatchedBy(func(transfer *model.Transfer) bool {
+		return transfer.FromUserID == 1 && transfer.ToUserID == 2 &&
+			transfer.Amount == money.FromRupiah(15000) && transfer.Note == "Uang jajan"
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*model.Transfer).ID = 7
+	}).Return(money.FromRupiah(85000), nil)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.Transfer(context.Background(), &pb.TransferRequest{
+		FromUserId: "1",
+		ToUserId:   "2",
+		Amount:     money.FromRupiah(15000).ToProto(),
+		Note:       "  Uang jajan ",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "7", res.Transfer.Id)
+	assert.Equal(t, "2", res.Transfer.ToUserId)
+	assert.Equal(t, money.FromRupiah(85000).Minor(), res.NewBalance.GetMinorUnits())
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk Transfer dengan permintaan yang tidak valid
+func TestTransfer_InvalidRequest(t *testing.T) {
+	longNote := make([]byte, maxTransferNoteLength+1)
+	for i := range longNote {
+		longNote[i] = 'a'
+	}
+
+	tests := []struct {
+		name    string
+		req     *pb.TransferRequest
+		wantErr error
+	}{
+		{"self transfer", &pb.TransferRequest{FromUserId: "1", ToUserId: "1", Amount: money.FromRupiah(1000).ToProto()}, ErrSelfTransfer},
+		{"invalid recipient", &pb.TransferRequest{FromUserId: "1", ToUserId: "abc", Amount: money.FromRupiah(1000).ToProto()}, ErrInvalidRecipient},
+		{"zero amount", &pb.TransferRequest{FromUserId: "1", ToUserId: "2", Amount: money.Money(0).ToProto()}, ErrInvalidTransferAmount},
+		{"negative amount", &pb.TransferRequest{FromUserId: "1", ToUserId: "2", Amount: money.FromRupiah(-1000).ToProto()}, ErrInvalidTransferAmount},
+		{"note too long", &pb.TransferRequest{FromUserId: "1", ToUserId: "2", Amount: money.FromRupiah(1000).ToProto(), Note: string(longNote)}, ErrTransferNoteTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(repository.MockWalletRepository)
+			walletService := NewWalletService(mockRepo, nil)
+
+			// Act
+			_, err := walletService.Transfer(context.Background(), tt.req)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockRepo.AssertNotCalled(t, "CreateTransfer", mock.Anything)
+		})
+	}
+}
+
+// Tes untuk Transfer saat saldo pengirim tidak cukup
+func TestTransfer_InsufficientFunds(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	mockRepo.On("CreateTransfer", mock.Anything).Return(money.Money(0), repository.ErrInsufficientFunds)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.Transfer(context.Background(), &pb.TransferRequest{
+		FromUserId: "1",
+		ToUserId:   "2",
+		Amount:     money.FromRupiah(500000).ToProto(),
+	})
+
+	// Assert
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, repository.ErrInsufficientFunds)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk ListTransfers
+func TestListTransfers_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	transfers := []model.Transfer{
+		{ID: 8, FromUserID: 2, ToUserID: 1, Amount: money.FromRupiah(5000)},
+		{ID: 7, FromUserID: 1, ToUserID: 2, Amount: money.FromRupiah(15000), Note: "Uang jajan"},
+	}
+	mockRepo.On("ListTransfers", repository.TransferFilter{UserID: 1, Offset: 10, Limit: 10}).Return(transfers, int64(12), nil)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.ListTransfers(context.Background(), &pb.ListTransfersRequest{UserId: "1", Page: 2, PageSize: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, res.Transfers, 2)
+	assert.Equal(t, "8", res.Transfers[0].Id)
+	assert.Equal(t, "Uang jajan", res.Transfers[1].Note)
+	assert.Equal(t, int64(12), res.Total)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk SetSpendingLimits
+func TestSetSpendingLimits_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	mockRepo.On("SaveSpendingLimit", &model.SpendingLimit{
+		UserID:              3,
+		DailyLimit:          money.FromRupiah(50000),
+		MonthlyLimit:        money.FromRupiah(500000),
+		PerTransactionLimit: money.FromRupiah(25000),
+	}).Return(nil)
+	mockRepo.On("GetSpending", uint(3), mock.AnythingOfType("time.Time")).Return(money.FromRupiah(10000), nil).Once()
+	mockRepo.On("GetSpending", uint(3), mock.AnythingOfType("time.Time")).Return(money.FromRupiah(120000), nil).Once()
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.SetSpendingLimits(context.Background(), &pb.SetSpendingLimitsRequest{
+		UserId:              "3",
+		DailyLimit:          money.FromRupiah(50000).ToProto(),
+		MonthlyLimit:        money.FromRupiah(500000).ToProto(),
+		PerTransactionLimit: money.FromRupiah(25000).ToProto(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromRupiah(50000).Minor(), res.DailyLimit.GetMinorUnits())
+	assert.Equal(t, money.FromRupiah(10000).Minor(), res.SpentToday.GetMinorUnits())
+	assert.Equal(t, money.FromRupiah(120000).Minor(), res.SpentThisMonth.GetMinorUnits())
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk SetSpendingLimits dengan batas yang tidak valid
+func TestSetSpendingLimits_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.SetSpendingLimitsRequest
+	}{
+		{"negative", &pb.SetSpendingLimitsRequest{UserId: "3", DailyLimit: money.FromRupiah(-1).ToProto()}},
+		{"daily above monthly", &pb.SetSpendingLimitsRequest{UserId: "3", DailyLimit: money.FromRupiah(600000).ToProto(), MonthlyLimit: money.FromRupiah(500000).ToProto()}},
+		{"per transaction above daily", &pb.SetSpendingLimitsRequest{UserId: "3", DailyLimit: money.FromRupiah(50000).ToProto(), PerTransactionLimit: money.FromRupiah(60000).ToProto()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(repository.MockWalletRepository)
+			walletService := NewWalletService(mockRepo, nil)
+
+			// Act
+			_, err := walletService.SetSpendingLimits(context.Background(), tt.req)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidSpendingLimit)
+			mockRepo.AssertNotCalled(t, "SaveSpendingLimit", mock.Anything)
+		})
+	}
+}
+
+// Tes untuk SetSpendingLimits: user hanya boleh memperketat batasnya sendiri, admin boleh melonggarkan
+func TestSetSpendingLimits_StricterOnly(t *testing.T) {
+	current := &model.SpendingLimit{UserID: 3, DailyLimit: money.FromRupiah(50000), MonthlyLimit: money.FromRupiah(500000)}
+	tests := []struct {
+		name    string
+		req     *pb.SetSpendingLimitsRequest
+		wantErr string // Kosong jika permintaan diterima
+	}{
+		{"user tightens daily limit", &pb.SetSpendingLimitsRequest{UserId: "3", DailyLimit: money.FromRupiah(30000).ToProto(), MonthlyLimit: money.FromRupiah(500000).ToProto()}, ""},
+		{"user raises daily limit", &pb.SetSpendingLimitsRequest{UserId: "3", DailyLimit: money.FromRupiah(100000).ToProto(), MonthlyLimit: money.FromRupiah(500000).ToProto()}, "daily_limit"},
+		{"user removes monthly limit", &pb.SetSpendingLimitsRequest{UserId: "3", DailyLimit: money.FromRupiah(50000).ToProto()}, "monthly_limit"},
+		{"admin raises daily limit", &pb.SetSpendingLimitsRequest{UserId: "3", DailyLimit: money.FromRupiah(100000).ToProto(), MonthlyLimit: money.FromRupiah(500000).ToProto(), CallerIsAdmin: true}, ""},
+		{"admin removes all limits", &pb.SetSpendingLimitsRequest{UserId: "3", CallerIsAdmin: true}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(repository.MockWalletRepository)
+			mockRepo.On("SaveSpendingLimit", mock.AnythingOfType("*model.SpendingLimit")).Return(nil, current)
+			mockRepo.On("GetSpending", uint(3), mock.AnythingOfType("time.Time")).Return(money.Money(0), nil).Maybe()
+			walletService := NewWalletService(mockRepo, nil)
+
+			// Act
+			res, err := walletService.SetSpendingLimits(context.Background(), tt.req)
+
+			// Assert
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.req.DailyLimit.GetMinorUnits(), res.DailyLimit.GetMinorUnits())
+			} else {
+				assert.ErrorIs(t, err, ErrSpendingLimitLoosened)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				mockRepo.AssertNotCalled(t, "GetSpending", mock.Anything, mock.Anything)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// Tes untuk GetSpendingLimits saat user belum punya batas
+func TestGetSpendingLimits_NoLimits(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	mockRepo.On("GetSpendingLimit", uint(3)).Return(nil, nil)
+	mockRepo.On("GetSpending", uint(3), mock.AnythingOfType("time.Time")).Return(money.Money(0), nil)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.GetSpendingLimits(context.Background(), &pb.GetSpendingLimitsRequest{UserId: "3"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "3", res.UserId)
+	assert.Equal(t, int64(0), res.DailyLimit.GetMinorUnits())
+	assert.Equal(t, int64(0), res.PerTransactionLimit.GetMinorUnits())
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk Authorize dengan masa berlaku default
+func TestAuthorize_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	before := time.Now()
+	mockRepo.On("CreateHold", mock.MatchedBy(func(h *model.Hold) bool {
+		return h.UserID == 1 && h.Amount == money.FromRupiah(50000) && h.ReferenceID == "res-1" &&
+			!h.ExpiresAt.Before(before.Add(defaultHoldTTL))
+	})).Return(&model.Hold{ID: 7, UserID: 1, Amount: money.FromRupiah(50000), Status: model.HoldStatusActive, ReferenceID: "res-1"}, nil)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.Authorize(context.Background(), &pb.AuthorizeRequest{
+		UserId:      "1",
+		Amount:      money.FromRupiah(50000).ToProto(),
+		ReferenceId: "res-1",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "7", res.Hold.Id)
+	assert.Equal(t, model.HoldStatusActive, res.Hold.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk Authorize saat saldo tersedia tidak cukup
+func TestAuthorize_InsufficientFunds(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	mockRepo.On("CreateHold", mock.Anything).Return(nil, repository.ErrInsufficientFunds)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.Authorize(context.Background(), &pb.AuthorizeRequest{
+		UserId:      "1",
+		Amount:      money.FromRupiah(50000).ToProto(),
+		ReferenceId: "res-1",
+	})
+
+	// Assert
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, repository.ErrInsufficientFunds)
+}
+
+// Tes untuk Authorize dengan permintaan yang tidak valid
+func TestAuthorize_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.AuthorizeRequest
+	}{
+		{"zero amount", &pb.AuthorizeRequest{UserId: "1", Amount: money.Money(0).ToProto(), ReferenceId: "res-1"}},
+		{"missing reference", &pb.AuthorizeRequest{UserId: "1", Amount: money.FromRupiah(1000).ToProto()}},
+		{"negative expiry", &pb.AuthorizeRequest{UserId: "1", Amount: money.FromRupiah(1000).ToProto(), ReferenceId: "res-1", ExpiresInSeconds: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(repository.MockWalletRepository)
+			walletService := NewWalletService(mockRepo, nil)
+
+			// Act
+			_, err := walletService.Authorize(context.Background(), tt.req)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidHold)
+			mockRepo.AssertNotCalled(t, "CreateHold", mock.Anything)
+		})
+	}
+}
+
+// Tes untuk Capture yang mengubah hold menjadi debit transaksi
+func TestCapture_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	hold := &model.Hold{ID: 7, UserID: 1, Amount: money.FromRupiah(50000), Status: model.HoldStatusCaptured, CaptureReference: "99"}
+	mockRepo.On("CaptureHold", uint(7), "99").Return(hold, money.FromRupiah(25000), nil)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.Capture(context.Background(), &pb.CaptureRequest{HoldId: "7", ReferenceId: "99"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, money.FromRupiah(25000).Minor(), res.NewBalance.GetMinorUnits())
+	assert.Equal(t, "99", res.Hold.CaptureReference)
+	mockRepo.AssertExpectations(t)
+}
+
+// Tes untuk Void pada hold yang sudah di-capture
+func TestVoid_AlreadyCaptured(t *testing.T) {
+	// Arrange
+	mockRepo := new(repository.MockWalletRepository)
+	mockRepo.On("VoidHold", uint(7)).Return(nil, repository.ErrHoldCaptured)
+
+	walletService := NewWalletService(mockRepo, nil)
+
+	// Act
+	res, err := walletService.Void(context.Background(), &pb.VoidRequest{HoldId: "7"})
+
+	// Assert
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, repository.ErrHoldCaptured)
+}
@@ -2,63 +2,372 @@ package worker
 
 import (
 	"context"
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"net"
+	"shared/events"
+	"shared/messagebroker"
+	"shared/money"
+	"strings"
+	"time"
+	"wallet-service/internal/model"
+	"wallet-service/internal/repository"
 	"wallet-service/internal/service"
 	pb "wallet-service/proto"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/segmentio/kafka-go"
 )
 
-// StartConsumer memulai worker yang mendengarkan topic Kafka.
-func StartConsumer(ctx context.Context, brokerAddress, topic string, walletService service.WalletService) {
-	r := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: []string{brokerAddress},
-		Topic:   topic,
-		GroupID: "wallet-service-group", // ID grup agar Kafka tahu message mana yang sudah diproses
-	})
+// Topic hasil pembayaran yang dikonsumsi oleh transaction-service.
+const (
+	TopicPaymentSuccess = "payment_success"
+	TopicPaymentFailed  = "payment_failed"
+)
+
+// Kode kegagalan pembayaran di event payment_failed. Batas pengeluaran memakai
+// repository.LimitCode* sebagai kodenya.
+const (
+	FailureCodeInsufficientFunds = "insufficient_funds"
+	FailureCodePaymentError      = "payment_error"
+	FailureCodeHoldExpired       = "authorization_expired"
+	FailureCodeHoldVoided        = "authorization_voided"
+)
+
+// EventProducer adalah nama service ini di envelope event yang dikirim.
+const EventProducer = "wallet-service"
+
+// DeadLetterSuffix ditambahkan ke nama topic asal untuk membentuk nama topic dead-letter.
+const DeadLetterSuffix = ".dlq"
+
+const (
+	defaultMaxAttempts = 5
+	defaultBaseBackoff = 500 * time.Millisecond
+	defaultMaxBackoff  = 10 * time.Second
+)
+
+// DeadLetterMessage adalah isi pesan yang dikirim ke topic dead-letter.
+type DeadLetterMessage struct {
+	OriginalTopic string    `json:"original_topic"`
+	Partition     int       `json:"partition"`
+	Offset        int64     `json:"offset"`
+	Key           string    `json:"key,omitempty"`
+	Payload       string    `json:"payload"`
+	Error         string    `json:"error"`
+	Attempts      int       `json:"attempts"`
+	FailedAt      time.Time `json:"failed_at"`
+}
+
+// permanentError menandai kegagalan yang tidak akan berhasil walaupun dicoba ulang,
+// sehingga pesan langsung dipindahkan ke dead-letter.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// PaymentHandler memproses event transaction.created dengan retry terbatas
+// dan memindahkan pesan yang gagal permanen ke dead-letter.
+type PaymentHandler struct {
+	walletService service.WalletService
+	producer      messagebroker.Producer
+	deadLetters   repository.DeadLetterRepository
 
-	log.Printf("Wallet Kafka consumer started on topic '%s'\n", topic)
+	MaxAttempts int           // Jumlah percobaan maksimal untuk error sementara
+	BaseBackoff time.Duration // Jeda sebelum percobaan kedua, berlipat dua setiap percobaan
+	MaxBackoff  time.Duration
+}
+
+// NewPaymentHandler adalah constructor untuk PaymentHandler dengan retry policy default.
+func NewPaymentHandler(walletService service.WalletService, producer messagebroker.Producer, deadLetters repository.DeadLetterRepository) *PaymentHandler {
+	return &PaymentHandler{
+		walletService: walletService,
+		producer:      producer,
+		deadLetters:   deadLetters,
+		MaxAttempts:   defaultMaxAttempts,
+		BaseBackoff:   defaultBaseBackoff,
+		MaxBackoff:    defaultMaxBackoff,
+	}
+}
+
+// StartConsumer memulai worker yang mendengarkan topic di broker.
+// Offset hanya di-commit setelah pesan selesai ditangani (berhasil, gagal secara bisnis,
+// atau sudah tersimpan di dead-letter), sehingga pesan tidak hilang saat service mati.
+func StartConsumer(ctx context.Context, broker messagebroker.Broker, topic string, handler *PaymentHandler) {
+	// ID grup agar broker tahu message mana yang sudah diproses
+	r := broker.Consumer(topic, "wallet-service-group")
+
+	log.Printf("Wallet consumer started on topic '%s'\n", topic)
 
 	go func() {
 		for {
-			m, err := r.ReadMessage(ctx)
+			m, err := r.FetchMessage(ctx)
 			if err != nil {
 				// Jika context dibatalkan (aplikasi mati), hentikan loop
 				if ctx.Err() != nil {
 					break
 				}
-				log.Println("Could not read message from Kafka: ", err)
+				log.Println("Could not read message from broker: ", err)
 				continue
 			}
 
 			log.Printf("Received Kafka message: %s", string(m.Value))
 
-			var event map[string]interface{}
-			if err := json.Unmarshal(m.Value, &event); err != nil {
-				log.Printf("Failed to unmarshal event: %v", err)
-				continue
+			if err := handler.Process(ctx, m); err != nil {
+				// Hanya terjadi saat aplikasi berhenti; pesan akan dibaca ulang saat start berikutnya
+				log.Printf("Stopped processing message at offset %d: %v", m.Offset, err)
+				break
 			}
-			
-			// Ekstrak data dari event
-			userID, _ := event["user_id"].(string)
-			amount, _ := event["total_amount"].(float64)
-
-			// Panggil logika debit di service
-			_, err = walletService.Debit(context.Background(), &pb.DebitRequest{
-				UserId: userID,
-				Amount: amount,
-			})
-			
-			if err != nil {
-				log.Printf("Failed to process debit for user %s: %v", userID, err)
-				// Di sini Anda bisa mengirim pesan ke topic 'payment_failed'
-			} else {
-				log.Printf("Successfully processed debit for user %s", userID)
-				// Di sini Anda bisa mengirim pesan ke topic 'payment_success'
+
+			if err := r.CommitMessages(ctx, m); err != nil {
+				log.Printf("Failed to commit offset %d: %v", m.Offset, err)
 			}
 		}
 		r.Close()
-		log.Println("Wallet Kafka consumer stopped.")
+		log.Println("Wallet consumer stopped.")
 	}()
-}
\ No newline at end of file
+}
+
+// Process menangani satu pesan sampai selesai. Error sementara dicoba ulang dengan backoff
+// eksponensial; jika tetap gagal atau errornya permanen, pesan disimpan ke dead-letter.
+// Error hanya dikembalikan jika ctx dibatalkan sebelum pesan selesai ditangani.
+func (h *PaymentHandler) Process(ctx context.Context, m kafka.Message) error {
+	for attempt := 1; ; attempt++ {
+		err := h.handle(ctx, m)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) || attempt >= h.MaxAttempts {
+			log.Printf("Giving up on message at offset %d after %d attempt(s): %v", m.Offset, attempt, err)
+			return h.deadLetter(ctx, m, err, attempt)
+		}
+
+		log.Printf("Transient error on message at offset %d (attempt %d/%d): %v", m.Offset, attempt, h.MaxAttempts, err)
+		if err := sleep(ctx, h.backoff(attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// handle melakukan capture (atau debit untuk transaksi tanpa hold) dan mengirim hasil pembayaran.
+func (h *PaymentHandler) handle(ctx context.Context, m kafka.Message) error {
+	// Envelope yang rusak, versi skema yang tidak dikenal, atau payload yang tidak lengkap
+	// tidak akan berhasil walaupun dicoba ulang
+	envelope, err := events.Unmarshal(m.Value)
+	if err != nil {
+		return &permanentError{fmt.Errorf("rejected event: %w", err)}
+	}
+	if envelope.EventType != events.TypeTransactionCreated {
+		return &permanentError{fmt.Errorf("rejected event: unexpected event type %s", envelope.EventType)}
+	}
+	event := envelope.GetTransactionCreated()
+	correlationID := headerValue(m, events.HeaderCorrelationID, event.TransactionId)
+
+	// transaction_id dipakai sebagai referensi idempotensi agar pesan yang terkirim
+	// ulang tidak mendebit dua kali.
+	newBalance, err := h.pay(ctx, event)
+	if err != nil && isTransient(err) {
+		return err
+	}
+
+	// Kirim hasil pembayaran agar transaction-service bisa memfinalisasi transaksi.
+	// Kegagalan publish dicoba ulang; debit tidak akan terulang berkat referensi.
+	if err != nil {
+		log.Printf("Failed to process debit for user %s: %v", event.UserId, err)
+		failed, err := events.NewPaymentFailed(EventProducer, &events.PaymentFailed{
+			TransactionId: event.TransactionId,
+			UserId:        event.UserId,
+			Amount:        event.TotalAmount,
+			Reason:        err.Error(),
+			FailureCode:   failureCode(err),
+		})
+		if err != nil {
+			return err
+		}
+		return h.publish(ctx, TopicPaymentFailed, event.UserId, correlationID, failed)
+	}
+
+	log.Printf("Successfully processed debit for user %s", event.UserId)
+	succeeded, err := events.NewPaymentSucceeded(EventProducer, &events.PaymentSucceeded{
+		TransactionId: event.TransactionId,
+		UserId:        event.UserId,
+		Amount:        event.TotalAmount,
+		NewBalance:    newBalance.ToProto(),
+	})
+	if err != nil {
+		return err
+	}
+	return h.publish(ctx, TopicPaymentSuccess, event.UserId, correlationID, succeeded)
+}
+
+// publish mengirim envelope ke topic sebagai JSON protobuf. Hasil pembayaran di-key dengan
+// user_id, sama seperti transaction.created, agar urutan event per user tetap terjaga.
+func (h *PaymentHandler) publish(ctx context.Context, topic, userID, correlationID string, envelope *events.Envelope) error {
+	value, err := events.Marshal(envelope)
+	if err != nil {
+		return &permanentError{err}
+	}
+	return h.producer.Publish(ctx, messagebroker.Message{
+		Topic:   topic,
+		Key:     userID,
+		Value:   json.RawMessage(value),
+		Headers: events.Headers(envelope, correlationID),
+	})
+}
+
+// headerValue mengambil nilai header pesan, atau fallback jika header tidak ada
+// (misalnya pesan yang dikirim sebelum header dipakai).
+func headerValue(m kafka.Message, key, fallback string) string {
+	for _, header := range m.Headers {
+		if header.Key == key && len(header.Value) > 0 {
+			return string(header.Value)
+		}
+	}
+	return fallback
+}
+
+// pay meng-capture hold transaksi jika ada. Transaksi lama yang dibuat tanpa hold didebit langsung.
+// Pesanan gratis (total 0, mis. kupon 100%) tidak punya hold dan tidak perlu mendebit apa pun,
+// sehingga hanya saldo saat ini yang dikembalikan.
+func (h *PaymentHandler) pay(ctx context.Context, event *events.TransactionCreated) (money.Money, error) {
+	if event.HoldId == "" {
+		amount, err := money.FromProto(event.TotalAmount)
+		if err != nil {
+			return 0, err
+		}
+		if amount == 0 {
+			return h.walletService.GetBalance(ctx, &pb.GetBalanceRequest{UserId: event.UserId})
+		}
+		return h.walletService.Debit(ctx, &pb.DebitRequest{
+			UserId:      event.UserId,
+			Amount:      event.TotalAmount,
+			ReferenceId: event.TransactionId,
+		})
+	}
+
+	response, err := h.walletService.Capture(ctx, &pb.CaptureRequest{
+		HoldId:      event.HoldId,
+		ReferenceId: event.TransactionId,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return money.FromProto(response.NewBalance)
+}
+
+// failureCode menerjemahkan error debit menjadi kode yang bisa dibaca transaction-service.
+func failureCode(err error) string {
+	var limitErr *repository.SpendingLimitError
+	switch {
+	case errors.As(err, &limitErr):
+		return limitErr.Code
+	case errors.Is(err, repository.ErrInsufficientFunds):
+		return FailureCodeInsufficientFunds
+	case errors.Is(err, repository.ErrHoldExpired):
+		return FailureCodeHoldExpired
+	case errors.Is(err, repository.ErrHoldVoided):
+		return FailureCodeHoldVoided
+	default:
+		return FailureCodePaymentError
+	}
+}
+
+// deadLetter menyimpan pesan ke tabel dead_letters dan mengirimnya ke topic dead-letter.
+// Penyimpanan ke database dicoba terus sampai berhasil agar offset tidak di-commit
+// sebelum pesan tersimpan di suatu tempat.
+func (h *PaymentHandler) deadLetter(ctx context.Context, m kafka.Message, cause error, attempts int) error {
+	deadLetter := &model.DeadLetter{
+		Topic:     m.Topic,
+		Partition: m.Partition,
+		Offset:    m.Offset,
+		Key:       string(m.Key),
+		Payload:   m.Value,
+		Error:     cause.Error(),
+		Attempts:  attempts,
+		Status:    model.DeadLetterStatusPending,
+	}
+
+	for retry := 1; ; retry++ {
+		err := h.deadLetters.CreateDeadLetter(deadLetter)
+		if err == nil {
+			break
+		}
+		log.Printf("CRITICAL: Failed to store dead letter for offset %d: %v", m.Offset, err)
+		if err := sleep(ctx, h.backoff(retry)); err != nil {
+			return err
+		}
+	}
+
+	message := DeadLetterMessage{
+		OriginalTopic: m.Topic,
+		Partition:     m.Partition,
+		Offset:        m.Offset,
+		Key:           string(m.Key),
+		Payload:       string(m.Value),
+		Error:         cause.Error(),
+		Attempts:      attempts,
+		FailedAt:      time.Now(),
+	}
+	headers := make(map[string]string, len(m.Headers))
+	for _, header := range m.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	deadLetterMessage := messagebroker.Message{Topic: m.Topic + DeadLetterSuffix, Key: string(m.Key), Value: message, Headers: headers}
+	if err := h.producer.Publish(ctx, deadLetterMessage); err != nil {
+		// Pesan sudah tersimpan di tabel dead_letters dan masih bisa di-replay lewat RPC admin
+		log.Printf("Failed to publish dead letter for offset %d to %s: %v", m.Offset, m.Topic+DeadLetterSuffix, err)
+	}
+	return nil
+}
+
+// backoff menghitung jeda sebelum percobaan berikutnya: BaseBackoff, 2x, 4x, ... maksimal MaxBackoff.
+func (h *PaymentHandler) backoff(attempt int) time.Duration {
+	backoff := h.BaseBackoff
+	for i := 1; i < attempt && backoff < h.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > h.MaxBackoff {
+		return h.MaxBackoff
+	}
+	return backoff
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isTransient menentukan apakah error berasal dari gangguan sementara (timeout, koneksi
+// database terputus, deadlock) sehingga layak dicoba ulang. Error bisnis seperti saldo
+// tidak cukup tidak termasuk dan langsung dilaporkan sebagai payment_failed.
+func isTransient(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	if pgconn.Timeout(err) || pgconn.SafeToRetry(err) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// 08: connection exception, 40001: serialization failure, 40P01: deadlock,
+		// 53: insufficient resources, 57P: server sedang shutdown/restart
+		return strings.HasPrefix(pgErr.Code, "08") || pgErr.Code == "40001" || pgErr.Code == "40P01" ||
+			strings.HasPrefix(pgErr.Code, "53") || strings.HasPrefix(pgErr.Code, "57P")
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,329 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"shared/events"
+	"shared/messagebroker"
+	"shared/money"
+	"strings"
+	"testing"
+	"time"
+	"wallet-service/internal/model"
+	"wallet-service/internal/repository"
+	"wallet-service/internal/service"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func newTestHandler(repo *repository.MockWalletRepository, producer *messagebroker.MockProducer, deadLetters *repository.MockDeadLetterRepository) *PaymentHandler {
+	h := NewPaymentHandler(service.NewWalletService(repo, nil), producer, deadLetters)
+	h.MaxAttempts = 3
+	h.BaseBackoff = time.Millisecond
+	h.MaxBackoff = 2 * time.Millisecond
+	return h
+}
+
+func rawMessage(value string) kafka.Message {
+	return kafka.Message{Topic: "transaction_created", Partition: 0, Offset: 42, Value: []byte(value)}
+}
+
+// transactionCreated membuat pesan transaction.created untuk transaksi 99 milik user 1 sebesar Rp50.000.
+func transactionCreated(holdID string) kafka.Message {
+	envelope, _ := events.NewTransactionCreated("transaction-service", &events.TransactionCreated{
+		TransactionId: "99",
+		UserId:        "1",
+		TotalAmount:   money.FromRupiah(50000).ToProto(),
+		HoldId:        holdID,
+	})
+	value, _ := events.Marshal(envelope)
+	return rawMessage(string(value))
+}
+
+// publishedEvent mencocokkan envelope yang dikirim ke topic, di-key dengan user_id
+// dan membawa correlation ID transaksinya.
+func publishedEvent(topic string, match func(*events.Envelope) bool) interface{} {
+	return mock.MatchedBy(func(message messagebroker.Message) bool {
+		value, ok := message.Value.(json.RawMessage)
+		if !ok || message.Topic != topic || message.Key != "1" || message.Headers[events.HeaderCorrelationID] != "99" {
+			return false
+		}
+		envelope, err := events.Unmarshal(value)
+		return err == nil && envelope.Producer == EventProducer &&
+			message.Headers[events.HeaderEventType] == envelope.EventType && match(envelope)
+	})
+}
+
+// publishedTo mencocokkan pesan apa pun yang dikirim ke topic.
+func publishedTo(topic string) interface{} {
+	return mock.MatchedBy(func(message messagebroker.Message) bool {
+		return message.Topic == topic
+	})
+}
+
+// Tes pesan yang berhasil didebit mengirim payment_success
+func TestProcess_Success(t *testing.T) {
+	repo := new(repository.MockWalletRepository)
+	producer := new(messagebroker.MockProducer)
+	deadLetters := new(repository.MockDeadLetterRepository)
+
+	repo.On("UpdateBalance", mock.MatchedBy(func(c repository.BalanceChange) bool {
+		return c.ReferenceID == "99" && c.Amount == -money.FromRupiah(50000)
+	})).Return(money.FromRupiah(25000), nil)
+	producer.On("Publish", mock.Anything, publishedEvent(TopicPaymentSuccess, func(e *events.Envelope) bool {
+		return e.EventType == events.TypePaymentSucceeded && e.GetPaymentSucceeded().GetTransactionId() == "99"
+	})).Return(nil)
+
+	err := newTestHandler(repo, producer, deadLetters).Process(context.Background(),
+		transactionCreated(""))
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	producer.AssertExpectations(t)
+	deadLetters.AssertNotCalled(t, "CreateDeadLetter", mock.Anything)
+}
+
+// Tes pesanan gratis (total 0, tanpa hold) langsung mengirim payment_success tanpa mengubah saldo
+func TestProcess_FreeOrder(t *testing.T) {
+	repo := new(repository.MockWalletRepository)
+	producer := new(messagebroker.MockProducer)
+	deadLetters := new(repository.MockDeadLetterRepository)
+
+	repo.On("GetBalance", uint(1)).Return(money.FromRupiah(25000), nil)
+	producer.On("Publish", mock.Anything, publishedEvent(TopicPaymentSuccess, func(e *events.Envelope) bool {
+		succeeded := e.GetPaymentSucceeded()
+		return succeeded.GetTransactionId() == "99" && succeeded.GetAmount().GetMinorUnits() == 0 &&
+			succeeded.GetNewBalance().GetMinorUnits() == money.FromRupiah(25000).Minor()
+	})).Return(nil)
+
+	envelope, err := events.NewTransactionCreated("transaction-service", &events.TransactionCreated{
+		TransactionId: "99",
+		UserId:        "1",
+		TotalAmount:   money.FromRupiah(0).ToProto(),
+	})
+	require.NoError(t, err)
+	value, err := events.Marshal(envelope)
+	require.NoError(t, err)
+
+	err = newTestHandler(repo, producer, deadLetters).Process(context.Background(), rawMessage(string(value)))
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "UpdateBalance", mock.Anything)
+	producer.AssertExpectations(t)
+	deadLetters.AssertNotCalled(t, "CreateDeadLetter", mock.Anything)
+}
+
+// Tes kegagalan bisnis (saldo tidak cukup) langsung dilaporkan tanpa retry
+func TestProcess_BusinessFailure(t *testing.T) {
+	repo := new(repository.MockWalletRepository)
+	producer := new(messagebroker.MockProducer)
+	deadLetters := new(repository.MockDeadLetterRepository)
+
+	repo.On("UpdateBalance", mock.Anything).Return(money.Money(0), repository.ErrInsufficientFunds).Once()
+	producer.On("Publish", mock.Anything, publishedEvent(TopicPaymentFailed, func(e *events.Envelope) bool {
+		return e.GetPaymentFailed().GetReason() == "insufficient funds" && e.GetPaymentFailed().GetFailureCode() == FailureCodeInsufficientFunds
+	})).Return(nil)
+
+	err := newTestHandler(repo, producer, deadLetters).Process(context.Background(),
+		transactionCreated(""))
+
+	assert.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "UpdateBalance", 1)
+	producer.AssertExpectations(t)
+	deadLetters.AssertNotCalled(t, "CreateDeadLetter", mock.Anything)
+}
+
+// Tes debit yang melewati batas pengeluaran dilaporkan dengan kode batasnya
+func TestProcess_SpendingLimitExceeded(t *testing.T) {
+	repo := new(repository.MockWalletRepository)
+	producer := new(messagebroker.MockProducer)
+	deadLetters := new(repository.MockDeadLetterRepository)
+
+	limitErr := &repository.SpendingLimitError{Code: repository.LimitCodeDaily, Limit: money.FromRupiah(100000), Spent: money.FromRupiah(80000)}
+	repo.On("UpdateBalance", mock.Anything).Return(money.Money(0), limitErr).Once()
+	producer.On("Publish", mock.Anything, publishedEvent(TopicPaymentFailed, func(e *events.Envelope) bool {
+		return e.GetPaymentFailed().GetFailureCode() == repository.LimitCodeDaily && e.GetPaymentFailed().GetReason() == limitErr.Error()
+	})).Return(nil)
+
+	err := newTestHandler(repo, producer, deadLetters).Process(context.Background(),
+		transactionCreated(""))
+
+	assert.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "UpdateBalance", 1)
+	producer.AssertExpectations(t)
+	deadLetters.AssertNotCalled(t, "CreateDeadLetter", mock.Anything)
+}
+
+// Tes error sementara (deadlock) dicoba ulang sampai berhasil
+func TestProcess_TransientThenSuccess(t *testing.T) {
+	repo := new(repository.MockWalletRepository)
+	producer := new(messagebroker.MockProducer)
+	deadLetters := new(repository.MockDeadLetterRepository)
+
+	repo.On("UpdateBalance", mock.Anything).Return(money.Money(0), &pgconn.PgError{Code: "40P01"}).Once()
+	repo.On("UpdateBalance", mock.Anything).Return(money.FromRupiah(25000), nil).Once()
+	producer.On("Publish", mock.Anything, publishedTo(TopicPaymentSuccess)).Return(nil)
+
+	err := newTestHandler(repo, producer, deadLetters).Process(context.Background(),
+		transactionCreated(""))
+
+	assert.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "UpdateBalance", 2)
+	producer.AssertExpectations(t)
+	deadLetters.AssertNotCalled(t, "CreateDeadLetter", mock.Anything)
+}
+
+// Tes error sementara yang terus berulang berakhir di dead-letter setelah MaxAttempts
+func TestProcess_TransientExhausted(t *testing.T) {
+	repo := new(repository.MockWalletRepository)
+	producer := new(messagebroker.MockProducer)
+	deadLetters := new(repository.MockDeadLetterRepository)
+
+	repo.On("UpdateBalance", mock.Anything).Return(money.Money(0), context.DeadlineExceeded)
+	deadLetters.On("CreateDeadLetter", mock.MatchedBy(func(dl *model.DeadLetter) bool {
+		return dl.Topic == "transaction_created" && dl.Offset == 42 && dl.Attempts == 3 &&
+			dl.Status == model.DeadLetterStatusPending && dl.Error == context.DeadlineExceeded.Error()
+	})).Return(nil)
+	producer.On("Publish", mock.Anything, mock.MatchedBy(func(message messagebroker.Message) bool {
+		_, ok := message.Value.(DeadLetterMessage)
+		return ok && message.Topic == "transaction_created.dlq"
+	})).Return(nil)
+
+	err := newTestHandler(repo, producer, deadLetters).Process(context.Background(),
+		transactionCreated(""))
+
+	assert.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "UpdateBalance", 3)
+	deadLetters.AssertExpectations(t)
+	producer.AssertExpectations(t)
+}
+
+// Tes pesan yang tidak bisa dibaca langsung masuk dead-letter tanpa debit
+func TestProcess_MalformedMessage(t *testing.T) {
+	repo := new(repository.MockWalletRepository)
+	producer := new(messagebroker.MockProducer)
+	deadLetters := new(repository.MockDeadLetterRepository)
+
+	deadLetters.On("CreateDeadLetter", mock.MatchedBy(func(dl *model.DeadLetter) bool {
+		return dl.Attempts == 1 && string(dl.Payload) == `{not json`
+	})).Return(nil)
+	producer.On("Publish", mock.Anything, publishedTo("transaction_created.dlq")).Return(nil)
+
+	err := newTestHandler(repo, producer, deadLetters).Process(context.Background(), rawMessage(`{not json`))
+
+	assert.NoError(t, err)
+	repo.AssertNotCalled(t, "UpdateBalance", mock.Anything)
+	deadLetters.AssertExpectations(t)
+}
+
+// Tes transaksi dengan hold di-capture, bukan didebit ulang
+func TestProcess_CapturesHold(t *testing.T) {
+	repo := new(repository.MockWalletRepository)
+	producer := new(messagebroker.MockProducer)
+	deadLetters := new(repository.MockDeadLetterRepository)
+
+	hold := &model.Hold{ID: 7, UserID: 1, Amount: money.FromRupiah(50000), Status: model.HoldStatusCaptured, CaptureReference: "99"}
+	repo.On("CaptureHold", uint(7), "99").Return(hold, money.FromRupiah(25000), nil)
+	producer.On("Publish", mock.Anything, publishedEvent(TopicPaymentSuccess, func(e *events.Envelope) bool {
+		return e.GetPaymentSucceeded().GetNewBalance().GetMinorUnits() == money.FromRupiah(25000).Minor()
+	})).Return(nil)
+
+	err := newTestHandler(repo, producer, deadLetters).Process(context.Background(),
+		transactionCreated("7"))
+
+	assert.NoError(t, err)
+	repo.AssertNotCalled(t, "UpdateBalance", mock.Anything)
+	repo.AssertExpectations(t)
+	producer.AssertExpectations(t)
+}
+
+// Tes hold yang sudah kedaluwarsa dilaporkan sebagai payment_failed
+func TestProcess_HoldExpired(t *testing.T) {
+	repo := new(repository.MockWalletRepository)
+	producer := new(messagebroker.MockProducer)
+	deadLetters := new(repository.MockDeadLetterRepository)
+
+	repo.On("CaptureHold", uint(7), "99").Return(nil, money.Money(0), repository.ErrHoldExpired)
+	producer.On("Publish", mock.Anything, publishedEvent(TopicPaymentFailed, func(e *events.Envelope) bool {
+		return e.GetPaymentFailed().GetFailureCode() == FailureCodeHoldExpired
+	})).Return(nil)
+
+	err := newTestHandler(repo, producer, deadLetters).Process(context.Background(),
+		transactionCreated("7"))
+
+	assert.NoError(t, err)
+	producer.AssertExpectations(t)
+	deadLetters.AssertNotCalled(t, "CreateDeadLetter", mock.Anything)
+}
+
+// Tes event dengan versi skema yang tidak dikenal langsung masuk dead-letter tanpa debit
+func TestProcess_UnsupportedSchemaVersion(t *testing.T) {
+	repo := new(repository.MockWalletRepository)
+	producer := new(messagebroker.MockProducer)
+	deadLetters := new(repository.MockDeadLetterRepository)
+
+	envelope, _ := events.NewTransactionCreated("transaction-service", &events.TransactionCreated{
+		TransactionId: "99",
+		UserId:        "1",
+		TotalAmount:   money.FromRupiah(50000).ToProto(),
+	})
+	envelope.SchemaVersion = 2
+	value, _ := protojson.Marshal(envelope)
+
+	deadLetters.On("CreateDeadLetter", mock.MatchedBy(func(dl *model.DeadLetter) bool {
+		return dl.Attempts == 1 && strings.Contains(dl.Error, "unsupported event schema version")
+	})).Return(nil)
+	producer.On("Publish", mock.Anything, publishedTo("transaction_created.dlq")).Return(nil)
+
+	err := newTestHandler(repo, producer, deadLetters).Process(context.Background(), rawMessage(string(value)))
+
+	assert.NoError(t, err)
+	repo.AssertNotCalled(t, "UpdateBalance", mock.Anything)
+	deadLetters.AssertExpectations(t)
+}
+
+// Tes consumer wallet lewat broker memory: transaction.created dari transaction-service
+// dikonsumsi worker dan hasilnya terkirim ke payment_success tanpa Kafka
+func TestStartConsumer_MemoryBroker(t *testing.T) {
+	repo := new(repository.MockWalletRepository)
+	deadLetters := new(repository.MockDeadLetterRepository)
+	broker := messagebroker.NewMemoryBroker()
+
+	repo.On("UpdateBalance", mock.MatchedBy(func(c repository.BalanceChange) bool {
+		return c.ReferenceID == "99" && c.Amount == -money.FromRupiah(50000)
+	})).Return(money.FromRupiah(25000), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	StartConsumer(ctx, broker, "transaction_created", NewPaymentHandler(service.NewWalletService(repo, nil), broker, deadLetters))
+
+	envelope, err := events.NewTransactionCreated("transaction-service", &events.TransactionCreated{
+		TransactionId: "99",
+		UserId:        "1",
+		TotalAmount:   money.FromRupiah(50000).ToProto(),
+	})
+	require.NoError(t, err)
+	value, err := events.Marshal(envelope)
+	require.NoError(t, err)
+	require.NoError(t, broker.Publish(ctx, messagebroker.Message{
+		Topic:   "transaction_created",
+		Key:     "1",
+		Value:   json.RawMessage(value),
+		Headers: events.Headers(envelope, "99"),
+	}))
+
+	result, err := broker.Consumer(TopicPaymentSuccess, "test-group").FetchMessage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(result.Key))
+	succeeded, err := events.Unmarshal(result.Value)
+	require.NoError(t, err)
+	assert.Equal(t, "99", succeeded.GetPaymentSucceeded().GetTransactionId())
+	assert.Equal(t, money.FromRupiah(25000).Minor(), succeeded.GetPaymentSucceeded().GetNewBalance().GetMinorUnits())
+	repo.AssertExpectations(t)
+}
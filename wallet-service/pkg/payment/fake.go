@@ -0,0 +1,61 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// FakeProvider adalah payment provider lokal tanpa jaringan untuk development dan testing.
+// Checkout langsung dibuat tanpa memanggil pihak ketiga, dan webhook ditandatangani
+// dengan HMAC-SHA256 (hex) atas body JSON {"reference": "...", "status": "paid"|"failed"}.
+type FakeProvider struct {
+	secret  []byte
+	baseURL string
+}
+
+// NewFakeProvider adalah constructor untuk FakeProvider. baseURL menjadi awalan checkout_url.
+func NewFakeProvider(secret []byte, baseURL string) *FakeProvider {
+	return &FakeProvider{secret: secret, baseURL: baseURL}
+}
+
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakeProvider) CreateCheckout(ctx context.Context, req CheckoutRequest) (*Checkout, error) {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	reference := "fake_" + req.OrderID + "_" + hex.EncodeToString(suffix)
+	return &Checkout{Reference: reference, CheckoutURL: p.baseURL + "/" + reference}, nil
+}
+
+func (p *FakeProvider) ParseWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	if !hmac.Equal([]byte(signature), []byte(p.Sign(payload))) {
+		return nil, ErrInvalidSignature
+	}
+
+	var body struct {
+		Reference string `json:"reference"`
+		Status    string `json:"status"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil || body.Reference == "" {
+		return nil, ErrInvalidPayload
+	}
+	if body.Status != StatusPaid && body.Status != StatusFailed {
+		return nil, ErrInvalidPayload
+	}
+	return &WebhookEvent{Reference: body.Reference, Status: body.Status}, nil
+}
+
+// Sign menghasilkan tanda tangan webhook untuk payload, sama seperti yang dikirim provider.
+func (p *FakeProvider) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,52 @@
+package payment
+
+import (
+	"context"
+	"shared/money"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tes checkout fake menghasilkan referensi unik dan URL pembayaran
+func TestFakeProvider_CreateCheckout(t *testing.T) {
+	provider := NewFakeProvider([]byte("secret"), "http://localhost:8000/fake-checkout")
+
+	first, err := provider.CreateCheckout(context.Background(), CheckoutRequest{OrderID: "12", UserID: "1", Amount: money.FromRupiah(50000)})
+	assert.NoError(t, err)
+	second, err := provider.CreateCheckout(context.Background(), CheckoutRequest{OrderID: "12", UserID: "1", Amount: money.FromRupiah(50000)})
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(first.Reference, "fake_12_"))
+	assert.Equal(t, "http://localhost:8000/fake-checkout/"+first.Reference, first.CheckoutURL)
+	assert.NotEqual(t, first.Reference, second.Reference)
+}
+
+// Tes webhook dengan tanda tangan yang benar bisa dibaca
+func TestFakeProvider_ParseWebhook(t *testing.T) {
+	provider := NewFakeProvider([]byte("secret"), "")
+	payload := []byte(`{"reference":"fake_12_abc","status":"paid"}`)
+
+	event, err := provider.ParseWebhook(payload, provider.Sign(payload))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "fake_12_abc", event.Reference)
+	assert.Equal(t, StatusPaid, event.Status)
+}
+
+// Tes webhook dengan tanda tangan salah atau isi tidak valid ditolak
+func TestFakeProvider_ParseWebhook_Rejected(t *testing.T) {
+	provider := NewFakeProvider([]byte("secret"), "")
+	payload := []byte(`{"reference":"fake_12_abc","status":"paid"}`)
+
+	_, err := provider.ParseWebhook(payload, NewFakeProvider([]byte("other"), "").Sign(payload))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	_, err = provider.ParseWebhook([]byte(`{"reference":"fake_12_abc","status":"paid","x":1}`), provider.Sign(payload))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	unknown := []byte(`{"reference":"fake_12_abc","status":"refunded"}`)
+	_, err = provider.ParseWebhook(unknown, provider.Sign(unknown))
+	assert.ErrorIs(t, err, ErrInvalidPayload)
+}
@@ -0,0 +1,49 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"shared/money"
+)
+
+// Status pembayaran yang dilaporkan provider melalui webhook.
+const (
+	StatusPaid   = "paid"
+	StatusFailed = "failed"
+)
+
+// Error yang dikembalikan ParseWebhook.
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+)
+
+// CheckoutRequest berisi data top-up yang akan dibayar user.
+type CheckoutRequest struct {
+	OrderID string // ID top-up di wallet-service
+	UserID  string
+	Amount  money.Money
+}
+
+// Checkout adalah pembayaran yang sudah dibuat di provider dan menunggu diselesaikan user.
+type Checkout struct {
+	Reference   string // Referensi unik dari provider, dikirim kembali di webhook
+	CheckoutURL string
+}
+
+// WebhookEvent adalah hasil pembayaran yang sudah diverifikasi dari webhook provider.
+type WebhookEvent struct {
+	Reference string
+	Status    string // StatusPaid atau StatusFailed
+}
+
+// Provider adalah payment provider yang memproses pembayaran top-up.
+// Implementasi baru cukup memenuhi interface ini dan dipilih lewat PAYMENT_PROVIDER.
+type Provider interface {
+	// Name mengembalikan nama provider yang disimpan di setiap top-up.
+	Name() string
+	// CreateCheckout membuat pembayaran baru di provider.
+	CreateCheckout(ctx context.Context, req CheckoutRequest) (*Checkout, error)
+	// ParseWebhook memverifikasi tanda tangan webhook lalu membaca isinya.
+	ParseWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
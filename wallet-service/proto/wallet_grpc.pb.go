@@ -24,12 +24,40 @@ const _ = grpc.SupportPackageIsVersion7
 type WalletServiceClient interface {
 	// Mendapatkan saldo pengguna
 	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
-	// Melakukan top-up saldo
+	// Membuat top-up yang menunggu pembayaran di payment provider
 	TopUp(ctx context.Context, in *TopUpRequest, opts ...grpc.CallOption) (*TopUpResponse, error)
+	// Dipanggil gateway saat payment provider mengirim webhook; hanya pembayaran sukses yang menambah saldo
+	HandlePaymentWebhook(ctx context.Context, in *PaymentWebhookRequest, opts ...grpc.CallOption) (*PaymentWebhookResponse, error)
+	// Mendapatkan riwayat top-up pengguna
+	ListTopUps(ctx context.Context, in *ListTopUpsRequest, opts ...grpc.CallOption) (*ListTopUpsResponse, error)
+	// Mendapatkan detail satu top-up (bukti top-up) milik pengguna
+	GetTopUp(ctx context.Context, in *GetTopUpRequest, opts ...grpc.CallOption) (*GetTopUpResponse, error)
 	// Digunakan oleh service lain (seperti transaction-service) untuk mengurangi saldo
 	Debit(ctx context.Context, in *DebitRequest, opts ...grpc.CallOption) (*DebitResponse, error)
 	// Digunakan untuk mengembalikan dana (refund/rollback)
 	Credit(ctx context.Context, in *CreditRequest, opts ...grpc.CallOption) (*CreditResponse, error)
+	// Mendapatkan riwayat perubahan saldo (ledger) pengguna
+	GetLedger(ctx context.Context, in *GetLedgerRequest, opts ...grpc.CallOption) (*GetLedgerResponse, error)
+	// Admin: daftar pesan Kafka yang gagal diproses secara permanen (dead-letter)
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	// Admin: kirim ulang payload asli pesan dead-letter ke topic asalnya
+	ReplayDeadLetter(ctx context.Context, in *ReplayDeadLetterRequest, opts ...grpc.CallOption) (*ReplayDeadLetterResponse, error)
+	// Digunakan oleh rekonsiliasi transaction-service: semua debit dan refund milik transaksi tertentu
+	ListTransactionMovements(ctx context.Context, in *ListTransactionMovementsRequest, opts ...grpc.CallOption) (*ListTransactionMovementsResponse, error)
+	// Memindahkan saldo dari satu pengguna ke pengguna lain secara atomik
+	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	// Mendapatkan riwayat transfer (masuk dan keluar) milik pengguna
+	ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error)
+	// Mengatur batas pengeluaran harian, bulanan, dan per transaksi milik pengguna
+	SetSpendingLimits(ctx context.Context, in *SetSpendingLimitsRequest, opts ...grpc.CallOption) (*SpendingLimitsResponse, error)
+	// Mendapatkan batas pengeluaran beserta pemakaiannya hari ini dan bulan ini
+	GetSpendingLimits(ctx context.Context, in *GetSpendingLimitsRequest, opts ...grpc.CallOption) (*SpendingLimitsResponse, error)
+	// Mencadangkan dana untuk pembelian (hold): saldo tersedia berkurang, saldo tidak berubah
+	Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*HoldResponse, error)
+	// Mengubah hold aktif menjadi debit
+	Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*CaptureResponse, error)
+	// Membatalkan hold sehingga dananya kembali tersedia
+	Void(ctx context.Context, in *VoidRequest, opts ...grpc.CallOption) (*HoldResponse, error)
 }
 
 type walletServiceClient struct {
@@ -58,6 +86,33 @@ func (c *walletServiceClient) TopUp(ctx context.Context, in *TopUpRequest, opts
 	return out, nil
 }
 
+func (c *walletServiceClient) HandlePaymentWebhook(ctx context.Context, in *PaymentWebhookRequest, opts ...grpc.CallOption) (*PaymentWebhookResponse, error) {
+	out := new(PaymentWebhookResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/HandlePaymentWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListTopUps(ctx context.Context, in *ListTopUpsRequest, opts ...grpc.CallOption) (*ListTopUpsResponse, error) {
+	out := new(ListTopUpsResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/ListTopUps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetTopUp(ctx context.Context, in *GetTopUpRequest, opts ...grpc.CallOption) (*GetTopUpResponse, error) {
+	out := new(GetTopUpResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/GetTopUp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletServiceClient) Debit(ctx context.Context, in *DebitRequest, opts ...grpc.CallOption) (*DebitResponse, error) {
 	out := new(DebitResponse)
 	err := c.cc.Invoke(ctx, "/wallet.WalletService/Debit", in, out, opts...)
@@ -76,18 +131,145 @@ func (c *walletServiceClient) Credit(ctx context.Context, in *CreditRequest, opt
 	return out, nil
 }
 
+func (c *walletServiceClient) GetLedger(ctx context.Context, in *GetLedgerRequest, opts ...grpc.CallOption) (*GetLedgerResponse, error) {
+	out := new(GetLedgerResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/GetLedger", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/ListDeadLetters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ReplayDeadLetter(ctx context.Context, in *ReplayDeadLetterRequest, opts ...grpc.CallOption) (*ReplayDeadLetterResponse, error) {
+	out := new(ReplayDeadLetterResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/ReplayDeadLetter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListTransactionMovements(ctx context.Context, in *ListTransactionMovementsRequest, opts ...grpc.CallOption) (*ListTransactionMovementsResponse, error) {
+	out := new(ListTransactionMovementsResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/ListTransactionMovements", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/Transfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListTransfers(ctx context.Context, in *ListTransfersRequest, opts ...grpc.CallOption) (*ListTransfersResponse, error) {
+	out := new(ListTransfersResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/ListTransfers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SetSpendingLimits(ctx context.Context, in *SetSpendingLimitsRequest, opts ...grpc.CallOption) (*SpendingLimitsResponse, error) {
+	out := new(SpendingLimitsResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/SetSpendingLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetSpendingLimits(ctx context.Context, in *GetSpendingLimitsRequest, opts ...grpc.CallOption) (*SpendingLimitsResponse, error) {
+	out := new(SpendingLimitsResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/GetSpendingLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*HoldResponse, error) {
+	out := new(HoldResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/Authorize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*CaptureResponse, error) {
+	out := new(CaptureResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/Capture", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Void(ctx context.Context, in *VoidRequest, opts ...grpc.CallOption) (*HoldResponse, error) {
+	out := new(HoldResponse)
+	err := c.cc.Invoke(ctx, "/wallet.WalletService/Void", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WalletServiceServer is the server API for WalletService service.
 // All implementations must embed UnimplementedWalletServiceServer
 // for forward compatibility
 type WalletServiceServer interface {
 	// Mendapatkan saldo pengguna
 	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
-	// Melakukan top-up saldo
+	// Membuat top-up yang menunggu pembayaran di payment provider
 	TopUp(context.Context, *TopUpRequest) (*TopUpResponse, error)
+	// Dipanggil gateway saat payment provider mengirim webhook; hanya pembayaran sukses yang menambah saldo
+	HandlePaymentWebhook(context.Context, *PaymentWebhookRequest) (*PaymentWebhookResponse, error)
+	// Mendapatkan riwayat top-up pengguna
+	ListTopUps(context.Context, *ListTopUpsRequest) (*ListTopUpsResponse, error)
+	// Mendapatkan detail satu top-up (bukti top-up) milik pengguna
+	GetTopUp(context.Context, *GetTopUpRequest) (*GetTopUpResponse, error)
 	// Digunakan oleh service lain (seperti transaction-service) untuk mengurangi saldo
 	Debit(context.Context, *DebitRequest) (*DebitResponse, error)
 	// Digunakan untuk mengembalikan dana (refund/rollback)
 	Credit(context.Context, *CreditRequest) (*CreditResponse, error)
+	// Mendapatkan riwayat perubahan saldo (ledger) pengguna
+	GetLedger(context.Context, *GetLedgerRequest) (*GetLedgerResponse, error)
+	// Admin: daftar pesan Kafka yang gagal diproses secara permanen (dead-letter)
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	// Admin: kirim ulang payload asli pesan dead-letter ke topic asalnya
+	ReplayDeadLetter(context.Context, *ReplayDeadLetterRequest) (*ReplayDeadLetterResponse, error)
+	// Digunakan oleh rekonsiliasi transaction-service: semua debit dan refund milik transaksi tertentu
+	ListTransactionMovements(context.Context, *ListTransactionMovementsRequest) (*ListTransactionMovementsResponse, error)
+	// Memindahkan saldo dari satu pengguna ke pengguna lain secara atomik
+	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
+	// Mendapatkan riwayat transfer (masuk dan keluar) milik pengguna
+	ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error)
+	// Mengatur batas pengeluaran harian, bulanan, dan per transaksi milik pengguna
+	SetSpendingLimits(context.Context, *SetSpendingLimitsRequest) (*SpendingLimitsResponse, error)
+	// Mendapatkan batas pengeluaran beserta pemakaiannya hari ini dan bulan ini
+	GetSpendingLimits(context.Context, *GetSpendingLimitsRequest) (*SpendingLimitsResponse, error)
+	// Mencadangkan dana untuk pembelian (hold): saldo tersedia berkurang, saldo tidak berubah
+	Authorize(context.Context, *AuthorizeRequest) (*HoldResponse, error)
+	// Mengubah hold aktif menjadi debit
+	Capture(context.Context, *CaptureRequest) (*CaptureResponse, error)
+	// Membatalkan hold sehingga dananya kembali tersedia
+	Void(context.Context, *VoidRequest) (*HoldResponse, error)
 	mustEmbedUnimplementedWalletServiceServer()
 }
 
@@ -101,12 +283,54 @@ func (UnimplementedWalletServiceServer) GetBalance(context.Context, *GetBalanceR
 func (UnimplementedWalletServiceServer) TopUp(context.Context, *TopUpRequest) (*TopUpResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TopUp not implemented")
 }
+func (UnimplementedWalletServiceServer) HandlePaymentWebhook(context.Context, *PaymentWebhookRequest) (*PaymentWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandlePaymentWebhook not implemented")
+}
+func (UnimplementedWalletServiceServer) ListTopUps(context.Context, *ListTopUpsRequest) (*ListTopUpsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTopUps not implemented")
+}
+func (UnimplementedWalletServiceServer) GetTopUp(context.Context, *GetTopUpRequest) (*GetTopUpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopUp not implemented")
+}
 func (UnimplementedWalletServiceServer) Debit(context.Context, *DebitRequest) (*DebitResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Debit not implemented")
 }
 func (UnimplementedWalletServiceServer) Credit(context.Context, *CreditRequest) (*CreditResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Credit not implemented")
 }
+func (UnimplementedWalletServiceServer) GetLedger(context.Context, *GetLedgerRequest) (*GetLedgerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLedger not implemented")
+}
+func (UnimplementedWalletServiceServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedWalletServiceServer) ReplayDeadLetter(context.Context, *ReplayDeadLetterRequest) (*ReplayDeadLetterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDeadLetter not implemented")
+}
+func (UnimplementedWalletServiceServer) ListTransactionMovements(context.Context, *ListTransactionMovementsRequest) (*ListTransactionMovementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactionMovements not implemented")
+}
+func (UnimplementedWalletServiceServer) Transfer(context.Context, *TransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transfer not implemented")
+}
+func (UnimplementedWalletServiceServer) ListTransfers(context.Context, *ListTransfersRequest) (*ListTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransfers not implemented")
+}
+func (UnimplementedWalletServiceServer) SetSpendingLimits(context.Context, *SetSpendingLimitsRequest) (*SpendingLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSpendingLimits not implemented")
+}
+func (UnimplementedWalletServiceServer) GetSpendingLimits(context.Context, *GetSpendingLimitsRequest) (*SpendingLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSpendingLimits not implemented")
+}
+func (UnimplementedWalletServiceServer) Authorize(context.Context, *AuthorizeRequest) (*HoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
+}
+func (UnimplementedWalletServiceServer) Capture(context.Context, *CaptureRequest) (*CaptureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capture not implemented")
+}
+func (UnimplementedWalletServiceServer) Void(context.Context, *VoidRequest) (*HoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Void not implemented")
+}
 func (UnimplementedWalletServiceServer) mustEmbedUnimplementedWalletServiceServer() {}
 
 // UnsafeWalletServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -156,6 +380,60 @@ func _WalletService_TopUp_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletService_HandlePaymentWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).HandlePaymentWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/HandlePaymentWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).HandlePaymentWebhook(ctx, req.(*PaymentWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListTopUps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopUpsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListTopUps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/ListTopUps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListTopUps(ctx, req.(*ListTopUpsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetTopUp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopUpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetTopUp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/GetTopUp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetTopUp(ctx, req.(*GetTopUpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WalletService_Debit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DebitRequest)
 	if err := dec(in); err != nil {
@@ -192,6 +470,204 @@ func _WalletService_Credit_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletService_GetLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLedgerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/GetLedger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetLedger(ctx, req.(*GetLedgerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/ListDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ReplayDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayDeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ReplayDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/ReplayDeadLetter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ReplayDeadLetter(ctx, req.(*ReplayDeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListTransactionMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListTransactionMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/ListTransactionMovements",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListTransactionMovements(ctx, req.(*ListTransactionMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/Transfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/ListTransfers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListTransfers(ctx, req.(*ListTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SetSpendingLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSpendingLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetSpendingLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/SetSpendingLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetSpendingLimits(ctx, req.(*SetSpendingLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetSpendingLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSpendingLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetSpendingLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/GetSpendingLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetSpendingLimits(ctx, req.(*GetSpendingLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Authorize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/Authorize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Authorize(ctx, req.(*AuthorizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Capture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Capture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/Capture",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Capture(ctx, req.(*CaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Void_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Void(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wallet.WalletService/Void",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Void(ctx, req.(*VoidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WalletService_ServiceDesc is the grpc.ServiceDesc for WalletService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -207,6 +683,18 @@ var WalletService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TopUp",
 			Handler:    _WalletService_TopUp_Handler,
 		},
+		{
+			MethodName: "HandlePaymentWebhook",
+			Handler:    _WalletService_HandlePaymentWebhook_Handler,
+		},
+		{
+			MethodName: "ListTopUps",
+			Handler:    _WalletService_ListTopUps_Handler,
+		},
+		{
+			MethodName: "GetTopUp",
+			Handler:    _WalletService_GetTopUp_Handler,
+		},
 		{
 			MethodName: "Debit",
 			Handler:    _WalletService_Debit_Handler,
@@ -215,6 +703,50 @@ var WalletService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Credit",
 			Handler:    _WalletService_Credit_Handler,
 		},
+		{
+			MethodName: "GetLedger",
+			Handler:    _WalletService_GetLedger_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _WalletService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "ReplayDeadLetter",
+			Handler:    _WalletService_ReplayDeadLetter_Handler,
+		},
+		{
+			MethodName: "ListTransactionMovements",
+			Handler:    _WalletService_ListTransactionMovements_Handler,
+		},
+		{
+			MethodName: "Transfer",
+			Handler:    _WalletService_Transfer_Handler,
+		},
+		{
+			MethodName: "ListTransfers",
+			Handler:    _WalletService_ListTransfers_Handler,
+		},
+		{
+			MethodName: "SetSpendingLimits",
+			Handler:    _WalletService_SetSpendingLimits_Handler,
+		},
+		{
+			MethodName: "GetSpendingLimits",
+			Handler:    _WalletService_GetSpendingLimits_Handler,
+		},
+		{
+			MethodName: "Authorize",
+			Handler:    _WalletService_Authorize_Handler,
+		},
+		{
+			MethodName: "Capture",
+			Handler:    _WalletService_Capture_Handler,
+		},
+		{
+			MethodName: "Void",
+			Handler:    _WalletService_Void_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/wallet.proto",